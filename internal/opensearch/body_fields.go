@@ -0,0 +1,134 @@
+package opensearch
+
+// Types of JSON body fields.
+const (
+	BodyFieldString = "string"
+	BodyFieldObject = "object"
+	BodyFieldArray  = "array"
+)
+
+// BodyField describes a field of a JSON request body, so bodies can be checked before they are sent.
+// Fields which are not described are left to OpenSearch to validate.
+type BodyField struct {
+	Name     string
+	Type     string
+	Required bool
+	// Fields of an object, or of each object in an array.
+	Fields []BodyField
+}
+
+// ConnectorBodyFields describe the body of POST /_plugins/_ml/connectors/_create.
+var ConnectorBodyFields = []BodyField{
+	{Name: "name", Type: BodyFieldString, Required: true},
+	{Name: "description", Type: BodyFieldString},
+	{Name: "protocol", Type: BodyFieldString, Required: true},
+	{Name: "parameters", Type: BodyFieldObject},
+	{Name: "credential", Type: BodyFieldObject},
+	{Name: "actions", Type: BodyFieldArray, Required: true, Fields: []BodyField{
+		{Name: "action_type", Type: BodyFieldString, Required: true},
+		{Name: "method", Type: BodyFieldString, Required: true},
+		{Name: "url", Type: BodyFieldString, Required: true},
+		{Name: "headers", Type: BodyFieldObject},
+		{Name: "request_body", Type: BodyFieldString},
+		{Name: "pre_process_function", Type: BodyFieldString},
+		{Name: "post_process_function", Type: BodyFieldString},
+	}},
+	{Name: "client_config", Type: BodyFieldObject},
+}
+
+// ModelRegisterBodyFields describe the body of POST /_plugins/_ml/models/_register.
+// Pretrained models are registered by name alone, so function_name is optional.
+var ModelRegisterBodyFields = []BodyField{
+	{Name: "name", Type: BodyFieldString, Required: true},
+	{Name: "function_name", Type: BodyFieldString},
+	{Name: "description", Type: BodyFieldString},
+	{Name: "model_group_id", Type: BodyFieldString},
+	{Name: "connector_id", Type: BodyFieldString},
+	{Name: "connector", Type: BodyFieldObject, Fields: ConnectorBodyFields},
+	{Name: "model_config", Type: BodyFieldObject},
+	{Name: "guardrails", Type: BodyFieldObject},
+	{Name: "rate_limiter", Type: BodyFieldObject, Fields: []BodyField{
+		{Name: "limit", Type: BodyFieldString, Required: true},
+		{Name: "unit", Type: BodyFieldString, Required: true},
+	}},
+}
+
+// IndexTemplateBodyFields describe the body of PUT /_index_template/<name>.
+var IndexTemplateBodyFields = []BodyField{
+	{Name: "index_patterns", Type: BodyFieldArray, Required: true},
+	{Name: "template", Type: BodyFieldObject, Fields: []BodyField{
+		{Name: "settings", Type: BodyFieldObject},
+		{Name: "mappings", Type: BodyFieldObject},
+		{Name: "aliases", Type: BodyFieldObject},
+	}},
+	{Name: "composed_of", Type: BodyFieldArray},
+	{Name: "data_stream", Type: BodyFieldObject},
+	{Name: "_meta", Type: BodyFieldObject},
+}
+
+// ComponentTemplateBodyFields describe the body of PUT /_component_template/<name>.
+var ComponentTemplateBodyFields = []BodyField{
+	{Name: "template", Type: BodyFieldObject, Required: true, Fields: []BodyField{
+		{Name: "settings", Type: BodyFieldObject},
+		{Name: "mappings", Type: BodyFieldObject},
+		{Name: "aliases", Type: BodyFieldObject},
+	}},
+	{Name: "_meta", Type: BodyFieldObject},
+}
+
+// ISMPolicyBodyFields describe the body of PUT /_plugins/_ism/policies/<policy_id>.
+var ISMPolicyBodyFields = []BodyField{
+	{Name: "policy", Type: BodyFieldObject, Required: true, Fields: []BodyField{
+		{Name: "description", Type: BodyFieldString},
+		{Name: "default_state", Type: BodyFieldString, Required: true},
+		{Name: "states", Type: BodyFieldArray, Required: true, Fields: []BodyField{
+			{Name: "name", Type: BodyFieldString, Required: true},
+			{Name: "actions", Type: BodyFieldArray},
+			{Name: "transitions", Type: BodyFieldArray},
+		}},
+	}},
+}
+
+// SnapshotPolicyBodyFields describe the body of POST /_plugins/_sm/policies/<name>.
+var SnapshotPolicyBodyFields = []BodyField{
+	{Name: "description", Type: BodyFieldString},
+	{Name: "creation", Type: BodyFieldObject, Required: true, Fields: []BodyField{
+		{Name: "schedule", Type: BodyFieldObject, Required: true},
+		{Name: "time_limit", Type: BodyFieldString},
+	}},
+	{Name: "deletion", Type: BodyFieldObject, Fields: []BodyField{
+		{Name: "schedule", Type: BodyFieldObject},
+		{Name: "condition", Type: BodyFieldObject},
+		{Name: "time_limit", Type: BodyFieldString},
+	}},
+	{Name: "snapshot_config", Type: BodyFieldObject, Required: true, Fields: []BodyField{
+		{Name: "repository", Type: BodyFieldString, Required: true},
+		{Name: "indices", Type: BodyFieldString},
+	}},
+	{Name: "notification", Type: BodyFieldObject},
+}
+
+// IngestPipelineBodyFields describe the body of PUT /_ingest/pipeline/<name>.
+var IngestPipelineBodyFields = []BodyField{
+	{Name: "description", Type: BodyFieldString},
+	{Name: "processors", Type: BodyFieldArray, Required: true},
+	{Name: "on_failure", Type: BodyFieldArray},
+}
+
+// SearchPipelineBodyFields describe the body of PUT /_search/pipeline/<name>.
+var SearchPipelineBodyFields = []BodyField{
+	{Name: "description", Type: BodyFieldString},
+	{Name: "request_processors", Type: BodyFieldArray},
+	{Name: "response_processors", Type: BodyFieldArray},
+	{Name: "phase_results_processors", Type: BodyFieldArray},
+}
+
+// MonitorBodyFields describe the body of POST /_plugins/_alerting/monitors.
+var MonitorBodyFields = []BodyField{
+	{Name: "type", Type: BodyFieldString},
+	{Name: "monitor_type", Type: BodyFieldString},
+	{Name: "name", Type: BodyFieldString, Required: true},
+	{Name: "schedule", Type: BodyFieldObject, Required: true},
+	{Name: "inputs", Type: BodyFieldArray, Required: true},
+	{Name: "triggers", Type: BodyFieldArray},
+}
@@ -0,0 +1,143 @@
+package opensearch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+const (
+	BulkActionIndex  = "index"
+	BulkActionCreate = "create"
+	BulkActionUpdate = "update"
+	BulkActionDelete = "delete"
+)
+
+// BulkOperation is one action of a POST /_bulk request. Document is the source for index and create,
+// and the update body (e.g. {"doc": {...}}) for update. Delete has no document.
+type BulkOperation struct {
+	Action   string
+	Index    string
+	ID       string
+	Document any
+}
+
+type bulkActionMetadata struct {
+	Index string `json:"_index,omitempty"`
+	ID    string `json:"_id,omitempty"`
+}
+
+// BulkBody encodes the operations as the newline delimited JSON POST /_bulk expects, which must end with a newline.
+func BulkBody(operations []BulkOperation) ([]byte, error) {
+	var body bytes.Buffer
+
+	for i, operation := range operations {
+		switch operation.Action {
+		case BulkActionIndex, BulkActionCreate, BulkActionUpdate, BulkActionDelete:
+		default:
+			return nil, fmt.Errorf("operation %d has the unknown action %q", i, operation.Action)
+		}
+
+		if operation.Action != BulkActionIndex && operation.Action != BulkActionCreate && operation.ID == "" {
+			return nil, fmt.Errorf("operation %d is a %s without an _id", i, operation.Action)
+		}
+
+		action, err := json.Marshal(map[string]bulkActionMetadata{
+			operation.Action: {Index: operation.Index, ID: operation.ID},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not encode operation %d: %w", i, err)
+		}
+
+		body.Write(action)
+		body.WriteByte('\n')
+
+		if operation.Action == BulkActionDelete {
+			continue
+		}
+
+		// Documents must be on a single line, which json.Marshal guarantees.
+		document, err := json.Marshal(operation.Document)
+		if err != nil {
+			return nil, fmt.Errorf("could not encode the document of operation %d: %w", i, err)
+		}
+
+		body.Write(document)
+		body.WriteByte('\n')
+	}
+
+	return body.Bytes(), nil
+}
+
+// BulkResponse is returned by POST /_bulk with a 200 even when some items failed, which is flagged by Errors.
+// Each item is keyed by its action, in the order of the request.
+type BulkResponse struct {
+	Took   int64                       `json:"took"`
+	Errors bool                        `json:"errors"`
+	Items  []map[string]BulkItemResult `json:"items"`
+}
+
+type BulkItemResult struct {
+	Index  string         `json:"_index"`
+	ID     string         `json:"_id"`
+	Status int            `json:"status"`
+	Result string         `json:"result,omitempty"`
+	Error  *BulkItemError `json:"error,omitempty"`
+}
+
+type BulkItemError struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// BulkItemFailure is an item of a bulk request which failed, with its position in the request.
+type BulkItemFailure struct {
+	Position int
+	Action   string
+	BulkItemResult
+}
+
+// String returns the failure in the form "<action> <index>/<id> (item <n>): <status> <type>: <reason>".
+func (f BulkItemFailure) String() string {
+	reason := "no reason given"
+	if f.Error != nil {
+		reason = fmt.Sprintf("%s: %s", f.Error.Type, f.Error.Reason)
+	}
+
+	return fmt.Sprintf("%s %s/%s (item %d): %d %s", f.Action, f.Index, f.ID, f.Position, f.Status, reason)
+}
+
+// Failures returns the items which failed, i.e. have an error or a status outside 2xx, in request order.
+func (r BulkResponse) Failures() []BulkItemFailure {
+	if !r.Errors {
+		return nil
+	}
+
+	var failures []BulkItemFailure
+
+	for position, item := range r.Items {
+		// Each item has a single action, sorted only so the result is deterministic.
+		actions := make([]string, 0, len(item))
+		for action := range item {
+			actions = append(actions, action)
+		}
+
+		sort.Strings(actions)
+
+		for _, action := range actions {
+			result := item[action]
+			if result.Error == nil && result.Status >= 200 && result.Status < 300 {
+				continue
+			}
+
+			failures = append(failures, BulkItemFailure{
+				Position:       position,
+				Action:         action,
+				BulkItemResult: result,
+			})
+		}
+	}
+
+	return failures
+}
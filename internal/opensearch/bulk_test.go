@@ -0,0 +1,105 @@
+package opensearch
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestBulkBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		operations []BulkOperation
+		want       string
+		wantErr    bool
+	}{
+		{
+			name: "every action",
+			operations: []BulkOperation{
+				{Action: BulkActionIndex, Index: "seed", ID: "1", Document: map[string]any{"text": "first"}},
+				{Action: BulkActionCreate, Index: "seed", Document: map[string]any{"text": "second"}},
+				{Action: BulkActionUpdate, Index: "seed", ID: "1", Document: map[string]any{"doc": map[string]any{"text": "updated"}}},
+				{Action: BulkActionDelete, Index: "seed", ID: "2"},
+			},
+			want: `{"index":{"_index":"seed","_id":"1"}}` + "\n" +
+				`{"text":"first"}` + "\n" +
+				`{"create":{"_index":"seed"}}` + "\n" +
+				`{"text":"second"}` + "\n" +
+				`{"update":{"_index":"seed","_id":"1"}}` + "\n" +
+				`{"doc":{"text":"updated"}}` + "\n" +
+				`{"delete":{"_index":"seed","_id":"2"}}` + "\n",
+		},
+		{
+			name:       "unknown action",
+			operations: []BulkOperation{{Action: "upsert", Index: "seed", ID: "1"}},
+			wantErr:    true,
+		},
+		{
+			name:       "delete without an ID",
+			operations: []BulkOperation{{Action: BulkActionDelete, Index: "seed"}},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BulkBody(tt.operations)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected an error %t, got %v", tt.wantErr, err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("expected body:\n%s\ngot:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBulkResponseFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     []string
+	}{
+		{
+			name: "mixed items",
+			response: `{"took":12,"errors":true,"items":[
+				{"index":{"_index":"seed","_id":"1","status":201,"result":"created"}},
+				{"create":{"_index":"seed","_id":"2","status":409,"error":{"type":"version_conflict_engine_exception","reason":"[2]: version conflict, document already exists"}}},
+				{"update":{"_index":"seed","_id":"3","status":200,"result":"updated"}},
+				{"index":{"_index":"seed","_id":"4","status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse field [count] of type [long]"}}},
+				{"delete":{"_index":"seed","_id":"5","status":404,"result":"not_found"}}
+			]}`,
+			want: []string{
+				"create seed/2 (item 1): 409 version_conflict_engine_exception: [2]: version conflict, document already exists",
+				"index seed/4 (item 3): 400 mapper_parsing_exception: failed to parse field [count] of type [long]",
+				"delete seed/5 (item 4): 404 no reason given",
+			},
+		},
+		{
+			name: "every item succeeded",
+			response: `{"took":3,"errors":false,"items":[
+				{"index":{"_index":"seed","_id":"1","status":201,"result":"created"}},
+				{"delete":{"_index":"seed","_id":"2","status":200,"result":"deleted"}}
+			]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var response BulkResponse
+			if err := json.Unmarshal([]byte(tt.response), &response); err != nil {
+				t.Fatalf("could not parse response: %s", err)
+			}
+
+			var got []string
+			for _, failure := range response.Failures() {
+				got = append(got, failure.String())
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected failures %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
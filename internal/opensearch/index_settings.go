@@ -0,0 +1,149 @@
+package opensearch
+
+import "strings"
+
+// Index settings which can only be set when an index is created (or while it is closed),
+// so changing them means recreating the index.
+// https://opensearch.org/docs/latest/install-and-configure/configuring-opensearch/index-settings/
+var staticIndexSettings = map[string]bool{
+	"number_of_shards":                  true,
+	"number_of_routing_shards":          true,
+	"routing_partition_size":            true,
+	"codec":                             true,
+	"codec.compression_level":           true,
+	"shard.check_on_startup":            true,
+	"soft_deletes.enabled":              true,
+	"load_fixed_bitset_filters_eagerly": true,
+	"replication.type":                  true,
+	"knn":                               true,
+	"knn.space_type":                    true,
+	"knn.algo_param.ef_construction":    true,
+	"knn.algo_param.m":                  true,
+}
+
+// Index settings which can be changed on an open index with PUT /<index>/_settings.
+var dynamicIndexSettings = map[string]bool{
+	"number_of_replicas":                   true,
+	"auto_expand_replicas":                 true,
+	"refresh_interval":                     true,
+	"max_result_window":                    true,
+	"max_inner_result_window":              true,
+	"max_rescore_window":                   true,
+	"max_docvalue_fields_search":           true,
+	"max_script_fields":                    true,
+	"max_ngram_diff":                       true,
+	"max_shingle_diff":                     true,
+	"max_refresh_listeners":                true,
+	"max_terms_count":                      true,
+	"max_regex_length":                     true,
+	"max_slices_per_scroll":                true,
+	"highlight.max_analyzed_offset":        true,
+	"analyze.max_token_count":              true,
+	"query.default_field":                  true,
+	"gc_deletes":                           true,
+	"default_pipeline":                     true,
+	"final_pipeline":                       true,
+	"hidden":                               true,
+	"search.idle.after":                    true,
+	"translog.durability":                  true,
+	"translog.sync_interval":               true,
+	"translog.flush_threshold_size":        true,
+	"unassigned.node_left.delayed_timeout": true,
+	"knn.algo_param.ef_search":             true,
+	"knn.advanced.approximate_threshold":   true,
+}
+
+// Prefixes of setting groups which are classified as a whole.
+var (
+	staticIndexSettingPrefixes  = []string{"analysis.", "sort.", "similarity."}
+	dynamicIndexSettingPrefixes = []string{"blocks.", "routing.allocation.", "routing.rebalance.", "merge.", "mapping."}
+)
+
+// IsStaticIndexSetting reports whether changing the given index setting requires recreating the index.
+// The name may be given with or without the "index." prefix, e.g. "index.number_of_shards".
+// Settings which are not classified are treated as dynamic, so OpenSearch rejects an invalid
+// update instead of the index (and its documents) being replaced.
+func IsStaticIndexSetting(name string) bool {
+	name = strings.TrimPrefix(name, "index.")
+
+	if staticIndexSettings[name] {
+		return true
+	}
+
+	if dynamicIndexSettings[name] {
+		return false
+	}
+
+	for _, prefix := range staticIndexSettingPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	for _, prefix := range dynamicIndexSettingPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+
+	return false
+}
+
+// Static index settings which can't be changed even on a closed index, with why, so plans can explain
+// the replacement instead of recreating the index silently.
+var createOnlyIndexSettings = map[string]string{
+	"number_of_shards":               "The number of primary shards is fixed when an index is created, use the _split or _shrink APIs into a new index to change it.",
+	"number_of_routing_shards":       "The number of routing shards is fixed when an index is created, as it determines how documents are split across shards.",
+	"routing_partition_size":         "The routing partition size is fixed when an index is created, as it determines which shards routed documents are stored on.",
+	"soft_deletes.enabled":           "Soft deletes can only be configured when an index is created.",
+	"replication.type":               "The replication type can only be configured when an index is created.",
+	"knn":                            "k-NN search can only be enabled when an index is created, as it changes how vectors are stored.",
+	"knn.space_type":                 "The k-NN space type is fixed when an index is created, as existing vector structures are built for it.",
+	"knn.algo_param.ef_construction": "The k-NN ef_construction is fixed when an index is created, as existing vector structures are built with it.",
+	"knn.algo_param.m":               "The k-NN m is fixed when an index is created, as existing vector structures are built with it.",
+}
+
+// Prefixes of create-only setting groups.
+var createOnlyIndexSettingPrefixes = map[string]string{
+	"sort.": "Index sorting is fixed when an index is created, as segments are written in the sort order.",
+}
+
+// IndexSettingReplaceReason returns why changing the given index setting requires recreating the index,
+// or an empty string when it can be changed in place. The name may include the "index." prefix.
+func IndexSettingReplaceReason(name string) string {
+	name = strings.TrimPrefix(name, "index.")
+
+	if reason, ok := createOnlyIndexSettings[name]; ok {
+		return reason
+	}
+
+	for prefix, reason := range createOnlyIndexSettingPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return reason
+		}
+	}
+
+	if IsStaticIndexSetting(name) {
+		return "The setting is static, so it can only be changed while the index is closed and changing it recreates the index."
+	}
+
+	return ""
+}
+
+// IndexSettingRequiresClose reports whether the given static index setting can be changed by closing the index,
+// rather than only by recreating it. The name may include the "index." prefix.
+func IndexSettingRequiresClose(name string) bool {
+	name = strings.TrimPrefix(name, "index.")
+
+	if _, ok := createOnlyIndexSettings[name]; ok {
+		return false
+	}
+
+	for prefix := range createOnlyIndexSettingPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return false
+		}
+	}
+
+	return IsStaticIndexSetting(name)
+}
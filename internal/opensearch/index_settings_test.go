@@ -0,0 +1,49 @@
+package opensearch
+
+import "testing"
+
+func TestIsStaticIndexSetting(t *testing.T) {
+	tests := map[string]bool{
+		"number_of_shards":                              true,
+		"index.number_of_shards":                        true,
+		"number_of_routing_shards":                      true,
+		"codec":                                         true,
+		"knn":                                           true,
+		"knn.space_type":                                true,
+		"knn.algo_param.ef_construction":                true,
+		"knn.algo_param.m":                              true,
+		"analysis.analyzer.default.type":                true,
+		"sort.field":                                    true,
+		"number_of_replicas":                            false,
+		"index.refresh_interval":                        false,
+		"knn.algo_param.ef_search":                      false,
+		"blocks.read_only":                              false,
+		"routing.allocation.include._tier":              false,
+		"mapping.total_fields.limit":                    false,
+		"plugins.index_state_management.rollover_alias": false,
+	}
+
+	for setting, want := range tests {
+		if got := IsStaticIndexSetting(setting); got != want {
+			t.Errorf("expected IsStaticIndexSetting(%q) to be %t, got %t", setting, want, got)
+		}
+	}
+}
+
+func TestIndexSettingReplaceReason(t *testing.T) {
+	tests := map[string]string{
+		"number_of_shards":               "The number of primary shards is fixed when an index is created, use the _split or _shrink APIs into a new index to change it.",
+		"index.number_of_routing_shards": "The number of routing shards is fixed when an index is created, as it determines how documents are split across shards.",
+		"knn.algo_param.m":               "The k-NN m is fixed when an index is created, as existing vector structures are built with it.",
+		"sort.field":                     "Index sorting is fixed when an index is created, as segments are written in the sort order.",
+		"codec":                          "The setting is static, so it can only be changed while the index is closed and changing it recreates the index.",
+		"number_of_replicas":             "",
+		"refresh_interval":               "",
+	}
+
+	for setting, want := range tests {
+		if got := IndexSettingReplaceReason(setting); got != want {
+			t.Errorf("expected the replace reason of %q to be %q, got %q", setting, want, got)
+		}
+	}
+}
@@ -1,10 +1,45 @@
 package opensearch
 
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
 const (
 	TaskStateCompleted = "COMPLETED"
 	TaskStateFailed    = "FAILED"
 )
 
+const (
+	TaskTypeRegisterModel = "REGISTER_MODEL"
+	TaskTypeDeployModel   = "DEPLOY_MODEL"
+)
+
+const (
+	ModelStateRegistering       = "REGISTERING"
+	ModelStateDeploying         = "DEPLOYING"
+	ModelStateDeployed          = "DEPLOYED"
+	ModelStatePartiallyDeployed = "PARTIALLY_DEPLOYED"
+	ModelStateDeployFailed      = "DEPLOY_FAILED"
+)
+
+// FunctionNameTextEmbedding is the function name OpenSearch reports for local text embedding models.
+const FunctionNameTextEmbedding = "TEXT_EMBEDDING"
+
+const (
+	ModelFormatTorchScript = "TORCH_SCRIPT"
+	ModelFormatONNX        = "ONNX"
+)
+
+// ModelConfigRequiredFields are the model_config fields OpenSearch requires for local models.
+var ModelConfigRequiredFields = []string{
+	"model_type",
+	"embedding_dimension",
+	"framework_type",
+}
+
 type ModelGroupCreateRequest struct {
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
@@ -14,22 +49,577 @@ type ModelGroupCreateResponse struct {
 	ModelGroupID string `json:"model_group_id,omitempty"`
 }
 
+type ModelGroupGetResponse struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Access      string         `json:"access,omitempty"`
+	Owner       *MLObjectOwner `json:"owner,omitempty"`
+}
+
+// MLObjectOwner is the user which created an ML Commons object, only reported when the security plugin is enabled.
+type MLObjectOwner struct {
+	Name         string   `json:"name"`
+	BackendRoles []string `json:"backend_roles"`
+	Roles        []string `json:"roles"`
+}
+
 type ConnectorCreateResponse struct {
 	ConnectorID string `json:"connector_id,omitempty"`
 }
 
+// Connector fields populated by OpenSearch, which are never part of a connector create body.
+var ConnectorServerManagedFields = []string{"connector_id", "created_time", "last_updated_time", "owner"}
+
+// ConnectorUpdateRequest is the body of PUT /_plugins/_ml/connectors/<connector_id>, only the given fields are changed.
+type ConnectorUpdateRequest struct {
+	Parameters map[string]any `json:"parameters,omitempty"`
+}
+
+// MonitorResponse is returned by the Alerting monitor APIs, e.g. GET /_plugins/_alerting/monitors/<monitor_id>.
+// The sequence number and primary term guard an update against concurrent changes.
+type MonitorResponse struct {
+	ID          string         `json:"_id"`
+	SeqNo       int64          `json:"_seq_no"`
+	PrimaryTerm int64          `json:"_primary_term"`
+	Monitor     map[string]any `json:"monitor"`
+}
+
+// Monitor fields populated by OpenSearch, which are never part of a monitor create body.
+var MonitorServerManagedFields = []string{"last_update_time", "enabled_time", "schema_version", "user"}
+
+type AgentRegisterResponse struct {
+	AgentID string `json:"agent_id"`
+}
+
+// ModelRegisterReferences are the IDs of other ML objects a model registration body refers to.
+type ModelRegisterReferences struct {
+	ModelGroupID string `json:"model_group_id,omitempty"`
+	ConnectorID  string `json:"connector_id,omitempty"`
+}
+
 type ModelRegisterResponse struct {
 	TaskID string `json:"task_id"`
 	Status string `json:"status"`
 }
 
+// DeployParameterKeys are the known fields of the body of a _deploy request. Newer versions of ML Commons may accept more.
+// https://opensearch.org/docs/latest/ml-commons-plugin/api/model-apis/deploy-model/
+var DeployParameterKeys = []string{"node_ids"}
+
+type ModelDeployResponse struct {
+	TaskID string `json:"task_id"`
+	Status string `json:"status"`
+}
+
 type TaskGetResponse struct {
 	TaskID   string         `json:"task_id,omitempty"`
+	TaskType string         `json:"task_type,omitempty"`
 	State    string         `json:"state,omitempty"`
 	ModelID  string         `json:"model_id,omitempty"`
+	Error    string         `json:"error,omitempty"`
 	Response map[string]any `json:"response,omitempty"`
 }
 
 type ModelGetResponse struct {
-	ModelID string `json:"model_id,omitempty"`
+	ModelID                string          `json:"model_id,omitempty"`
+	Name                   string          `json:"name,omitempty"`
+	Description            string          `json:"description,omitempty"`
+	FunctionName           string          `json:"function_name,omitempty"`
+	ModelGroupID           string          `json:"model_group_id,omitempty"`
+	ConnectorID            string          `json:"connector_id,omitempty"`
+	Connector              json.RawMessage `json:"connector,omitempty"`
+	Interface              json.RawMessage `json:"interface,omitempty"`
+	ModelFormat            string          `json:"model_format,omitempty"`
+	ModelConfig            json.RawMessage `json:"model_config,omitempty"`
+	ModelContentHashValue  string          `json:"model_content_hash_value,omitempty"`
+	RateLimiter            *RateLimiter    `json:"rate_limiter,omitempty"`
+	PlanningWorkerNodes    []string        `json:"planning_worker_nodes,omitempty"`
+	IsEnabled              *bool           `json:"is_enabled,omitempty"`
+	ModelState             string          `json:"model_state,omitempty"`
+	CurrentWorkerNodeCount int64           `json:"current_worker_node_count,omitempty"`
+}
+
+// ModelBodyDriftFields are the model registration body fields compared with OpenSearch to detect drift.
+// model_config is left out as OpenSearch fills in defaults, the model_config attribute tracks it instead.
+var ModelBodyDriftFields = []string{"name", "description", "function_name", "model_group_id", "connector_id", "connector", "interface"}
+
+type ModelUpdateRequest struct {
+	IsEnabled   *bool        `json:"is_enabled,omitempty"`
+	RateLimiter *RateLimiter `json:"rate_limiter,omitempty"`
+}
+
+// ModelProfileResponse is returned by GET /_plugins/_ml/profile/models/<model_id>, keyed by the ID of each node
+// which knows of the model.
+type ModelProfileResponse struct {
+	Nodes map[string]ModelProfileNode `json:"nodes"`
+}
+
+type ModelProfileNode struct {
+	Models map[string]ModelProfile `json:"models"`
+}
+
+type ModelProfile struct {
+	ModelState        string   `json:"model_state,omitempty"`
+	Predictor         string   `json:"predictor,omitempty"`
+	TargetWorkerNodes []string `json:"target_worker_nodes,omitempty"`
+	WorkerNodes       []string `json:"worker_nodes,omitempty"`
+}
+
+// DeployedNodes returns the sorted IDs of the nodes reporting the model as deployed.
+func (r ModelProfileResponse) DeployedNodes(modelID string) []string {
+	nodes := []string{}
+
+	for nodeID, node := range r.Nodes {
+		if profile, ok := node.Models[modelID]; ok && profile.ModelState == ModelStateDeployed {
+			nodes = append(nodes, nodeID)
+		}
+	}
+
+	sort.Strings(nodes)
+
+	return nodes
+}
+
+// RateLimiter caps the number of predict requests a model accepts, limit requests per unit.
+// OpenSearch takes the limit as a string holding a number, e.g. "4".
+type RateLimiter struct {
+	Limit string `json:"limit"`
+	Unit  string `json:"unit"`
+}
+
+// RateLimiterUnits are the time units OpenSearch accepts for a rate limiter.
+var RateLimiterUnits = []string{"NANOSECONDS", "MICROSECONDS", "MILLISECONDS", "SECONDS", "MINUTES", "HOURS", "DAYS"}
+
+type SearchResponse struct {
+	Hits SearchHits `json:"hits"`
+}
+
+type SearchHits struct {
+	Total SearchTotal `json:"total"`
+	Hits  []SearchHit `json:"hits"`
+}
+
+type SearchTotal struct {
+	Value int `json:"value"`
+}
+
+type SearchHit struct {
+	ID     string          `json:"_id"`
+	Source json.RawMessage `json:"_source"`
+}
+
+type MLStatsResponse struct {
+	Nodes                  map[string]MLNodeStats `json:"nodes"`
+	MLModelCount           int64                  `json:"ml_model_count"`
+	MLConnectorCount       int64                  `json:"ml_connector_count"`
+	MLModelIndexStatus     string                 `json:"ml_model_index_status"`
+	MLConnectorIndexStatus string                 `json:"ml_connector_index_status"`
+	MLTaskIndexStatus      string                 `json:"ml_task_index_status"`
+	MLConfigIndexStatus    string                 `json:"ml_config_index_status"`
+}
+
+type MLNodeStats struct {
+	MLRequestCount               int64   `json:"ml_request_count"`
+	MLFailureCount               int64   `json:"ml_failure_count"`
+	MLExecutingTaskCount         int64   `json:"ml_executing_task_count"`
+	MLDeployedModelCount         int64   `json:"ml_deployed_model_count"`
+	MLCircuitBreakerTriggerCount int64   `json:"ml_circuit_breaker_trigger_count"`
+	MLJVMHeapUsage               float64 `json:"ml_jvm_heap_usage"`
+}
+
+// ClusterSettingsRequest is the body of PUT /_cluster/settings. A null value resets a setting to its default.
+type ClusterSettingsRequest struct {
+	Persistent map[string]any `json:"persistent,omitempty"`
+	Transient  map[string]any `json:"transient,omitempty"`
+}
+
+// ClusterSettingsResponse is returned by GET /_cluster/settings, with defaults only when include_defaults is set.
+type ClusterSettingsResponse struct {
+	Persistent map[string]any `json:"persistent"`
+	Transient  map[string]any `json:"transient"`
+	Defaults   map[string]any `json:"defaults,omitempty"`
+}
+
+type ClusterHealthResponse struct {
+	ClusterName                 string  `json:"cluster_name"`
+	Status                      string  `json:"status"`
+	TimedOut                    bool    `json:"timed_out"`
+	NumberOfNodes               int64   `json:"number_of_nodes"`
+	NumberOfDataNodes           int64   `json:"number_of_data_nodes"`
+	ActivePrimaryShards         int64   `json:"active_primary_shards"`
+	ActiveShards                int64   `json:"active_shards"`
+	RelocatingShards            int64   `json:"relocating_shards"`
+	InitializingShards          int64   `json:"initializing_shards"`
+	UnassignedShards            int64   `json:"unassigned_shards"`
+	DelayedUnassignedShards     int64   `json:"delayed_unassigned_shards"`
+	NumberOfPendingTasks        int64   `json:"number_of_pending_tasks"`
+	ActiveShardsPercentAsNumber float64 `json:"active_shards_percent_as_number"`
+}
+
+const ScriptLangMustache = "mustache"
+
+type StoredScriptRequest struct {
+	Script StoredScript `json:"script"`
+}
+
+type StoredScript struct {
+	Lang   string `json:"lang"`
+	Source string `json:"source"`
+}
+
+type StoredScriptGetResponse struct {
+	ID     string       `json:"_id"`
+	Found  bool         `json:"found"`
+	Script StoredScript `json:"script"`
+}
+
+const (
+	SnapshotStateInProgress = "IN_PROGRESS"
+	SnapshotStateSuccess    = "SUCCESS"
+	SnapshotStatePartial    = "PARTIAL"
+	SnapshotStateFailed     = "FAILED"
+)
+
+type SnapshotCreateRequest struct {
+	Indices            []string `json:"indices,omitempty"`
+	IncludeGlobalState bool     `json:"include_global_state"`
+}
+
+type SnapshotGetResponse struct {
+	Snapshots []Snapshot `json:"snapshots"`
+}
+
+type Snapshot struct {
+	Snapshot string            `json:"snapshot"`
+	UUID     string            `json:"uuid"`
+	State    string            `json:"state"`
+	Indices  []string          `json:"indices"`
+	Failures []SnapshotFailure `json:"failures"`
+}
+
+// FailureSummary returns all shard failures as a single line.
+func (s Snapshot) FailureSummary() string {
+	if len(s.Failures) == 0 {
+		return "no shard failures reported"
+	}
+
+	failures := make([]string, 0, len(s.Failures))
+	for _, failure := range s.Failures {
+		failures = append(failures, failure.String())
+	}
+
+	return strings.Join(failures, "; ")
+}
+
+type SnapshotFailure struct {
+	Index   string `json:"index"`
+	ShardID int    `json:"shard_id"`
+	Reason  string `json:"reason"`
+}
+
+// String returns the failure in the form "<index>[<shard>]: <reason>".
+func (f SnapshotFailure) String() string {
+	return fmt.Sprintf("%s[%d]: %s", f.Index, f.ShardID, f.Reason)
+}
+
+// SnapshotRepository is the body of PUT /_snapshot/<repository>, OpenSearch reports every setting as a string.
+type SnapshotRepository struct {
+	Type     string          `json:"type"`
+	Settings json.RawMessage `json:"settings,omitempty"`
+}
+
+// SnapshotRepositoryGetResponse is keyed by repository name, as GET /_snapshot/<repository> accepts wildcards.
+type SnapshotRepositoryGetResponse map[string]SnapshotRepository
+
+const NodeRoleML = "ml"
+
+type NodesInfoResponse struct {
+	Nodes map[string]NodeInfo `json:"nodes"`
+}
+
+type NodeInfo struct {
+	Name  string   `json:"name"`
+	Roles []string `json:"roles"`
+}
+
+type IndexCreateRequest struct {
+	Settings json.RawMessage       `json:"settings,omitempty"`
+	Mappings json.RawMessage       `json:"mappings,omitempty"`
+	Aliases  map[string]IndexAlias `json:"aliases,omitempty"`
+}
+
+// IndexAlias is an alias created along with an index, keyed by the alias name in IndexCreateRequest.
+type IndexAlias struct {
+	Filter       json.RawMessage `json:"filter,omitempty"`
+	Routing      string          `json:"routing,omitempty"`
+	IsWriteIndex *bool           `json:"is_write_index,omitempty"`
+}
+
+// IndexGetResponse is keyed by index name, as GET /<index> accepts wildcards and aliases.
+type IndexGetResponse map[string]Index
+
+// Index is an index as returned with flat_settings, e.g. {"index.number_of_replicas": "1"}.
+// Defaults are only reported when include_defaults is set.
+type Index struct {
+	Aliases  map[string]Alias `json:"aliases,omitempty"`
+	Mappings json.RawMessage  `json:"mappings"`
+	Settings map[string]any   `json:"settings"`
+	Defaults map[string]any   `json:"defaults,omitempty"`
+}
+
+// IndexStatsResponse is the response of GET /<index>/_stats/docs,store.
+type IndexStatsResponse struct {
+	All IndexStatsGroups `json:"_all"`
+}
+
+// IndexStatsGroups are the stats of the primary shards, and of all shards including replicas.
+type IndexStatsGroups struct {
+	Primaries IndexStats `json:"primaries"`
+	Total     IndexStats `json:"total"`
+}
+
+type IndexStats struct {
+	Docs  IndexDocsStats  `json:"docs"`
+	Store IndexStoreStats `json:"store"`
+}
+
+type IndexDocsStats struct {
+	Count int64 `json:"count"`
+}
+
+type IndexStoreStats struct {
+	SizeInBytes int64 `json:"size_in_bytes"`
+}
+
+// IndexTemplateSimulateResponse is the response of POST /_index_template/_simulate/<name>, with the settings,
+// mappings and aliases an index created from the template gets.
+type IndexTemplateSimulateResponse struct {
+	Template    json.RawMessage                `json:"template"`
+	Overlapping []IndexTemplateSimulateOverlap `json:"overlapping"`
+}
+
+// IndexTemplateSimulateOverlap is another template matching some of the same index patterns, with a lower priority.
+type IndexTemplateSimulateOverlap struct {
+	Name          string   `json:"name"`
+	IndexPatterns []string `json:"index_patterns"`
+}
+
+type IndexTemplateGetResponse struct {
+	IndexTemplates []IndexTemplateItem `json:"index_templates"`
+}
+
+type IndexTemplateItem struct {
+	Name          string          `json:"name"`
+	IndexTemplate json.RawMessage `json:"index_template"`
+}
+
+type ComponentTemplateGetResponse struct {
+	ComponentTemplates []ComponentTemplateItem `json:"component_templates"`
+}
+
+type ComponentTemplateItem struct {
+	Name              string          `json:"name"`
+	ComponentTemplate json.RawMessage `json:"component_template"`
+}
+
+type DataStreamGetResponse struct {
+	DataStreams []DataStream `json:"data_streams"`
+}
+
+type DataStream struct {
+	Name       string            `json:"name"`
+	Generation int64             `json:"generation"`
+	Status     string            `json:"status,omitempty"`
+	Template   string            `json:"template,omitempty"`
+	Indices    []DataStreamIndex `json:"indices"`
+}
+
+type DataStreamIndex struct {
+	IndexName string `json:"index_name"`
+	IndexUUID string `json:"index_uuid,omitempty"`
+}
+
+// ISMPolicyResponse is returned when an ISM policy is created, updated or read. The sequence number and primary
+// term identify the policy's version for optimistic concurrency control.
+type ISMPolicyResponse struct {
+	ID          string          `json:"_id"`
+	Version     int64           `json:"_version"`
+	SeqNo       int64           `json:"_seq_no"`
+	PrimaryTerm int64           `json:"_primary_term"`
+	Policy      json.RawMessage `json:"policy"`
+}
+
+// ISMExplainRequest is the body of POST /_plugins/_ism/explain, limiting the explained indices to those matching the filter.
+type ISMExplainRequest struct {
+	Filter ISMExplainFilter `json:"filter"`
+}
+
+type ISMExplainFilter struct {
+	PolicyID string `json:"policy_id"`
+}
+
+// ISMExplainResponse is keyed by index name, besides the total. Only the total is decoded, as the indices
+// are paged and counting them would take a request per page.
+type ISMExplainResponse struct {
+	TotalManagedIndices int64 `json:"total_managed_indices"`
+}
+
+// ISM policy fields populated by OpenSearch, which are never part of a policy request body.
+var ISMPolicyServerManagedFields = []string{"policy_id", "last_updated_time", "schema_version"}
+
+// SnapshotPolicyResponse is returned when a Snapshot Management policy is created, updated or read. The sequence
+// number and primary term identify the policy's version for optimistic concurrency control.
+type SnapshotPolicyResponse struct {
+	ID          string          `json:"_id"`
+	Version     int64           `json:"_version"`
+	SeqNo       int64           `json:"_seq_no"`
+	PrimaryTerm int64           `json:"_primary_term"`
+	SMPolicy    json.RawMessage `json:"sm_policy"`
+}
+
+// Snapshot Management policy fields populated by OpenSearch, which are never part of a policy request body.
+var SnapshotPolicyServerManagedFields = []string{"name", "schema_version", "schedule", "last_updated_time", "enabled_time"}
+
+// ForceMergeResponse has the shard results of a force merge, or the task running it when it doesn't wait for completion.
+type ForceMergeResponse struct {
+	Shards ShardResults `json:"_shards"`
+	Task   string       `json:"task,omitempty"`
+}
+
+type ShardResults struct {
+	Total      int64 `json:"total"`
+	Successful int64 `json:"successful"`
+	Failed     int64 `json:"failed"`
+}
+
+// AliasActionsRequest is the body of POST /_aliases, its actions are applied atomically.
+type AliasActionsRequest struct {
+	Actions []AliasAction `json:"actions"`
+}
+
+// AliasAction has exactly one of Add and Remove.
+type AliasAction struct {
+	Add    *AliasActionParams `json:"add,omitempty"`
+	Remove *AliasActionParams `json:"remove,omitempty"`
+}
+
+type AliasActionParams struct {
+	Index        string          `json:"index"`
+	Alias        string          `json:"alias"`
+	Filter       json.RawMessage `json:"filter,omitempty"`
+	Routing      string          `json:"routing,omitempty"`
+	IsWriteIndex *bool           `json:"is_write_index,omitempty"`
+}
+
+// AliasGetResponse is keyed by the name of each index the alias points to.
+type AliasGetResponse map[string]IndexAliases
+
+type IndexAliases struct {
+	Aliases map[string]Alias `json:"aliases"`
+}
+
+// Alias is an alias of an index, routing set with "routing" is reported as both index and search routing.
+type Alias struct {
+	Filter        json.RawMessage `json:"filter,omitempty"`
+	IndexRouting  string          `json:"index_routing,omitempty"`
+	SearchRouting string          `json:"search_routing,omitempty"`
+	IsWriteIndex  *bool           `json:"is_write_index,omitempty"`
+}
+
+// SecurityObjectFlags are reported for each object of the security plugin's REST API. Reserved objects can only
+// be changed by an admin certificate, static ones are defined by the plugin and hidden ones are for its internal use.
+type SecurityObjectFlags struct {
+	Reserved bool `json:"reserved,omitempty"`
+	Hidden   bool `json:"hidden,omitempty"`
+	Static   bool `json:"static,omitempty"`
+}
+
+// ReadOnlyReason returns why the object can't be changed or deleted through the REST API, or an empty string when it can.
+func (f SecurityObjectFlags) ReadOnlyReason() string {
+	switch {
+	case f.Static:
+		return "it is static, i.e. defined by the security plugin itself"
+	case f.Reserved:
+		return "it is reserved, i.e. only an admin certificate can change it"
+	case f.Hidden:
+		return "it is hidden, i.e. for the security plugin's internal use"
+	default:
+		return ""
+	}
+}
+
+// Role is the body of PUT /_plugins/_security/api/roles/<role>.
+type Role struct {
+	Description        string                 `json:"description,omitempty"`
+	ClusterPermissions []string               `json:"cluster_permissions"`
+	IndexPermissions   []RoleIndexPermission  `json:"index_permissions"`
+	TenantPermissions  []RoleTenantPermission `json:"tenant_permissions"`
+}
+
+type RoleIndexPermission struct {
+	IndexPatterns  []string `json:"index_patterns"`
+	AllowedActions []string `json:"allowed_actions"`
+	DLS            string   `json:"dls,omitempty"`
+	FLS            []string `json:"fls,omitempty"`
+	MaskedFields   []string `json:"masked_fields,omitempty"`
+}
+
+type RoleTenantPermission struct {
+	TenantPatterns []string `json:"tenant_patterns"`
+	AllowedActions []string `json:"allowed_actions"`
+}
+
+// RoleGetResponse is keyed by role name.
+type RoleGetResponse map[string]RoleGetItem
+
+type RoleGetItem struct {
+	Role
+	SecurityObjectFlags
+}
+
+// RoleMapping is the body of PUT /_plugins/_security/api/rolesmapping/<role>.
+type RoleMapping struct {
+	Description  string   `json:"description,omitempty"`
+	Users        []string `json:"users"`
+	BackendRoles []string `json:"backend_roles"`
+	Hosts        []string `json:"hosts"`
+}
+
+// RoleMappingGetResponse is keyed by role name.
+type RoleMappingGetResponse map[string]RoleMappingGetItem
+
+type RoleMappingGetItem struct {
+	RoleMapping
+	SecurityObjectFlags
+}
+
+// InternalUser is the body of PUT /_plugins/_security/api/internalusers/<user>. The password is never returned,
+// only its hash.
+type InternalUser struct {
+	Password                string            `json:"password,omitempty"`
+	Description             string            `json:"description,omitempty"`
+	BackendRoles            []string          `json:"backend_roles"`
+	Attributes              map[string]string `json:"attributes"`
+	OpendistroSecurityRoles []string          `json:"opendistro_security_roles"`
+}
+
+// InternalUserGetResponse is keyed by user name.
+type InternalUserGetResponse map[string]InternalUserGetItem
+
+type InternalUserGetItem struct {
+	InternalUser
+	SecurityObjectFlags
+}
+
+// Tenant is the body of PUT /_plugins/_security/api/tenants/<tenant>.
+type Tenant struct {
+	Description string `json:"description,omitempty"`
+}
+
+// TenantGetResponse is keyed by tenant name.
+type TenantGetResponse map[string]TenantGetItem
+
+type TenantGetItem struct {
+	Tenant
+	SecurityObjectFlags
 }
@@ -0,0 +1,37 @@
+package opensearch
+
+import "strings"
+
+// ServerlessLimitationsURL documents the APIs OpenSearch Serverless (aoss) collections support.
+const ServerlessLimitationsURL = "https://docs.aws.amazon.com/opensearch-service/latest/developerguide/serverless-genref.html"
+
+// Resources and data sources which can't be used with OpenSearch Serverless, with why.
+var serverlessUnsupported = map[string]string{
+	"opensearch_health":              "Serverless collections don't expose the _cluster APIs, so cluster health is not available.",
+	"opensearch_cluster_settings":    "Serverless collections don't expose the _cluster APIs, so cluster settings can't be changed.",
+	"opensearch_snapshot":            "Serverless collections are backed up automatically and don't expose the _snapshot APIs.",
+	"opensearch_snapshot_repository": "Serverless collections are backed up automatically and don't expose the _snapshot APIs.",
+	"opensearch_snapshot_policy":     "Serverless collections are backed up automatically and don't expose the Snapshot Management APIs.",
+	"opensearch_index_force_merge":   "Serverless collections manage segments themselves and don't expose the _forcemerge API.",
+	"opensearch_ism_policy":          "Serverless collections manage retention with data lifecycle policies instead of Index State Management.",
+	"opensearch_alerting_monitor":    "Serverless collections don't support the Alerting plugin.",
+}
+
+// Index settings which Serverless collections manage themselves, with why.
+var serverlessManagedIndexSettings = map[string]string{
+	"number_of_shards":     "Serverless collections scale shards automatically.",
+	"number_of_replicas":   "Serverless collections replicate data automatically.",
+	"auto_expand_replicas": "Serverless collections replicate data automatically.",
+}
+
+// ServerlessUnsupportedReason returns why the given resource or data source type (e.g. "opensearch_snapshot")
+// can't be used with OpenSearch Serverless, or an empty string when it can.
+func ServerlessUnsupportedReason(typeName string) string {
+	return serverlessUnsupported[typeName]
+}
+
+// ServerlessManagedIndexSettingReason returns why the given index setting can't be set on OpenSearch Serverless,
+// or an empty string when it can. The name may include the "index." prefix.
+func ServerlessManagedIndexSettingReason(name string) string {
+	return serverlessManagedIndexSettings[strings.TrimPrefix(name, "index.")]
+}
@@ -0,0 +1,306 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AgentResource{}
+
+// NewAgentResource is a helper function to simplify the provider implementation.
+func NewAgentResource() resource.Resource {
+	return &AgentResource{}
+}
+
+// AgentResource is the resource implementation.
+type AgentResource struct {
+	providerData *ProviderData
+}
+
+// AgentModel describes the Agent resource data model.
+type AgentModel struct {
+	ID   types.String `tfsdk:"id"`
+	Body JSONBody     `tfsdk:"body"`
+}
+
+// Metadata returns the resource type name.
+func (r *AgentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_agent", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Agent resource.
+func (r *AgentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Registers an ML Commons agent, which runs tools and models to answer questions.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier for the agent.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "A JSON payload which defines the agent, e.g. its `type`, `llm` and `tools`.",
+				Required:            true,
+				CustomType:          JSONBodyType{},
+				PlanModifiers: []planmodifier.String{
+					UseStateForSemanticallyEqualJSON(),
+					// Registering again is the only supported “update”.
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *AgentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *AgentResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// Create registers a new agent in OpenSearch.
+func (r *AgentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AgentModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	registerReq, err := http.NewRequestWithContext(ctx, "POST", "/_plugins/_ml/agents/_register", bytes.NewReader([]byte(data.Body.ValueString())))
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating agent register request", err.Error())
+		return
+	}
+
+	registerReq.Header.Set("Content-Type", "application/json")
+	registerReq.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(registerReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Error registering agent", err.Error())
+		return
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading agent register response", err.Error())
+		return
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		resp.Diagnostics.AddError(
+			"Error registering agent",
+			fmt.Sprintf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body)),
+		)
+		return
+	}
+
+	var registerResponse skpropensearch.AgentRegisterResponse
+
+	if err := json.Unmarshal(body, &registerResponse); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing agent register response",
+			fmt.Sprintf("Could not parse agent register response: %s", err.Error()),
+		)
+		return
+	}
+
+	if registerResponse.AgentID == "" {
+		resp.Diagnostics.AddError(
+			"Error registering agent",
+			fmt.Sprintf("OpenSearch did not return an agent ID: %s", string(body)),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(registerResponse.AgentID)
+
+	tflog.Trace(ctx, "created Agent resource", map[string]any{
+		"agent_id": registerResponse.AgentID,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read checks the agent still exists in OpenSearch.
+func (r *AgentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AgentModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ID.IsNull() || data.ID.IsUnknown() || data.ID.ValueString() == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	getReq, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/_plugins/_ml/agents/%s", data.ID.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating agent get request", err.Error())
+		return
+	}
+
+	getReq.Header.Set("Content-Type", "application/json")
+	getReq.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(getReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading agent", err.Error())
+		return
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading agent response", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if httpResp.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		resp.Diagnostics.AddError(
+			"Error reading agent",
+			fmt.Sprintf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body)),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update is not supported; registering a new agent is the only way to change anything.
+func (r *AgentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AgentModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the agent from OpenSearch.
+func (r *AgentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AgentModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Nothing to delete if missing ID.
+	if data.ID.IsNull() || data.ID.IsUnknown() || data.ID.ValueString() == "" {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	delReq, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("/_plugins/_ml/agents/%s", data.ID.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating agent delete request", err.Error())
+		return
+	}
+
+	delReq.Header.Set("Content-Type", "application/json")
+	delReq.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(delReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting agent", err.Error())
+		return
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading agent delete response", err.Error())
+		return
+	}
+
+	// Treat 404 as already deleted.
+	if httpResp.StatusCode == http.StatusNotFound {
+		return
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		resp.Diagnostics.AddError(
+			"Error deleting agent",
+			fmt.Sprintf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body)),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Agent resource", map[string]any{
+		"agent_id": data.ID.ValueString(),
+	})
+}
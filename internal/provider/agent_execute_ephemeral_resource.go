@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &AgentExecuteEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &AgentExecuteEphemeralResource{}
+)
+
+// NewAgentExecuteEphemeralResource is a helper function to simplify the provider implementation.
+func NewAgentExecuteEphemeralResource() ephemeral.EphemeralResource {
+	return &AgentExecuteEphemeralResource{}
+}
+
+// AgentExecuteEphemeralResource is the ephemeral resource implementation.
+type AgentExecuteEphemeralResource struct {
+	providerData *ProviderData
+}
+
+// AgentExecuteModel describes the Agent Execute ephemeral resource data model.
+type AgentExecuteModel struct {
+	AgentID    types.String `tfsdk:"agent_id"`
+	Parameters JSONBody     `tfsdk:"parameters"`
+	Response   types.String `tfsdk:"response"`
+}
+
+// Metadata returns the ephemeral resource type name.
+func (e *AgentExecuteEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_ml_agent_execute", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Agent Execute ephemeral resource.
+func (e *AgentExecuteEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs an agent without storing anything in state or plan, e.g. to bootstrap data during an apply. " +
+			"The agent runs every time Terraform opens the ephemeral resource, i.e. on every plan and apply which uses it, " +
+			"and each call an agent's tools make to a remote model is billed and adds its latency to the run.",
+
+		Attributes: map[string]schema.Attribute{
+			"agent_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the agent to run, e.g. `opensearch_agent.example.id`.",
+				Required:            true,
+			},
+			"parameters": schema.StringAttribute{
+				MarkdownDescription: "A JSON object of the parameters the agent runs with, e.g. `{\"question\": \"How many orders were placed today?\"}`. " +
+					"It is sent as `parameters` in the body of the `_execute` request.",
+				Required:   true,
+				CustomType: JSONBodyType{},
+			},
+			"response": schema.StringAttribute{
+				MarkdownDescription: "The JSON response returned by OpenSearch.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for the ephemeral resource.
+func (e *AgentExecuteEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	e.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (e *AgentExecuteEphemeralResource) client() (*opensearchapi.Client, error) {
+	return e.providerData.client()
+}
+
+// Open runs the agent.
+func (e *AgentExecuteEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data AgentExecuteModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := e.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := data.run(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error running agent",
+			fmt.Sprintf("Could not run agent %s: %s", data.AgentID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// Runs the agent with the parameters and stores the response, failing on any non 2xx status.
+func (m *AgentExecuteModel) run(ctx context.Context, client *opensearchapi.Client) error {
+	var parameters map[string]any
+
+	if err := json.Unmarshal([]byte(m.Parameters.ValueString()), &parameters); err != nil {
+		return fmt.Errorf("parameters must be a JSON object: %w", err)
+	}
+
+	requestBody, err := json.Marshal(map[string]any{
+		"parameters": parameters,
+	})
+	if err != nil {
+		return err
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "POST", fmt.Sprintf("/_plugins/_ml/agents/%s/_execute", m.AgentID.ValueString()), requestBody)
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	m.Response = types.StringValue(string(body))
+
+	tflog.Trace(ctx, "ran agent", map[string]any{
+		"agent_id": m.AgentID.ValueString(),
+	})
+
+	return nil
+}
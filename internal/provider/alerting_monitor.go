@@ -0,0 +1,448 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &AlertingMonitorResource{}
+	_ resource.ResourceWithImportState    = &AlertingMonitorResource{}
+	_ resource.ResourceWithModifyPlan     = &AlertingMonitorResource{}
+	_ resource.ResourceWithValidateConfig = &AlertingMonitorResource{}
+)
+
+// NewAlertingMonitorResource is a helper function to simplify the provider implementation.
+func NewAlertingMonitorResource() resource.Resource {
+	return &AlertingMonitorResource{}
+}
+
+// AlertingMonitorResource is the resource implementation.
+type AlertingMonitorResource struct {
+	providerData *ProviderData
+}
+
+// AlertingMonitorModel describes the Alerting Monitor resource data model.
+type AlertingMonitorModel struct {
+	ID      types.String `tfsdk:"id"`
+	Body    JSONBody     `tfsdk:"body"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+// Metadata returns the resource type name.
+func (r *AlertingMonitorResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_alerting_monitor", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Alerting Monitor resource.
+func (r *AlertingMonitorResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an Alerting monitor, which runs a query on a schedule and triggers alerts on its results, e.g. to watch ML inference error rates.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the monitor, generated by OpenSearch.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "A JSON payload which defines the monitor, e.g. its `name`, `schedule`, `inputs` and `triggers`. " +
+					"Whether the monitor runs is set with `enabled` rather than in the body. Only the fields given here are checked for drift.",
+				Required:   true,
+				CustomType: JSONBodyType{},
+				PlanModifiers: []planmodifier.String{
+					UseStateForSemanticallyEqualJSON(),
+				},
+				Validators: []validator.String{
+					JSONBodyFields("monitor", skpropensearch.MonitorBodyFields),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the monitor runs on its schedule. Disabling a monitor, e.g. to pause a noisy one during maintenance, " +
+					"keeps it and its alerts, and only changes this flag of the monitor in OpenSearch. Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+// ValidateConfig rejects a body which sets enabled, as the attribute decides it.
+func (r *AlertingMonitorResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data AlertingMonitorModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Body.IsNull() || data.Body.IsUnknown() {
+		return
+	}
+
+	var body map[string]any
+
+	// Invalid JSON is reported by the attribute's validation.
+	if err := json.Unmarshal([]byte(data.Body.ValueString()), &body); err != nil {
+		return
+	}
+
+	if _, ok := body["enabled"]; ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("body"),
+			"Conflicting monitor enabled",
+			"The body sets enabled, which is managed by the enabled attribute. Remove it from the body.",
+		)
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *AlertingMonitorResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *AlertingMonitorResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// ModifyPlan rejects the resource when the provider is configured for OpenSearch Serverless.
+func (r *AlertingMonitorResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.providerData.serverlessDiagnostics("opensearch_alerting_monitor")...)
+}
+
+// Create creates the monitor.
+func (r *AlertingMonitorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AlertingMonitorModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	requestBody, err := monitorRequestBody(data.Body.ValueString(), data.Enabled.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating monitor request body",
+			fmt.Sprintf("Could not create monitor request body: %s", err.Error()),
+		)
+		return
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "POST", "/_plugins/_alerting/monitors", requestBody)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating monitor", err.Error())
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error creating monitor",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	var createResponse skpropensearch.MonitorResponse
+
+	if err := json.Unmarshal(body, &createResponse); err != nil || createResponse.ID == "" {
+		resp.Diagnostics.AddError(
+			"Error parsing monitor response",
+			fmt.Sprintf("Could not find the monitor ID in the response: %s", string(body)),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(createResponse.ID)
+
+	tflog.Trace(ctx, "created Alerting Monitor resource", map[string]any{
+		"monitor_id": createResponse.ID,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read compares the monitor in OpenSearch with the state, and reads whether it is enabled.
+func (r *AlertingMonitorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AlertingMonitorModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	monitor, exists, err := getMonitor(ctx, client, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading monitor", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if !exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	enabled, _ := monitor.Monitor["enabled"].(bool)
+	data.Enabled = types.BoolValue(enabled)
+
+	// Fields OpenSearch populates are left out, so an imported body only has what a create body would.
+	delete(monitor.Monitor, "enabled")
+	for _, field := range skpropensearch.MonitorServerManagedFields {
+		delete(monitor.Monitor, field)
+	}
+
+	body, err := readBackJSONSubset(data.Body.ValueString(), monitor.Monitor)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading monitor",
+			fmt.Sprintf("Could not compare monitor %s: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.Body = NewJSONBodyValue(body)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update replaces the monitor when its body changes. When only enabled changes, the monitor stored in OpenSearch is
+// updated with the new flag instead, as the Alerting plugin has no API to enable or disable a monitor by itself.
+func (r *AlertingMonitorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state AlertingMonitorModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	id := data.ID.ValueString()
+
+	switch {
+	case !data.Body.Equal(state.Body):
+		requestBody, err := monitorRequestBody(data.Body.ValueString(), data.Enabled.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating monitor request body",
+				fmt.Sprintf("Could not create monitor request body: %s", err.Error()),
+			)
+			return
+		}
+
+		if err := putMonitor(ctx, client, fmt.Sprintf("/_plugins/_alerting/monitors/%s", id), requestBody); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating monitor",
+				fmt.Sprintf("Could not update monitor %s: %s", id, err.Error()),
+			)
+			return
+		}
+	case !data.Enabled.Equal(state.Enabled):
+		if err := setMonitorEnabled(ctx, client, id, data.Enabled.ValueBool()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating monitor",
+				fmt.Sprintf("Could not set whether monitor %s is enabled: %s", id, err.Error()),
+			)
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "updated Alerting Monitor resource", map[string]any{
+		"monitor_id": id,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the monitor along with its active alerts.
+func (r *AlertingMonitorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AlertingMonitorModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "DELETE", fmt.Sprintf("/_plugins/_alerting/monitors/%s", data.ID.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting monitor", err.Error())
+		return
+	}
+
+	// Treat 404 as already deleted.
+	if status == http.StatusNotFound {
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error deleting monitor",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Alerting Monitor resource", map[string]any{
+		"monitor_id": data.ID.ValueString(),
+	})
+}
+
+// ImportState imports a monitor by its ID. The read that follows sets enabled from the monitor
+// and the body from everything else, less the fields OpenSearch manages itself.
+func (r *AlertingMonitorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// Returns the body with enabled set, as the monitor create and update APIs expect it.
+func monitorRequestBody(body string, enabled bool) ([]byte, error) {
+	var monitor map[string]any
+
+	if err := json.Unmarshal([]byte(body), &monitor); err != nil {
+		return nil, fmt.Errorf("body must be a JSON object: %w", err)
+	}
+
+	monitor["enabled"] = enabled
+
+	return json.Marshal(monitor)
+}
+
+// Returns the monitor and whether it exists.
+func getMonitor(ctx context.Context, client *opensearchapi.Client, id string) (skpropensearch.MonitorResponse, bool, error) {
+	var monitor skpropensearch.MonitorResponse
+
+	status, body, err := performJSONRequest(ctx, client, "GET", fmt.Sprintf("/_plugins/_alerting/monitors/%s", id), nil)
+	if err != nil {
+		return monitor, false, err
+	}
+
+	if status == http.StatusNotFound {
+		return monitor, false, nil
+	}
+
+	if status < 200 || status >= 300 {
+		return monitor, false, fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	if err := json.Unmarshal(body, &monitor); err != nil {
+		return monitor, false, fmt.Errorf("could not parse monitor: %w", err)
+	}
+
+	return monitor, true, nil
+}
+
+// Replaces a monitor with the body at the given path, which may carry the sequence number to update.
+func putMonitor(ctx context.Context, client *opensearchapi.Client, path string, body []byte) error {
+	status, respBody, err := performJSONRequest(ctx, client, "PUT", path, body)
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", status, string(respBody))
+	}
+
+	return nil
+}
+
+// Enables or disables a monitor, leaving the rest of it as stored in OpenSearch. The update only applies to
+// the version of the monitor which was read, so a concurrent change fails it rather than being overwritten.
+func setMonitorEnabled(ctx context.Context, client *opensearchapi.Client, id string, enabled bool) error {
+	monitor, exists, err := getMonitor(ctx, client, id)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return fmt.Errorf("monitor %s was not found", id)
+	}
+
+	if current, _ := monitor.Monitor["enabled"].(bool); current == enabled {
+		return nil
+	}
+
+	for _, field := range skpropensearch.MonitorServerManagedFields {
+		delete(monitor.Monitor, field)
+	}
+
+	monitor.Monitor["enabled"] = enabled
+
+	body, err := json.Marshal(monitor.Monitor)
+	if err != nil {
+		return err
+	}
+
+	return putMonitor(ctx, client, fmt.Sprintf("/_plugins/_alerting/monitors/%s?if_seq_no=%d&if_primary_term=%d", id, monitor.SeqNo, monitor.PrimaryTerm), body)
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestSetMonitorEnabled(t *testing.T) {
+	tests := []struct {
+		name    string
+		current bool
+		enabled bool
+		wantPut bool
+	}{
+		{
+			name:    "disable",
+			current: true,
+			enabled: false,
+			wantPut: true,
+		},
+		{
+			name:    "enable",
+			current: false,
+			enabled: true,
+			wantPut: true,
+		},
+		{
+			name:    "already enabled",
+			current: true,
+			enabled: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var put map[string]any
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/_plugins/_alerting/monitors/monitor-1" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					return
+				}
+
+				switch r.Method {
+				case "GET":
+					writeJSON(w, http.StatusOK, fmt.Sprintf(`{"_id":"monitor-1","_seq_no":7,"_primary_term":2,"monitor":{"type":"monitor","name":"inference errors","enabled":%t,"enabled_time":1700000000000,"last_update_time":1700000000000,"schema_version":8,"schedule":{"period":{"interval":5,"unit":"MINUTES"}},"inputs":[{"search":{"indices":["ml-logs"],"query":{"size":0}}}]}}`, tt.current))
+				case "PUT":
+					if got := r.URL.RawQuery; got != "if_seq_no=7&if_primary_term=2" {
+						t.Errorf("expected the update to be guarded by the sequence number, got %q", got)
+					}
+
+					if err := json.NewDecoder(r.Body).Decode(&put); err != nil {
+						t.Errorf("could not parse update: %s", err)
+					}
+
+					writeJSON(w, http.StatusOK, `{"_id":"monitor-1","_seq_no":8,"_primary_term":2}`)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+			})
+
+			if err := setMonitorEnabled(context.Background(), client, "monitor-1", tt.enabled); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !tt.wantPut {
+				if put != nil {
+					t.Errorf("expected no update, got %v", put)
+				}
+				return
+			}
+
+			if put["enabled"] != tt.enabled {
+				t.Errorf("expected enabled %t, got %v", tt.enabled, put["enabled"])
+			}
+
+			// The rest of the monitor is sent back as it is, without the fields OpenSearch manages.
+			if put["name"] != "inference errors" || put["schedule"] == nil || put["inputs"] == nil {
+				t.Errorf("expected the monitor to be kept, got %v", put)
+			}
+
+			if _, ok := put["last_update_time"]; ok {
+				t.Errorf("expected server managed fields to be left out, got %v", put)
+			}
+		})
+	}
+}
@@ -0,0 +1,490 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &AliasResource{}
+	_ resource.ResourceWithValidateConfig = &AliasResource{}
+	_ resource.ResourceWithImportState    = &AliasResource{}
+)
+
+// NewAliasResource is a helper function to simplify the provider implementation.
+func NewAliasResource() resource.Resource {
+	return &AliasResource{}
+}
+
+// AliasResource is the resource implementation.
+type AliasResource struct {
+	providerData *ProviderData
+}
+
+// AliasModel describes the Alias resource data model.
+type AliasModel struct {
+	ID      types.String      `tfsdk:"id"`
+	Name    types.String      `tfsdk:"name"`
+	Indices []AliasIndexModel `tfsdk:"indices"`
+}
+
+// AliasIndexModel describes one index the alias points to.
+type AliasIndexModel struct {
+	Index        types.String `tfsdk:"index"`
+	Filter       JSONBody     `tfsdk:"filter"`
+	Routing      types.String `tfsdk:"routing"`
+	IsWriteIndex types.Bool   `tfsdk:"is_write_index"`
+}
+
+// Metadata returns the resource type name.
+func (r *AliasResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_alias", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Alias resource.
+func (r *AliasResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an index alias and the indices it points to. Changes are applied atomically, " +
+			"so an alias can be moved from one index to another without a moment where it points to neither.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the alias.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the alias.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"indices": schema.SetNestedAttribute{
+				MarkdownDescription: "Indices the alias points to. An index deleted outside Terraform drops out of the alias, and is added again on the next apply.",
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"index": schema.StringAttribute{
+							MarkdownDescription: "Name of the index.",
+							Required:            true,
+						},
+						"filter": schema.StringAttribute{
+							MarkdownDescription: "A JSON query limiting the documents of the index visible through the alias, e.g. `{\"term\": {\"tenant\": \"a\"}}`.",
+							Optional:            true,
+							CustomType:          JSONBodyType{},
+						},
+						"routing": schema.StringAttribute{
+							MarkdownDescription: "Routing value used to index and search through the alias.",
+							Optional:            true,
+						},
+						"is_write_index": schema.BoolAttribute{
+							MarkdownDescription: "Whether documents written to the alias go to this index. Only one index of an alias can be the write index.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks each index is only given once, as OpenSearch keeps one alias definition per index.
+func (r *AliasResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data AliasModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := make(map[string]bool, len(data.Indices))
+	writeIndices := 0
+
+	for _, index := range data.Indices {
+		if index.IsWriteIndex.ValueBool() {
+			writeIndices++
+		}
+
+		if index.Index.IsUnknown() {
+			continue
+		}
+
+		if seen[index.Index.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("indices"),
+				"Duplicate alias index",
+				fmt.Sprintf("The index %q is given more than once, an alias has a single filter, routing and is_write_index per index.", index.Index.ValueString()),
+			)
+		}
+
+		seen[index.Index.ValueString()] = true
+	}
+
+	if writeIndices > 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("indices"),
+			"Multiple write indices",
+			"Only one index of an alias can have is_write_index set to true.",
+		)
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *AliasResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *AliasResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// Create adds the alias to every index.
+func (r *AliasResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AliasModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := updateAliases(ctx, client, aliasActions(data.Name.ValueString(), nil, data.Indices)); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating alias",
+			fmt.Sprintf("Could not create alias %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+
+	tflog.Trace(ctx, "created Alias resource", map[string]any{
+		"alias": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read refreshes the indices the alias points to.
+func (r *AliasResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AliasModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	status, body, err := performJSONRequest(ctx, client, "GET", fmt.Sprintf("/_alias/%s", name), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading alias", err.Error())
+		return
+	}
+
+	// The alias is gone, e.g. because every index it pointed to was deleted.
+	if status == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error reading alias",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	var getResponse skpropensearch.AliasGetResponse
+
+	if err := json.Unmarshal(body, &getResponse); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing alias response",
+			fmt.Sprintf("Could not parse alias response: %s", err.Error()),
+		)
+		return
+	}
+
+	indices := readBackAliasIndices(name, data.Indices, getResponse)
+	if len(indices) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = data.Name
+	data.Indices = indices
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update adds the alias to new or changed indices and removes it from indices no longer listed, in one atomic request.
+func (r *AliasResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state AliasModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	actions := aliasActions(data.Name.ValueString(), state.Indices, data.Indices)
+
+	if len(actions) > 0 {
+		if err := updateAliases(ctx, client, actions); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating alias",
+				fmt.Sprintf("Could not update alias %s: %s", data.Name.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "updated Alias resource", map[string]any{
+		"alias":   data.Name.ValueString(),
+		"actions": len(actions),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the alias from every index, the indices are kept.
+func (r *AliasResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AliasModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := updateAliases(ctx, client, aliasActions(data.Name.ValueString(), data.Indices, nil)); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting alias",
+			fmt.Sprintf("Could not delete alias %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Alias resource", map[string]any{
+		"alias": data.Name.ValueString(),
+	})
+}
+
+// ImportState imports an alias by its name, with every index it points to.
+func (r *AliasResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
+
+// Returns the minimal actions moving the alias from the prior to the planned indices: removing it from indices which
+// are no longer listed, and adding it to new or changed ones (adding again replaces an index's alias definition).
+func aliasActions(alias string, prior, planned []AliasIndexModel) []skpropensearch.AliasAction {
+	var actions []skpropensearch.AliasAction
+
+	plannedIndices := make(map[string]AliasIndexModel, len(planned))
+	for _, index := range planned {
+		plannedIndices[index.Index.ValueString()] = index
+	}
+
+	priorIndices := make(map[string]AliasIndexModel, len(prior))
+	for _, index := range prior {
+		priorIndices[index.Index.ValueString()] = index
+	}
+
+	for _, index := range prior {
+		if _, ok := plannedIndices[index.Index.ValueString()]; !ok {
+			actions = append(actions, skpropensearch.AliasAction{
+				Remove: &skpropensearch.AliasActionParams{Index: index.Index.ValueString(), Alias: alias},
+			})
+		}
+	}
+
+	for _, index := range planned {
+		if priorIndex, ok := priorIndices[index.Index.ValueString()]; ok && priorIndex.equal(index) {
+			continue
+		}
+
+		add := &skpropensearch.AliasActionParams{
+			Index:   index.Index.ValueString(),
+			Alias:   alias,
+			Routing: index.Routing.ValueString(),
+		}
+
+		if !index.Filter.IsNull() {
+			add.Filter = json.RawMessage(index.Filter.ValueString())
+		}
+
+		if !index.IsWriteIndex.IsNull() {
+			isWriteIndex := index.IsWriteIndex.ValueBool()
+			add.IsWriteIndex = &isWriteIndex
+		}
+
+		actions = append(actions, skpropensearch.AliasAction{Add: add})
+	}
+
+	return actions
+}
+
+// Reports whether both describe the same alias definition, comparing filters as JSON.
+func (m AliasIndexModel) equal(other AliasIndexModel) bool {
+	if !m.Index.Equal(other.Index) || !m.Routing.Equal(other.Routing) || !m.IsWriteIndex.Equal(other.IsWriteIndex) {
+		return false
+	}
+
+	if m.Filter.IsNull() || other.Filter.IsNull() {
+		return m.Filter.IsNull() == other.Filter.IsNull()
+	}
+
+	equal, err := jsonEqualIgnoring(m.Filter.ValueString(), other.Filter.ValueString(), nil)
+
+	return err == nil && equal
+}
+
+// Applies alias actions atomically.
+func updateAliases(ctx context.Context, client *opensearchapi.Client, actions []skpropensearch.AliasAction) error {
+	requestBody, err := json.Marshal(skpropensearch.AliasActionsRequest{Actions: actions})
+	if err != nil {
+		return err
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "POST", "/_aliases", requestBody)
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	return nil
+}
+
+// Returns the indices the alias points to in OpenSearch, sorted by name. Values which match the state keep
+// the state's form, e.g. a filter formatted differently, or is_write_index left unset while it is false.
+func readBackAliasIndices(alias string, state []AliasIndexModel, remote skpropensearch.AliasGetResponse) []AliasIndexModel {
+	stateIndices := make(map[string]AliasIndexModel, len(state))
+	for _, index := range state {
+		stateIndices[index.Index.ValueString()] = index
+	}
+
+	names := make([]string, 0, len(remote))
+	for name, aliases := range remote {
+		if _, ok := aliases.Aliases[alias]; ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	indices := make([]AliasIndexModel, 0, len(names))
+
+	for _, name := range names {
+		actual := remote[name].Aliases[alias]
+		prior := stateIndices[name]
+
+		index := AliasIndexModel{
+			Index:        types.StringValue(name),
+			Filter:       NewJSONBodyNull(),
+			Routing:      types.StringNull(),
+			IsWriteIndex: types.BoolNull(),
+		}
+
+		if len(actual.Filter) > 0 {
+			index.Filter = NewJSONBodyValue(string(actual.Filter))
+
+			if !prior.Filter.IsNull() {
+				if equal, err := jsonEqualIgnoring(prior.Filter.ValueString(), string(actual.Filter), nil); err == nil && equal {
+					index.Filter = prior.Filter
+				}
+			}
+		}
+
+		// Routing is managed as a single value for indexing and searching, so separate values set outside Terraform show up as drift.
+		if actual.IndexRouting != "" || actual.SearchRouting != "" {
+			index.Routing = types.StringValue(actual.IndexRouting)
+			if actual.IndexRouting != actual.SearchRouting {
+				index.Routing = types.StringValue(fmt.Sprintf("%s (search: %s)", actual.IndexRouting, actual.SearchRouting))
+			}
+		}
+
+		if actual.IsWriteIndex != nil && (*actual.IsWriteIndex || !prior.IsWriteIndex.IsNull()) {
+			index.IsWriteIndex = types.BoolValue(*actual.IsWriteIndex)
+		}
+
+		indices = append(indices, index)
+	}
+
+	return indices
+}
@@ -0,0 +1,422 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// Bedrock runtime APIs a connector can call.
+const (
+	bedrockAPIConverse = "converse"
+	bedrockAPIInvoke   = "invoke"
+)
+
+// Request body sent to the Converse API, which has the same shape for every Bedrock model.
+const bedrockConverseRequestBody = `{"messages": ${parameters.messages}}`
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &BedrockConnectorResource{}
+	_ resource.ResourceWithValidateConfig = &BedrockConnectorResource{}
+)
+
+// NewBedrockConnectorResource is a helper function to simplify the provider implementation.
+func NewBedrockConnectorResource() resource.Resource {
+	return &BedrockConnectorResource{}
+}
+
+// BedrockConnectorResource is the resource implementation.
+type BedrockConnectorResource struct {
+	providerData *ProviderData
+}
+
+// BedrockConnectorModel describes the Bedrock Connector resource data model.
+type BedrockConnectorModel struct {
+	ID                  types.String `tfsdk:"id"`
+	Name                types.String `tfsdk:"name"`
+	Description         types.String `tfsdk:"description"`
+	Region              types.String `tfsdk:"region"`
+	Model               types.String `tfsdk:"model"`
+	API                 types.String `tfsdk:"api"`
+	RequestBody         types.String `tfsdk:"request_body"`
+	PreProcessFunction  types.String `tfsdk:"pre_process_function"`
+	PostProcessFunction types.String `tfsdk:"post_process_function"`
+	Parameters          types.Map    `tfsdk:"parameters"`
+	RoleArn             types.String `tfsdk:"role_arn"`
+	AccessKey           types.String `tfsdk:"access_key"`
+	SecretKey           types.String `tfsdk:"secret_key"`
+	SessionToken        types.String `tfsdk:"session_token"`
+}
+
+// Metadata returns the data source type name.
+func (r *BedrockConnectorResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_bedrock_connector", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Bedrock Connector resource.
+func (r *BedrockConnectorResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Connectors can't be updated, so every argument replaces the connector.
+	requiresReplace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Connector to a model hosted on Amazon Bedrock, assembled from typed attributes. " +
+			"Use `opensearch_connector` for other remote model providers.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier for the connector.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the connector.",
+				Required:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the connector.",
+				Optional:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "AWS region Bedrock is called in, e.g. `us-east-1`.",
+				Required:            true,
+				PlanModifiers:       requiresReplace,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d+$`), "must be an AWS region, e.g. us-east-1"),
+				},
+			},
+			"model": schema.StringAttribute{
+				MarkdownDescription: "Bedrock model ID, e.g. `anthropic.claude-3-haiku-20240307-v1:0` or `amazon.titan-embed-text-v2:0`.",
+				Required:            true,
+				PlanModifiers:       requiresReplace,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"api": schema.StringAttribute{
+				MarkdownDescription: "Bedrock runtime API to call, `converse` or `invoke`. Defaults to `converse`, which accepts the same request for every model. " +
+					"`invoke` requires `request_body` in the format of the model.",
+				Optional:      true,
+				Computed:      true,
+				Default:       stringdefault.StaticString(bedrockAPIConverse),
+				PlanModifiers: requiresReplace,
+				Validators: []validator.String{
+					stringvalidator.OneOf(bedrockAPIConverse, bedrockAPIInvoke),
+				},
+			},
+			"request_body": schema.StringAttribute{
+				MarkdownDescription: "Request body template of the predict action, e.g. `{\"inputText\": \"${parameters.inputText}\"}`. " +
+					"Defaults to `{\"messages\": ${parameters.messages}}` for the `converse` API.",
+				Optional:      true,
+				PlanModifiers: requiresReplace,
+			},
+			"pre_process_function": schema.StringAttribute{
+				MarkdownDescription: "Pre-processing function of the predict action, e.g. `connector.pre_process.bedrock.embedding`.",
+				Optional:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"post_process_function": schema.StringAttribute{
+				MarkdownDescription: "Post-processing function of the predict action, e.g. `connector.post_process.bedrock.embedding`.",
+				Optional:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"parameters": schema.MapAttribute{
+				MarkdownDescription: "Additional connector parameters, e.g. defaults referenced by `request_body`. `region`, `service_name` and `model` are always set from the typed attributes.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"role_arn": schema.StringAttribute{
+				MarkdownDescription: "ARN of the IAM role OpenSearch assumes to call Bedrock. Exactly one of `role_arn` or `access_key` must be set.",
+				Optional:            true,
+				PlanModifiers:       requiresReplace,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("access_key")),
+				},
+			},
+			"access_key": schema.StringAttribute{
+				MarkdownDescription: "AWS access key used to call Bedrock.",
+				Optional:            true,
+				Sensitive:           true,
+				PlanModifiers:       requiresReplace,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("secret_key")),
+				},
+			},
+			"secret_key": schema.StringAttribute{
+				MarkdownDescription: "AWS secret key used to call Bedrock.",
+				Optional:            true,
+				Sensitive:           true,
+				PlanModifiers:       requiresReplace,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("access_key")),
+				},
+			},
+			"session_token": schema.StringAttribute{
+				MarkdownDescription: "AWS session token for temporary access keys.",
+				Optional:            true,
+				Sensitive:           true,
+				PlanModifiers:       requiresReplace,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("access_key")),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the request body is set when it can't be defaulted.
+func (r *BedrockConnectorResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data BedrockConnectorModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.API.ValueString() == bedrockAPIInvoke && data.RequestBody.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("request_body"),
+			"Missing request body",
+			"request_body must be set when using the invoke API, as its format depends on the model.",
+		)
+	}
+}
+
+// Returns the connector create body for the Bedrock blueprint.
+func (m BedrockConnectorModel) createBody(ctx context.Context) ([]byte, error) {
+	parameters := map[string]string{}
+
+	if !m.Parameters.IsNull() {
+		if diags := m.Parameters.ElementsAs(ctx, &parameters, false); diags.HasError() {
+			return nil, fmt.Errorf("could not read parameters")
+		}
+	}
+
+	parameters["region"] = m.Region.ValueString()
+	parameters["service_name"] = "bedrock"
+	parameters["model"] = m.Model.ValueString()
+
+	api := m.API.ValueString()
+	if api == "" {
+		api = bedrockAPIConverse
+	}
+
+	requestBody := m.RequestBody.ValueString()
+	if m.RequestBody.IsNull() {
+		requestBody = bedrockConverseRequestBody
+	}
+
+	action := map[string]any{
+		"action_type": "predict",
+		"method":      "POST",
+		"url":         fmt.Sprintf("https://bedrock-runtime.${parameters.region}.amazonaws.com/model/${parameters.model}/%s", api),
+		"headers": map[string]string{
+			"content-type":         "application/json",
+			"x-amz-content-sha256": "required",
+		},
+		"request_body": requestBody,
+	}
+
+	if !m.PreProcessFunction.IsNull() {
+		action["pre_process_function"] = m.PreProcessFunction.ValueString()
+	}
+
+	if !m.PostProcessFunction.IsNull() {
+		action["post_process_function"] = m.PostProcessFunction.ValueString()
+	}
+
+	credential := map[string]string{}
+
+	if !m.RoleArn.IsNull() {
+		credential["roleArn"] = m.RoleArn.ValueString()
+	} else {
+		credential["access_key"] = m.AccessKey.ValueString()
+		credential["secret_key"] = m.SecretKey.ValueString()
+
+		if !m.SessionToken.IsNull() {
+			credential["session_token"] = m.SessionToken.ValueString()
+		}
+	}
+
+	body := map[string]any{
+		"name":       m.Name.ValueString(),
+		"version":    1,
+		"protocol":   "aws_sigv4",
+		"parameters": parameters,
+		"credential": credential,
+		"actions":    []any{action},
+	}
+
+	if !m.Description.IsNull() {
+		body["description"] = m.Description.ValueString()
+	}
+
+	return json.Marshal(body)
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *BedrockConnectorResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *BedrockConnectorResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// Create a new Bedrock connector in OpenSearch.
+func (r *BedrockConnectorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BedrockConnectorModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	createBody, err := data.createBody(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating connector request body",
+			fmt.Sprintf("Could not create connector create request body: %s", err.Error()),
+		)
+		return
+	}
+
+	connectorID, _, err := createConnector(ctx, client, createBody)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating connector",
+			fmt.Sprintf("Could not create connector: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(connectorID)
+
+	tflog.Trace(ctx, "created Bedrock Connector resource", map[string]any{
+		"connector_id": connectorID,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read the resource state from OpenSearch for our connector.
+func (r *BedrockConnectorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BedrockConnectorModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	_, exists, err := getConnector(ctx, client, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading connector", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if !exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update is not supported; every argument replaces the connector.
+func (r *BedrockConnectorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data BedrockConnectorModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete the connector from OpenSearch.
+func (r *BedrockConnectorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BedrockConnectorModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := deleteConnector(ctx, client, data.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting connector",
+			fmt.Sprintf("Could not delete connector %s: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Bedrock Connector resource", map[string]any{
+		"connector_id": data.ID.ValueString(),
+	})
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Sends the operations in a single POST /_bulk request. A 200 doesn't mean every item succeeded,
+// see bulkDiagnostics for the items which failed.
+func performBulk(ctx context.Context, client *opensearchapi.Client, operations []skpropensearch.BulkOperation) (skpropensearch.BulkResponse, error) {
+	var bulkResponse skpropensearch.BulkResponse
+
+	if len(operations) == 0 {
+		return bulkResponse, nil
+	}
+
+	body, err := skpropensearch.BulkBody(operations)
+	if err != nil {
+		return bulkResponse, err
+	}
+
+	status, respBody, err := performRequest(ctx, client, "POST", "/_bulk", "application/x-ndjson", body)
+	if err != nil {
+		return bulkResponse, err
+	}
+
+	if status < 200 || status >= 300 {
+		return bulkResponse, fmt.Errorf("OpenSearch returned %d: %s", status, string(respBody))
+	}
+
+	if err := json.Unmarshal(respBody, &bulkResponse); err != nil {
+		return bulkResponse, fmt.Errorf("could not parse bulk response: %w", err)
+	}
+
+	return bulkResponse, nil
+}
+
+// Returns an error for each item of a bulk request which failed, e.g. a document rejected by its mapping.
+func bulkDiagnostics(summary string, bulkResponse skpropensearch.BulkResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	failures := bulkResponse.Failures()
+
+	for _, failure := range failures {
+		diags.AddError(
+			summary,
+			fmt.Sprintf("%d of %d bulk items failed, %s", len(failures), len(bulkResponse.Items), failure.String()),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+func TestPerformBulkWithFailedItems(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/_bulk" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+
+		if got := r.Header.Get("Content-Type"); got != "application/x-ndjson" {
+			t.Errorf("expected an ndjson body, got %q", got)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if lines := strings.Count(string(body), "\n"); lines != 4 {
+			t.Errorf("expected 4 lines, got %d: %s", lines, body)
+		}
+
+		// Bulk responds with a 200 even when items fail.
+		writeJSON(w, http.StatusOK, `{"took":5,"errors":true,"items":[
+			{"index":{"_index":"seed","_id":"1","status":201,"result":"created"}},
+			{"index":{"_index":"seed","_id":"2","status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse field [count] of type [long]"}}}
+		]}`)
+	})
+
+	response, err := performBulk(context.Background(), client, []skpropensearch.BulkOperation{
+		{Action: skpropensearch.BulkActionIndex, Index: "seed", ID: "1", Document: map[string]any{"count": 1}},
+		{Action: skpropensearch.BulkActionIndex, Index: "seed", ID: "2", Document: map[string]any{"count": "many"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	diags := bulkDiagnostics("Error seeding documents", response)
+	if len(diags) != 1 {
+		t.Fatalf("expected one diagnostic, got %v", diags)
+	}
+
+	want := "1 of 2 bulk items failed, index seed/2 (item 1): 400 mapper_parsing_exception: failed to parse field [count] of type [long]"
+	if got := diags[0].Detail(); got != want {
+		t.Errorf("expected detail %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,422 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource               = &ClusterSettingsResource{}
+	_ resource.ResourceWithModifyPlan = &ClusterSettingsResource{}
+)
+
+// NewClusterSettingsResource is a helper function to simplify the provider implementation.
+func NewClusterSettingsResource() resource.Resource {
+	return &ClusterSettingsResource{}
+}
+
+// ClusterSettingsResource is the resource implementation.
+type ClusterSettingsResource struct {
+	providerData *ProviderData
+}
+
+// ClusterSettingsModel describes the Cluster Settings resource data model.
+type ClusterSettingsModel struct {
+	ID         types.String `tfsdk:"id"`
+	Persistent JSONBody     `tfsdk:"persistent"`
+	Transient  JSONBody     `tfsdk:"transient"`
+}
+
+// The cluster has a single set of settings, so every instance of the resource shares this ID.
+const clusterSettingsID = "cluster_settings"
+
+// Metadata returns the resource type name.
+func (r *ClusterSettingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_cluster_settings", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Cluster Settings resource.
+func (r *ClusterSettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Applies dynamic cluster settings, e.g. `plugins.ml_commons.only_run_on_ml_node`. " +
+			"Only the settings given here are managed, other cluster settings are left as they are. " +
+			"Settings which are removed from the configuration, or all of them when the resource is deleted, are reset to their defaults.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Always `cluster_settings`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"persistent": schema.StringAttribute{
+				MarkdownDescription: "A JSON object of settings which persist across cluster restarts, either nested or flat, " +
+					"e.g. `{\"plugins.ml_commons.only_run_on_ml_node\": false}`. Only the settings given here are checked for drift.",
+				Optional:   true,
+				CustomType: JSONBodyType{},
+				Validators: []validator.String{
+					stringvalidator.AtLeastOneOf(path.MatchRoot("transient")),
+				},
+				PlanModifiers: []planmodifier.String{
+					UseStateForSemanticallyEqualJSON(),
+				},
+			},
+			"transient": schema.StringAttribute{
+				MarkdownDescription: "A JSON object of settings which are lost when the cluster restarts, in the same form as `persistent`. " +
+					"OpenSearch deprecates transient settings, prefer `persistent`.",
+				Optional:   true,
+				CustomType: JSONBodyType{},
+				PlanModifiers: []planmodifier.String{
+					UseStateForSemanticallyEqualJSON(),
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *ClusterSettingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *ClusterSettingsResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// ModifyPlan rejects the resource when the provider is configured for OpenSearch Serverless.
+func (r *ClusterSettingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.providerData.serverlessDiagnostics("opensearch_cluster_settings")...)
+}
+
+// Create applies the settings.
+func (r *ClusterSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ClusterSettingsModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	update, err := clusterSettingsUpdate(ClusterSettingsModel{}, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating cluster settings",
+			fmt.Sprintf("Could not parse cluster settings: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := putClusterSettings(ctx, client, update); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating cluster settings",
+			fmt.Sprintf("Could not apply cluster settings: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(clusterSettingsID)
+
+	tflog.Trace(ctx, "created Cluster Settings resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read refreshes the configured settings from OpenSearch.
+func (r *ClusterSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ClusterSettingsModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	// Defaults are included so a setting reset to its default is compared against the default value.
+	status, body, err := performJSONRequest(ctx, client, "GET", "/_cluster/settings?flat_settings=true&include_defaults=true", nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading cluster settings", err.Error())
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error reading cluster settings",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	var getResponse skpropensearch.ClusterSettingsResponse
+
+	if err := json.Unmarshal(body, &getResponse); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing cluster settings response",
+			fmt.Sprintf("Could not parse cluster settings response: %s", err.Error()),
+		)
+		return
+	}
+
+	for _, settings := range []struct {
+		value  *JSONBody
+		remote map[string]any
+	}{
+		{&data.Persistent, getResponse.Persistent},
+		{&data.Transient, getResponse.Transient},
+	} {
+		if settings.value.IsNull() {
+			continue
+		}
+
+		remote := flattenIndexSettings(getResponse.Defaults)
+		for setting, value := range flattenIndexSettings(settings.remote) {
+			remote[setting] = value
+		}
+
+		readBack, err := readBackIndexSettings(settings.value.ValueString(), remote)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading cluster settings",
+				fmt.Sprintf("Could not compare cluster settings: %s", err.Error()),
+			)
+			return
+		}
+
+		*settings.value = NewJSONBodyValue(readBack)
+	}
+
+	data.ID = types.StringValue(clusterSettingsID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update applies the changed settings and resets the ones no longer configured.
+func (r *ClusterSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ClusterSettingsModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	update, err := clusterSettingsUpdate(state, data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating cluster settings",
+			fmt.Sprintf("Could not parse cluster settings: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := putClusterSettings(ctx, client, update); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating cluster settings",
+			fmt.Sprintf("Could not apply cluster settings: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(clusterSettingsID)
+
+	tflog.Trace(ctx, "updated Cluster Settings resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete resets the managed settings to their defaults, leaving the rest alone.
+func (r *ClusterSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ClusterSettingsModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	update, err := clusterSettingsUpdate(data, ClusterSettingsModel{})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting cluster settings",
+			fmt.Sprintf("Could not parse cluster settings: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := putClusterSettings(ctx, client, update); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting cluster settings",
+			fmt.Sprintf("Could not reset cluster settings: %s", err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Cluster Settings resource")
+}
+
+// Returns the body of PUT /_cluster/settings which moves the cluster from the prior settings to the planned ones.
+// Planned settings are sent as they are, and settings only in the prior ones are sent as null, which resets them.
+// Settings in neither are left out, so OpenSearch keeps them.
+func clusterSettingsUpdate(prior, planned ClusterSettingsModel) (skpropensearch.ClusterSettingsRequest, error) {
+	var update skpropensearch.ClusterSettingsRequest
+
+	var err error
+
+	update.Persistent, err = clusterSettingsChanges(prior.Persistent, planned.Persistent)
+	if err != nil {
+		return update, fmt.Errorf("persistent: %w", err)
+	}
+
+	update.Transient, err = clusterSettingsChanges(prior.Transient, planned.Transient)
+	if err != nil {
+		return update, fmt.Errorf("transient: %w", err)
+	}
+
+	return update, nil
+}
+
+// Returns the settings to send to PUT /_cluster/settings to go from the prior settings to the planned ones. Only the
+// planned settings are sent, so settings managed elsewhere are left alone, and settings removed from the plan are sent
+// as null, which resets them to their defaults.
+func clusterSettingsChanges(prior, planned JSONBody) (map[string]any, error) {
+	priorSettings, err := parseClusterSettings(prior)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := parseClusterSettings(planned)
+	if err != nil {
+		return nil, err
+	}
+
+	for setting := range priorSettings {
+		if _, ok := changes[setting]; !ok {
+			changes[setting] = nil
+		}
+	}
+
+	return changes, nil
+}
+
+// Parses a settings JSON object into flat setting names with their values, e.g. {"cluster": {"max_shards_per_node": 2000}}
+// becomes {"cluster.max_shards_per_node": 2000}, so nested and dotted names of the same setting compare equal.
+func parseClusterSettings(settings JSONBody) (map[string]any, error) {
+	flat := make(map[string]any)
+
+	if settings.IsNull() || settings.IsUnknown() || settings.ValueString() == "" {
+		return flat, nil
+	}
+
+	var parsed map[string]any
+
+	if err := json.Unmarshal([]byte(settings.ValueString()), &parsed); err != nil {
+		return nil, fmt.Errorf("settings must be a JSON object: %w", err)
+	}
+
+	flattenClusterSettingsInto(flat, "", parsed)
+
+	return flat, nil
+}
+
+func flattenClusterSettingsInto(flat map[string]any, prefix string, settings map[string]any) {
+	for key, value := range settings {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+
+		if nested, ok := value.(map[string]any); ok {
+			flattenClusterSettingsInto(flat, name, nested)
+			continue
+		}
+
+		flat[name] = value
+	}
+}
+
+// Applies the cluster settings.
+func putClusterSettings(ctx context.Context, client *opensearchapi.Client, update skpropensearch.ClusterSettingsRequest) error {
+	requestBody, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "PUT", "/_cluster/settings", requestBody)
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestClusterSettingsChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		prior   JSONBody
+		planned JSONBody
+		want    string
+	}{
+		{
+			name:    "removed setting",
+			prior:   NewJSONBodyValue(`{"cluster":{"max_shards_per_node":2000,"routing":{"allocation":{"enable":"all"}}}}`),
+			planned: NewJSONBodyValue(`{"cluster":{"max_shards_per_node":3000}}`),
+			want:    `{"cluster.max_shards_per_node":3000,"cluster.routing.allocation.enable":null}`,
+		},
+		{
+			name:    "all settings removed",
+			prior:   NewJSONBodyValue(`{"indices.recovery.max_bytes_per_sec":"100mb"}`),
+			planned: JSONBody{StringValue: types.StringNull()},
+			want:    `{"indices.recovery.max_bytes_per_sec":null}`,
+		},
+		{
+			name:    "nested and dotted names",
+			prior:   NewJSONBodyValue(`{"cluster.max_shards_per_node":2000}`),
+			planned: NewJSONBodyValue(`{"cluster":{"max_shards_per_node":2000}}`),
+			want:    `{"cluster.max_shards_per_node":2000}`,
+		},
+		{
+			name:    "added setting",
+			prior:   JSONBody{StringValue: types.StringNull()},
+			planned: NewJSONBodyValue(`{"action.auto_create_index":false}`),
+			want:    `{"action.auto_create_index":false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := clusterSettingsChanges(tt.prior, tt.planned)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			got, err := json.Marshal(changes)
+			if err != nil {
+				t.Fatalf("could not marshal changes: %s", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("expected changes %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := clusterSettingsChanges(NewJSONBodyValue(`["cluster"]`), NewJSONBodyValue(`{}`)); err == nil {
+		t.Error("expected an error for settings which are not a JSON object")
+	}
+}
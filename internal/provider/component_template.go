@@ -0,0 +1,317 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &ComponentTemplateResource{}
+	_ resource.ResourceWithImportState = &ComponentTemplateResource{}
+)
+
+// NewComponentTemplateResource is a helper function to simplify the provider implementation.
+func NewComponentTemplateResource() resource.Resource {
+	return &ComponentTemplateResource{}
+}
+
+// ComponentTemplateResource is the resource implementation.
+type ComponentTemplateResource struct {
+	providerData *ProviderData
+}
+
+// ComponentTemplateModel describes the Component Template resource data model.
+type ComponentTemplateModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Body JSONBody     `tfsdk:"body"`
+}
+
+// Metadata returns the resource type name.
+func (r *ComponentTemplateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_component_template", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Component Template resource.
+func (r *ComponentTemplateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a component template, a reusable block of settings, mappings and aliases which index templates list in `composed_of`. " +
+			"Changes only apply to indices created afterwards.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the component template.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the component template.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "A JSON payload which defines the component template, e.g. its `template`, `version` and `_meta`. " +
+					"Only the fields given here are checked for drift.",
+				Required:   true,
+				CustomType: JSONBodyType{},
+				PlanModifiers: []planmodifier.String{
+					UseStateForSemanticallyEqualJSON(),
+				},
+				Validators: []validator.String{
+					JSONBodyFields("component template", skpropensearch.ComponentTemplateBodyFields),
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *ComponentTemplateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *ComponentTemplateResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// Create creates the component template.
+func (r *ComponentTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ComponentTemplateModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := putComponentTemplate(ctx, client, data.Name.ValueString(), data.Body.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating component template",
+			fmt.Sprintf("Could not create component template %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+
+	tflog.Trace(ctx, "created Component Template resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read compares the component template in OpenSearch with the state.
+func (r *ComponentTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ComponentTemplateModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	status, body, err := performJSONRequest(ctx, client, "GET", fmt.Sprintf("/_component_template/%s", name), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading component template", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if status == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error reading component template",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	var getResponse skpropensearch.ComponentTemplateGetResponse
+
+	if err := json.Unmarshal(body, &getResponse); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing component template response",
+			fmt.Sprintf("Could not parse component template response: %s", err.Error()),
+		)
+		return
+	}
+
+	var template json.RawMessage
+
+	for _, item := range getResponse.ComponentTemplates {
+		if item.Name == name {
+			template = item.ComponentTemplate
+		}
+	}
+
+	if template == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Component templates nest settings under "template" like index templates, so they are read back the same way.
+	templateBody, err := readBackIndexTemplateBody(data.Body.ValueString(), template)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading component template",
+			fmt.Sprintf("Could not compare component template %s: %s", name, err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+	data.Body = NewJSONBodyValue(templateBody)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update replaces the component template in place, templates are mutable.
+func (r *ComponentTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ComponentTemplateModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := putComponentTemplate(ctx, client, data.Name.ValueString(), data.Body.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating component template",
+			fmt.Sprintf("Could not update component template %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "updated Component Template resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the component template, OpenSearch refuses while an index template is composed of it.
+func (r *ComponentTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ComponentTemplateModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "DELETE", fmt.Sprintf("/_component_template/%s", data.Name.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting component template", err.Error())
+		return
+	}
+
+	// Treat 404 as already deleted.
+	if status == http.StatusNotFound {
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error deleting component template",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Component Template resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+}
+
+// ImportState imports an component template by its name, reading its whole body back from OpenSearch.
+func (r *ComponentTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
+
+// Creates or replaces the named component template.
+func putComponentTemplate(ctx context.Context, client *opensearchapi.Client, name, body string) error {
+	status, respBody, err := performJSONRequest(ctx, client, "PUT", fmt.Sprintf("/_component_template/%s", name), []byte(body))
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", status, string(respBody))
+	}
+
+	return nil
+}
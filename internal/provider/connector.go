@@ -3,15 +3,30 @@ package provider
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
@@ -20,7 +35,12 @@ import (
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
-var _ resource.Resource = &ConnectorResource{}
+var (
+	_ resource.Resource                 = &ConnectorResource{}
+	_ resource.ResourceWithImportState  = &ConnectorResource{}
+	_ resource.ResourceWithModifyPlan   = &ConnectorResource{}
+	_ resource.ResourceWithUpgradeState = &ConnectorResource{}
+)
 
 // NewConnectorResource is a helper function to simplify the provider implementation.
 func NewConnectorResource() resource.Resource {
@@ -29,13 +49,21 @@ func NewConnectorResource() resource.Resource {
 
 // ConnectorResource is the resource implementation.
 type ConnectorResource struct {
-	config opensearchapi.Config
+	providerData *ProviderData
 }
 
 // ConnectorModel describes the Model Register resource data model.
 type ConnectorModel struct {
-	ID   types.String `tfsdk:"id"`
-	Body types.String `tfsdk:"body"`
+	ID                     types.String `tfsdk:"id"`
+	Body                   JSONBody     `tfsdk:"body"`
+	IgnoreBodyPaths        types.List   `tfsdk:"ignore_body_paths"`
+	Credential             types.Map    `tfsdk:"credential"`
+	EndpointOverride       types.String `tfsdk:"endpoint_override"`
+	AdoptExisting          types.Bool   `tfsdk:"adopt_existing"`
+	PreventDeleteIfInUse   types.Bool   `tfsdk:"prevent_delete_if_in_use"`
+	CaptureResponseHeaders types.Bool   `tfsdk:"capture_response_headers"`
+	ResponseHeaders        types.Map    `tfsdk:"response_headers"`
+	CredentialFingerprint  types.String `tfsdk:"credential_fingerprint"`
 }
 
 // Metadata returns the data source type name.
@@ -46,6 +74,9 @@ func (r *ConnectorResource) Metadata(ctx context.Context, req resource.MetadataR
 // Schema defines the schema for the Model Register resource.
 func (r *ConnectorResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// Version 1 added computed and defaulted attributes, see UpgradeState.
+		Version: 1,
+
 		MarkdownDescription: "Connector resource",
 
 		Attributes: map[string]schema.Attribute{
@@ -57,13 +88,82 @@ func (r *ConnectorResource) Schema(ctx context.Context, req resource.SchemaReque
 				},
 			},
 			"body": schema.StringAttribute{
-				MarkdownDescription: "A JSON payload which defines the connector configuration.",
-				Required:            true,
+				MarkdownDescription: "A JSON payload which defines the connector configuration. Changes which only add or change `parameters` " +
+					"(e.g. `region` or `model`) update the connector in place, any other change creates the connector again.",
+				Required:   true,
+				CustomType: JSONBodyType{},
+				Validators: []validator.String{
+					JSONBodyFields("connector", skpropensearch.ConnectorBodyFields),
+				},
+				PlanModifiers: []planmodifier.String{
+					UseStateForSemanticallyEqualJSONIgnoring("ignore_body_paths"),
+					connectorBodyReplaceModifier{},
+				},
+			},
+			"ignore_body_paths": schema.ListAttribute{
+				MarkdownDescription: "JSON pointers (RFC 6901, e.g. `/created_time` or `/actions/0/headers`) excluded when comparing `body`, " +
+					"so server managed fields don't cause changes.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^/`), "must be a JSON pointer starting with \"/\""),
+					),
+				},
+			},
+			"credential": schema.MapAttribute{
+				MarkdownDescription: "Secrets merged into the `credential` object of `body` when the connector is created, " +
+					"so they can be kept out of the reviewable body. Values set here win over the same keys in `body`.",
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"endpoint_override": schema.StringAttribute{
+				MarkdownDescription: "Scheme and host (e.g. `https://api.staging.example.com`) replacing those of every action `url` in `body` when the connector is created, " +
+					"so the same body can target each environment. The path and query of each action are kept, and `body` is authoritative for everything else.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^https?://[^/?#\s]+/?$`), "must be a URL with only a scheme and host, e.g. \"https://api.example.com\""),
+				},
 				PlanModifiers: []planmodifier.String{
-					// Registering again is the only supported “update”.
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Adopt an existing connector with the same `name` as the body instead of creating a duplicate, " +
+					"e.g. to recover from an interrupted apply. OpenSearch does not enforce unique connector names, " +
+					"so creation fails if more than one connector has the name, and warns when the adopted connector differs from the body.",
+				Optional: true,
+			},
+			"prevent_delete_if_in_use": schema.BoolAttribute{
+				MarkdownDescription: "Refuse to delete the connector while any model uses it, listing those models, which costs one extra model search per delete. " +
+					"Defaults to `false`. Recommended for connectors shared between configurations, so one can't tear down a connector another depends on.",
+				Optional: true,
+			},
+			"capture_response_headers": schema.BoolAttribute{
+				MarkdownDescription: "Whether to record rate limit and quota headers returned when the connector is created or updated in `response_headers`.",
+				Optional:            true,
+			},
+			"response_headers": schema.MapAttribute{
+				MarkdownDescription: "Rate limit and quota headers (e.g. `retry-after`, `x-ratelimit-remaining`) returned when the connector was last created or updated. " +
+					"Only populated when `capture_response_headers` is enabled, authentication related headers are never captured.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"credential_fingerprint": schema.StringAttribute{
+				MarkdownDescription: "HMAC-SHA256 of the `credential` object the connector was last created with, i.e. `credential` merged over the one in `body`, " +
+					"keyed with a random salt kept in the resource's private state so the credential can't be guessed from it. " +
+					"OpenSearch encrypts credentials and never returns them, so this tracks the last supplied credential instead. " +
+					"Rotating credentials replaces the connector and its salt, so plans show the fingerprint as known after apply. " +
+					"It is null when there is no credential, or when the connector was imported until the next apply.",
+				Computed: true,
+			},
 		},
 	}
 }
@@ -74,22 +174,22 @@ func (r *ConnectorResource) Configure(ctx context.Context, req resource.Configur
 		return
 	}
 
-	opensearchConfig, ok := req.ProviderData.(opensearchapi.Config)
+	providerData, ok := req.ProviderData.(*ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected opensearchapi.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.config = opensearchConfig
+	r.providerData = providerData
 }
 
-// Returns a configured OpenSearch client.
+// Returns the OpenSearch client shared by the provider.
 // https://github.com/opensearch-project/opensearch-go/blob/main/_samples/json.go
 func (r *ConnectorResource) client() (*opensearchapi.Client, error) {
-	return opensearchapi.NewClient(r.config)
+	return r.providerData.client()
 }
 
 // Create registers a new model in OpenSearch.
@@ -110,60 +210,532 @@ func (r *ConnectorResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	registerRequest, err := http.NewRequestWithContext(ctx, "POST", "/_plugins/_ml/connectors/_create", bytes.NewReader([]byte(data.Body.ValueString())))
+	if data.AdoptExisting.ValueBool() {
+		connectorID, err := findConnectorByBodyName(ctx, client, data.Body.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error adopting existing connector",
+				fmt.Sprintf("Could not look up an existing connector: %s", err.Error()),
+			)
+			return
+		}
+
+		if connectorID != "" {
+			differences, err := data.adoptedConnectorDifferences(ctx, client, connectorID)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error adopting existing connector",
+					fmt.Sprintf("Could not compare connector %s with the body: %s", connectorID, err.Error()),
+				)
+				return
+			}
+
+			// Connector names aren't unique, so the connector could belong to another configuration.
+			if len(differences) > 0 {
+				resp.Diagnostics.AddWarning(
+					"Adopted connector differs from the body",
+					fmt.Sprintf("Connector %s was adopted by its name, but its %s differ from the body. "+
+						"The next plan shows the differences; make sure the connector isn't managed elsewhere before applying them.",
+						connectorID, strings.Join(differences, ", ")),
+				)
+			}
+
+			data.ID = types.StringValue(connectorID)
+			data.ResponseHeaders = types.MapNull(types.StringType)
+
+			resp.Diagnostics.Append(data.setCredentialFingerprint(ctx, resp.Private)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			tflog.Trace(ctx, "adopted existing Connector resource", map[string]any{
+				"connector_id": connectorID,
+			})
+
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
+	createBody, err := data.createBody(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error creating connector request",
-			fmt.Sprintf("Could not create connector create request: %s", err.Error()),
+			"Error creating connector request body",
+			fmt.Sprintf("Could not create connector create request body: %s", err.Error()),
 		)
 		return
 	}
 
-	registerRequest.Header.Set("Content-Type", "application/json")
-	registerRequest.Header.Set("Accept", "application/json")
-
-	response, err := client.Client.Perform(registerRequest)
+	connectorID, responseHeader, err := createConnector(ctx, client, createBody)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error registering model",
-			fmt.Sprintf("Could not register model: %s", err.Error()),
+			"Error creating connector",
+			fmt.Sprintf("Could not create connector: %s", err.Error()),
 		)
 		return
 	}
 
-	// Decode then close explicitly (don’t defer inside loops; this is fine here).
-	body, err := io.ReadAll(response.Body)
+	data.ID = types.StringValue(connectorID)
+	data.ResponseHeaders = types.MapNull(types.StringType)
+
+	resp.Diagnostics.Append(data.setCredentialFingerprint(ctx, resp.Private)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.CaptureResponseHeaders.ValueBool() {
+		responseHeaders, diags := types.MapValueFrom(ctx, types.StringType, allowedResponseHeaders(responseHeader))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.ResponseHeaders = responseHeaders
+	}
+
+	tflog.Trace(ctx, "created Connector resource", map[string]any{
+		"connector_id": connectorID,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// ModifyPlan computes the credential fingerprint from the configured credentials, and expects new response headers
+// when the body of a connector capturing them is updated. The fingerprint is left to apply when the connector
+// doesn't have a salt yet, i.e. when it is being created.
+func (r *ConnectorResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to compute when destroying.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data ConnectorModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create keys the fingerprint with a new salt, so it is only known after apply when the connector is created or
+	// replaced, which Terraform plans with a null prior state but the private state of the connector being replaced.
+	replaced := req.State.Raw.IsNull()
+
+	if !replaced {
+		var prior ConnectorModel
+
+		resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		replaced = !data.Credential.Equal(prior.Credential) || !data.EndpointOverride.Equal(prior.EndpointOverride)
+	}
+
+	salt, diags := credentialFingerprintSalt(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if replaced {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("credential_fingerprint"), types.StringUnknown())...)
+	} else if salt != nil {
+		fingerprint, err := data.credentialFingerprint(ctx, salt)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credential_fingerprint"),
+				"Error computing credential fingerprint",
+				fmt.Sprintf("Could not compute the credential fingerprint: %s", err.Error()),
+			)
+			return
+		}
+
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("credential_fingerprint"), fingerprint)...)
+	}
+
+	if req.State.Raw.IsNull() || !data.CaptureResponseHeaders.ValueBool() {
+		return
+	}
+
+	var body JSONBody
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("body"), &body)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Semantically equal bodies keep the state's value, so any difference is sent to the update API.
+	if !data.Body.Equal(body) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("response_headers"), types.MapUnknown(types.StringType))...)
+	}
+}
+
+// Returns the HMAC-SHA256 of the credential object the connector is created with keyed with the salt, which is unknown
+// until the body and credential are, and null without a credential. Only the hash is returned, errors never include the credential.
+func (m ConnectorModel) credentialFingerprint(ctx context.Context, salt []byte) (types.String, error) {
+	if m.Body.IsUnknown() || m.Credential.IsUnknown() {
+		return types.StringUnknown(), nil
+	}
+
+	for _, value := range m.Credential.Elements() {
+		if value.IsUnknown() {
+			return types.StringUnknown(), nil
+		}
+	}
+
+	createBody, err := m.createBody(ctx)
+	if err != nil {
+		return types.StringNull(), err
+	}
+
+	var body struct {
+		Credential map[string]any `json:"credential"`
+	}
+
+	if err := json.Unmarshal(createBody, &body); err != nil {
+		return types.StringNull(), fmt.Errorf("could not parse body")
+	}
+
+	if len(body.Credential) == 0 {
+		return types.StringNull(), nil
+	}
+
+	// Maps are encoded with sorted keys, so the hash doesn't depend on the order credentials are given in.
+	encoded, err := json.Marshal(body.Credential)
+	if err != nil {
+		return types.StringNull(), fmt.Errorf("could not encode credential")
+	}
+
+	mac := hmac.New(sha256.New, salt)
+	mac.Write(encoded)
+
+	return types.StringValue(hex.EncodeToString(mac.Sum(nil))), nil
+}
+
+// Key of the private state holding the salt of the credential fingerprint.
+const credentialFingerprintSaltKey = "credential_fingerprint_salt"
+
+// privateState is the private state of a resource, as given to its CRUD and plan methods.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// Returns the salt of the credential fingerprint from the private state, or nil when the connector doesn't have one,
+// i.e. before it is created or when it was created by an earlier version of the provider.
+func credentialFingerprintSalt(ctx context.Context, private privateState) ([]byte, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, credentialFingerprintSaltKey)
+	if diags.HasError() || len(value) == 0 {
+		return nil, diags
+	}
+
+	var encoded string
+
+	if err := json.Unmarshal(value, &encoded); err != nil {
+		diags.AddError("Error reading credential fingerprint salt", fmt.Sprintf("Could not parse the salt in private state: %s", err.Error()))
+		return nil, diags
+	}
+
+	salt, err := hex.DecodeString(encoded)
+	if err != nil {
+		diags.AddError("Error reading credential fingerprint salt", fmt.Sprintf("Could not parse the salt in private state: %s", err.Error()))
+		return nil, diags
+	}
+
+	return salt, diags
+}
+
+// Returns a new random salt for the credential fingerprint, after storing it in the private state.
+func newCredentialFingerprintSalt(ctx context.Context, private privateState) ([]byte, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	salt := make([]byte, 32)
+
+	if _, err := rand.Read(salt); err != nil {
+		diags.AddError("Error creating credential fingerprint salt", fmt.Sprintf("Could not generate a salt: %s", err.Error()))
+		return nil, diags
+	}
+
+	// Private state values must be JSON.
+	value, err := json.Marshal(hex.EncodeToString(salt))
+	if err != nil {
+		diags.AddError("Error creating credential fingerprint salt", fmt.Sprintf("Could not encode the salt: %s", err.Error()))
+		return nil, diags
+	}
+
+	diags.Append(private.SetKey(ctx, credentialFingerprintSaltKey, value)...)
+
+	return salt, diags
+}
+
+// Sets the credential fingerprint keyed with a new salt, which is stored in the private state.
+func (m *ConnectorModel) setCredentialFingerprint(ctx context.Context, private privateState) diag.Diagnostics {
+	salt, diags := newCredentialFingerprintSalt(ctx, private)
+	if diags.HasError() {
+		return diags
+	}
+
+	fingerprint, err := m.credentialFingerprint(ctx, salt)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("credential_fingerprint"),
+			"Error computing credential fingerprint",
+			fmt.Sprintf("Could not compute the credential fingerprint: %s", err.Error()),
+		)
+		return diags
+	}
+
+	m.CredentialFingerprint = fingerprint
+
+	return diags
+}
+
+// Creates a connector, returning its ID and the response headers.
+func createConnector(ctx context.Context, client *opensearchapi.Client, body []byte) (string, http.Header, error) {
+	createRequest, err := http.NewRequestWithContext(ctx, "POST", "/_plugins/_ml/connectors/_create", bytes.NewReader(body))
+	if err != nil {
+		return "", nil, err
+	}
+
+	createRequest.Header.Set("Content-Type", "application/json")
+	createRequest.Header.Set("Accept", "application/json")
+
+	response, err := client.Client.Perform(createRequest)
+	if err != nil {
+		return "", nil, err
+	}
+
+	responseBody, err := io.ReadAll(response.Body)
 	_ = response.Body.Close()
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading connector create response", err.Error())
-		return
+		return "", nil, err
 	}
 
 	if response.StatusCode < 200 || response.StatusCode >= 300 {
-		resp.Diagnostics.AddError(
-			"Error registering connector",
-			fmt.Sprintf("OpenSearch returned %d: %s", response.StatusCode, string(body)),
-		)
-		return
+		return "", nil, fmt.Errorf("OpenSearch returned %d: %s", response.StatusCode, string(responseBody))
 	}
 
 	var createResponse skpropensearch.ConnectorCreateResponse
 
-	if err := json.Unmarshal(body, &createResponse); err != nil {
-		resp.Diagnostics.AddError(
-			"Error parsing connector create response",
-			fmt.Sprintf("Could not parse connector create response: %s", err.Error()),
-		)
-		return
+	// Some proxies drop the body of the create response, which is resolved below.
+	if len(bytes.TrimSpace(responseBody)) > 0 {
+		if err := json.Unmarshal(responseBody, &createResponse); err != nil {
+			return "", nil, fmt.Errorf("could not parse connector create response: %w", err)
+		}
 	}
 
-	data.ID = types.StringValue(createResponse.ConnectorID)
+	if createResponse.ConnectorID != "" {
+		return createResponse.ConnectorID, response.Header, nil
+	}
 
-	tflog.Trace(ctx, "created Connector resource", map[string]any{
-		"connector_id": createResponse.ConnectorID,
-	})
+	connectorID, err := resolveCreatedConnectorID(ctx, client, response.Header, body)
+	if err != nil {
+		return "", nil, err
+	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	return connectorID, response.Header, nil
+}
+
+// Returns the ID of a connector whose create response had no body, from the Location header or else by
+// searching for the connector's name. The create body holds credentials so it is never part of the error.
+func resolveCreatedConnectorID(ctx context.Context, client *opensearchapi.Client, header http.Header, body []byte) (string, error) {
+	// e.g. Location: /_plugins/_ml/connectors/<connector_id>
+	location := strings.TrimRight(header.Get("Location"), "/")
+	if connectorID := location[strings.LastIndex(location, "/")+1:]; connectorID != "" {
+		return connectorID, nil
+	}
+
+	var connector struct {
+		Name string `json:"name"`
+	}
+
+	if err := json.Unmarshal(body, &connector); err != nil || connector.Name == "" {
+		return "", fmt.Errorf("OpenSearch did not return the connector ID and the body has no name to search for it by")
+	}
+
+	ids, err := searchMLIDsByName(ctx, client, "/_plugins/_ml/connectors/_search", connector.Name)
+	if err != nil {
+		return "", fmt.Errorf("OpenSearch did not return the connector ID and searching for connector %q failed: %w", connector.Name, err)
+	}
+
+	if len(ids) != 1 {
+		return "", fmt.Errorf("OpenSearch did not return the connector ID and %d connectors are named %q, so it can't be determined", len(ids), connector.Name)
+	}
+
+	return ids[0], nil
+}
+
+// Returns the connector create body with the sensitive credentials merged into its credential object
+// and the endpoint override applied. Errors never include the merged body so secrets can't leak into diagnostics.
+func (m ConnectorModel) createBody(ctx context.Context) ([]byte, error) {
+	if m.Credential.IsNull() && m.EndpointOverride.IsNull() {
+		return []byte(m.Body.ValueString()), nil
+	}
+
+	var body map[string]any
+
+	if err := json.Unmarshal([]byte(m.Body.ValueString()), &body); err != nil {
+		return nil, fmt.Errorf("could not parse body: %w", err)
+	}
+
+	if !m.Credential.IsNull() {
+		var credential map[string]string
+
+		if diags := m.Credential.ElementsAs(ctx, &credential, false); diags.HasError() {
+			return nil, fmt.Errorf("could not read credential")
+		}
+
+		merged, ok := body["credential"].(map[string]any)
+		if !ok {
+			merged = make(map[string]any, len(credential))
+		}
+
+		for key, value := range credential {
+			merged[key] = value
+		}
+
+		body["credential"] = merged
+	}
+
+	if !m.EndpointOverride.IsNull() {
+		overrideActionEndpoints(body, m.EndpointOverride.ValueString())
+	}
+
+	return json.Marshal(body)
+}
+
+// Returns the body the connector is expected to have in OpenSearch, i.e. with the endpoint override applied.
+func (m ConnectorModel) expectedBody() (string, error) {
+	if m.EndpointOverride.IsNull() {
+		return m.Body.ValueString(), nil
+	}
+
+	var body map[string]any
+
+	if err := json.Unmarshal([]byte(m.Body.ValueString()), &body); err != nil {
+		return "", err
+	}
+
+	overrideActionEndpoints(body, m.EndpointOverride.ValueString())
+
+	expected, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	return string(expected), nil
+}
+
+// Replaces the scheme and host of each action URL in a connector body with the override, keeping the path and query.
+// URLs are rewritten as strings since they can contain placeholders, e.g. https://${parameters.host}/v1/chat.
+func overrideActionEndpoints(body map[string]any, override string) {
+	override = strings.TrimSuffix(override, "/")
+
+	actions, _ := body["actions"].([]any)
+
+	for _, action := range actions {
+		action, ok := action.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		actionURL, ok := action["url"].(string)
+		if !ok {
+			continue
+		}
+
+		scheme := strings.Index(actionURL, "://")
+		if scheme < 0 {
+			continue
+		}
+
+		rest := actionURL[scheme+len("://"):]
+
+		if i := strings.IndexAny(rest, "/?#"); i >= 0 {
+			action["url"] = override + rest[i:]
+		} else {
+			action["url"] = override
+		}
+	}
+}
+
+// Returns the ID of the existing connector named the same as the body, or an empty string if there is none.
+func findConnectorByBodyName(ctx context.Context, client *opensearchapi.Client, body string) (string, error) {
+	var connector struct {
+		Name string `json:"name"`
+	}
+
+	if err := json.Unmarshal([]byte(body), &connector); err != nil {
+		return "", fmt.Errorf("could not parse body: %w", err)
+	}
+
+	if connector.Name == "" {
+		return "", fmt.Errorf("the body must include a name to adopt an existing connector")
+	}
+
+	ids, err := searchMLIDsByName(ctx, client, "/_plugins/_ml/connectors/_search", connector.Name)
+	if err != nil {
+		return "", err
+	}
+
+	if len(ids) > 1 {
+		return "", fmt.Errorf("found %d connectors named %q (%s), remove the duplicates or disable adopt_existing", len(ids), connector.Name, strings.Join(ids, ", "))
+	}
+
+	if len(ids) == 0 {
+		return "", nil
+	}
+
+	return ids[0], nil
+}
+
+// Returns the sorted top level fields of the body which the existing connector doesn't have the same values for.
+func (m ConnectorModel) adoptedConnectorDifferences(ctx context.Context, client *opensearchapi.Client, connectorID string) ([]string, error) {
+	connector, exists, err := getConnector(ctx, client, connectorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("connector %s does not exist", connectorID)
+	}
+
+	remote, err := normalizeConnectorBody(connector)
+	if err != nil {
+		return nil, err
+	}
+
+	expected, err := m.expectedBody()
+	if err != nil {
+		return nil, err
+	}
+
+	readBack, err := readBackConnectorBody(expected, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	var expectedBody, readBackBody map[string]any
+
+	if err := json.Unmarshal([]byte(expected), &expectedBody); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(readBack), &readBackBody); err != nil {
+		return nil, err
+	}
+
+	var differences []string
+
+	for key, value := range expectedBody {
+		if readBackValue, ok := readBackBody[key]; !ok || !reflect.DeepEqual(value, readBackValue) {
+			differences = append(differences, key)
+		}
+	}
+
+	sort.Strings(differences)
+
+	return differences, nil
 }
 
 // Read the resource state from OpenSearch for our model.
@@ -190,63 +762,344 @@ func (r *ConnectorResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	getReq, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/_plugins/_ml/connectors/%s", data.ID.ValueString()), nil)
-	if err != nil {
-		resp.Diagnostics.AddError("Error creating connector get request", err.Error())
-		return
-	}
-
-	getReq.Header.Set("Content-Type", "application/json")
-	getReq.Header.Set("Accept", "application/json")
-
-	httpResp, err := client.Client.Perform(getReq)
+	connector, exists, err := getConnector(ctx, client, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading connector", err.Error())
 		return
 	}
 
 	// If it’s gone, tell Terraform to drop it from state.
-	if httpResp.StatusCode == http.StatusNotFound {
+	if !exists {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	body, err := io.ReadAll(httpResp.Body)
-	_ = httpResp.Body.Close()
+	remote, err := normalizeConnectorBody(connector)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing connector",
+			fmt.Sprintf("Could not parse connector %s: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	expected, err := data.expectedBody()
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading model get response", err.Error())
+		resp.Diagnostics.AddError(
+			"Error parsing connector",
+			fmt.Sprintf("Could not apply the endpoint override to the body of connector %s: %s", data.ID.ValueString(), err.Error()),
+		)
 		return
 	}
 
-	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+	body, err := readBackConnectorBody(expected, remote)
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error reading model",
-			fmt.Sprintf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body)),
+			"Error parsing connector",
+			fmt.Sprintf("Could not compare connector %s with state: %s", data.ID.ValueString(), err.Error()),
 		)
 		return
 	}
 
+	// The endpoint override is applied by the provider, so a connector which matches it is unchanged.
+	if body == expected {
+		body = data.Body.ValueString()
+	}
+
+	data.Body = NewJSONBodyValue(body)
+
+	// Connectors created before fingerprints were salted get a salt, and their fingerprint is computed with it.
+	salt, diags := credentialFingerprintSalt(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if salt == nil {
+		resp.Diagnostics.Append(data.setCredentialFingerprint(ctx, resp.Private)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-// Update is not supported; registering a new model is the only way to change anything.
+// Update sends changed parameters to the connector update API, every other change to the body replaces the
+// connector (see connectorBodyReplaceModifier). Changes to the other attributes are only persisted.
 func (r *ConnectorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data ConnectorModel
+	var data, state ConnectorModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// All updatable fields are RequiresReplace, so Update should not be called for changes.
-	// Still, if called (e.g. drift-only), just persist planned state.
-	tflog.Trace(ctx, "updated Connector resource (no-op update)", map[string]any{
+	var ignorePaths []string
+
+	if !data.IgnoreBodyPaths.IsNull() {
+		resp.Diagnostics.Append(data.IgnoreBodyPaths.ElementsAs(ctx, &ignorePaths, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	parameters, inPlace, err := connectorParametersUpdate(state.Body.ValueString(), data.Body.ValueString(), ignorePaths)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating connector",
+			fmt.Sprintf("Could not compare the body of connector %s with state: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if !inPlace {
+		resp.Diagnostics.AddError(
+			"Error updating connector",
+			fmt.Sprintf("Connector %s has changes other than to its parameters, which require replacing it. Please report this issue to the provider developers.", data.ID.ValueString()),
+		)
+		return
+	}
+
+	// Headers are only returned when the update API is called.
+	if data.ResponseHeaders.IsUnknown() {
+		data.ResponseHeaders = state.ResponseHeaders
+	}
+
+	// Unknown when the connector didn't have a salt to plan the fingerprint with.
+	if data.CredentialFingerprint.IsUnknown() {
+		resp.Diagnostics.Append(data.setCredentialFingerprint(ctx, resp.Private)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if parameters != nil {
+		client, err := r.client()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating OpenSearch client",
+				fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+			)
+			return
+		}
+
+		update, err := connectorUpdateRequest(ctx, client, data.ID.ValueString(), parameters)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating connector",
+				fmt.Sprintf("Could not read connector %s to update its parameters: %s", data.ID.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		responseHeader, err := updateConnector(ctx, client, data.ID.ValueString(), update)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating connector",
+				fmt.Sprintf("Could not update the parameters of connector %s: %s", data.ID.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		if data.CaptureResponseHeaders.ValueBool() {
+			responseHeaders, diags := types.MapValueFrom(ctx, types.StringType, allowedResponseHeaders(responseHeader))
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			data.ResponseHeaders = responseHeaders
+		}
+	}
+
+	tflog.Trace(ctx, "updated Connector resource", map[string]any{
 		"connector_id": data.ID.ValueString(),
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// Updates a connector in place, returning the response headers. OpenSearch refuses while a model using the connector is deployed.
+func updateConnector(ctx context.Context, client *opensearchapi.Client, id string, update skpropensearch.ConnectorUpdateRequest) (http.Header, error) {
+	requestBody, err := json.Marshal(update)
+	if err != nil {
+		return nil, err
+	}
+
+	updateRequest, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("/_plugins/_ml/connectors/%s", id), bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, err
+	}
+
+	updateRequest.Header.Set("Content-Type", "application/json")
+	updateRequest.Header.Set("Accept", "application/json")
+
+	response, err := client.Client.Perform(updateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, fmt.Errorf("OpenSearch returned %d: %s", response.StatusCode, string(body))
+	}
+
+	return response.Header, nil
+}
+
+// Returns the update for a connector with the planned parameters merged over the ones OpenSearch has stored, so
+// parameters only the server knows about (ignored in the body, or added by OpenSearch) are sent back unchanged.
+// Only parameters are sent, which leaves the fields OpenSearch manages, e.g. created_time and owner, untouched.
+func connectorUpdateRequest(ctx context.Context, client *opensearchapi.Client, id string, parameters map[string]any) (skpropensearch.ConnectorUpdateRequest, error) {
+	var update skpropensearch.ConnectorUpdateRequest
+
+	connector, found, err := getConnector(ctx, client, id)
+	if err != nil {
+		return update, err
+	}
+
+	if !found {
+		return update, fmt.Errorf("connector %s was not found", id)
+	}
+
+	var current struct {
+		Parameters map[string]any `json:"parameters"`
+	}
+
+	if err := json.Unmarshal(connector, &current); err != nil {
+		return update, err
+	}
+
+	update.Parameters = make(map[string]any, len(current.Parameters)+len(parameters))
+
+	maps.Copy(update.Parameters, current.Parameters)
+	maps.Copy(update.Parameters, parameters)
+
+	return update, nil
+}
+
+// Returns the parameters to update the connector with when the planned body only adds or changes parameters
+// compared with the prior one, ignoring the given JSON pointers, and whether the change can be made in place.
+// The parameters are nil when they didn't change. OpenSearch merges updated parameters into the existing
+// ones, so removing a parameter, like any change outside parameters, can only be done by replacing the connector.
+func connectorParametersUpdate(prior, planned string, ignorePaths []string) (map[string]any, bool, error) {
+	var priorBody, plannedBody map[string]any
+
+	if err := json.Unmarshal([]byte(prior), &priorBody); err != nil {
+		return nil, false, err
+	}
+
+	if err := json.Unmarshal([]byte(planned), &plannedBody); err != nil {
+		return nil, false, err
+	}
+
+	for _, pointer := range ignorePaths {
+		removeJSONPointer(priorBody, pointer)
+		removeJSONPointer(plannedBody, pointer)
+	}
+
+	priorCompared, priorOK := connectorBodyParameters(priorBody)
+	plannedCompared, plannedOK := connectorBodyParameters(plannedBody)
+
+	if !priorOK || !plannedOK {
+		return nil, false, nil
+	}
+
+	delete(priorBody, "parameters")
+	delete(plannedBody, "parameters")
+
+	if !reflect.DeepEqual(priorBody, plannedBody) {
+		return nil, false, nil
+	}
+
+	for name := range priorCompared {
+		if _, ok := plannedCompared[name]; !ok {
+			return nil, false, nil
+		}
+	}
+
+	if reflect.DeepEqual(priorCompared, plannedCompared) {
+		return nil, true, nil
+	}
+
+	// Ignored parameters are left out, OpenSearch keeps the values it has for them.
+	return plannedCompared, true, nil
+}
+
+// Returns the parameters of a connector body, and false when they are set to something other than an object,
+// which only replacing the connector can change.
+func connectorBodyParameters(body map[string]any) (map[string]any, bool) {
+	value, ok := body["parameters"]
+	if !ok || value == nil {
+		return nil, true
+	}
+
+	parameters, ok := value.(map[string]any)
+
+	return parameters, ok
+}
+
+// Requires replacing the connector unless its body only adds or changes parameters, see connectorParametersUpdate.
+type connectorBodyReplaceModifier struct{}
+
+// Description returns a plain text description of the modifier's behavior.
+func (m connectorBodyReplaceModifier) Description(ctx context.Context) string {
+	return "Requires replacing the connector unless only its parameters are added or changed."
+}
+
+// MarkdownDescription returns a markdown formatted description of the modifier's behavior.
+func (m connectorBodyReplaceModifier) MarkdownDescription(ctx context.Context) string {
+	return "Requires replacing the connector unless only its `parameters` are added or changed."
+}
+
+// PlanModifyString requires replacement when the body changes in a way the connector update API can't apply.
+func (m connectorBodyReplaceModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to replace while creating or destroying.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if req.PlanValue.IsUnknown() {
+		resp.RequiresReplace = true
+		return
+	}
+
+	if req.PlanValue.Equal(req.StateValue) {
+		return
+	}
+
+	var ignore types.List
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("ignore_body_paths"), &ignore)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Without knowing what is ignored, the change can't be classified.
+	if ignore.IsUnknown() {
+		resp.RequiresReplace = true
+		return
+	}
+
+	var ignorePaths []string
+
+	resp.Diagnostics.Append(ignore.ElementsAs(ctx, &ignorePaths, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Invalid JSON is reported by the attribute's validation.
+	_, inPlace, err := connectorParametersUpdate(req.StateValue.ValueString(), req.PlanValue.ValueString(), ignorePaths)
+	resp.RequiresReplace = err != nil || !inPlace
+}
+
 // Delete the model from OpenSearch.
 func (r *ConnectorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data ConnectorModel
@@ -270,45 +1123,248 @@ func (r *ConnectorResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	delReq, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("/_plugins/_ml/connectors/%s", data.ID.ValueString()), nil)
-	if err != nil {
-		resp.Diagnostics.AddError("Error creating connector delete request", err.Error())
+	if data.PreventDeleteIfInUse.ValueBool() {
+		modelIDs, err := searchModelIDs(ctx, client, "connector_id", data.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error checking connector references",
+				fmt.Sprintf("Could not search for models using connector %s: %s", data.ID.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		if len(modelIDs) > 0 {
+			resp.Diagnostics.AddError(
+				"Connector is still in use",
+				fmt.Sprintf("Connector %s is still used by models: %s. Delete these models first or set prevent_delete_if_in_use to false.",
+					data.ID.ValueString(), strings.Join(modelIDs, ", ")),
+			)
+			return
+		}
+	}
+
+	if err := deleteConnector(ctx, client, data.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting connector",
+			fmt.Sprintf("Could not delete connector %s: %s", data.ID.ValueString(), err.Error()),
+		)
 		return
 	}
 
+	tflog.Trace(ctx, "deleted Connector resource", map[string]any{
+		"connector_id": data.ID.ValueString(),
+	})
+}
+
+// Deletes a connector. A connector which is already gone counts as deleted.
+func deleteConnector(ctx context.Context, client *opensearchapi.Client, id string) error {
+	delReq, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("/_plugins/_ml/connectors/%s", id), nil)
+	if err != nil {
+		return err
+	}
+
 	delReq.Header.Set("Content-Type", "application/json")
 	delReq.Header.Set("Accept", "application/json")
 
 	httpResp, err := client.Client.Perform(delReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Error deleting connector", err.Error())
-		return
+		return err
 	}
 
-	body, readErr := io.ReadAll(httpResp.Body)
+	body, err := io.ReadAll(httpResp.Body)
 	_ = httpResp.Body.Close()
-	if readErr != nil {
-		resp.Diagnostics.AddError("Error reading connector delete response", readErr.Error())
-		return
+	if err != nil {
+		return err
 	}
 
 	// Treat 404 as already deleted.
 	if httpResp.StatusCode == http.StatusNotFound {
 		tflog.Trace(ctx, "connector already deleted", map[string]any{
-			"connector_id": data.ID.ValueString(),
+			"connector_id": id,
 		})
-		return
+		return nil
+	}
+
+	// OpenSearch refuses to delete a connector while models still use it, name them so the user can act on it.
+	if httpResp.StatusCode == http.StatusBadRequest || httpResp.StatusCode == http.StatusConflict {
+		modelIDs, err := searchModelIDs(ctx, client, "connector_id", id)
+		if err == nil && len(modelIDs) > 0 {
+			return fmt.Errorf("the connector is still used by models: %s. Delete these models (or remove their dependency on this connector) before deleting the connector",
+				strings.Join(modelIDs, ", "))
+		}
 	}
 
 	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// Returns the connector body to store in state, so out of band changes surface in plan without
+// flapping: only the top level fields the state already has are compared, as OpenSearch fills in
+// defaults the configuration may leave out, and the credential is kept as OpenSearch never returns it.
+func readBackConnectorBody(state, remote string) (string, error) {
+	if state == "" {
+		return remote, nil
+	}
+
+	var stateBody, remoteBody map[string]any
+
+	if err := json.Unmarshal([]byte(state), &stateBody); err != nil {
+		return "", err
+	}
+
+	if err := json.Unmarshal([]byte(remote), &remoteBody); err != nil {
+		return "", err
+	}
+
+	body := make(map[string]any, len(stateBody))
+
+	for key, value := range stateBody {
+		if key == "credential" {
+			body[key] = value
+			continue
+		}
+
+		if remoteValue, ok := remoteBody[key]; ok {
+			body[key] = remoteValue
+		}
+	}
+
+	if reflect.DeepEqual(body, stateBody) {
+		return state, nil
+	}
+
+	readBack, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	return string(readBack), nil
+}
+
+// ImportState imports the connector by ID, or by name using a `name:<value>` import ID.
+func (r *ConnectorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	client, err := r.client()
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error deleting connector",
-			fmt.Sprintf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body)),
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
 		)
 		return
 	}
 
-	tflog.Trace(ctx, "deleted Connector resource", map[string]any{
-		"connector_id": data.ID.ValueString(),
-	})
+	id, err := resolveImportID(ctx, client, "/_plugins/_ml/connectors/_search", req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing connector",
+			fmt.Sprintf("Could not resolve connector import ID: %s", err.Error()),
+		)
+		return
+	}
+
+	connector, exists, err := getConnector(ctx, client, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing connector",
+			fmt.Sprintf("Could not read connector %s: %s", id, err.Error()),
+		)
+		return
+	}
+
+	if !exists {
+		resp.Diagnostics.AddError(
+			"Error importing connector",
+			fmt.Sprintf("Connector %s does not exist.", id),
+		)
+		return
+	}
+
+	body, err := normalizeConnectorBody(connector)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing connector",
+			fmt.Sprintf("Could not parse connector %s: %s", id, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("body"), NewJSONBodyValue(body))...)
+}
+
+// Returns the raw connector and whether it exists.
+func getConnector(ctx context.Context, client *opensearchapi.Client, id string) ([]byte, bool, error) {
+	getReq, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/_plugins/_ml/connectors/%s", id), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	getReq.Header.Set("Content-Type", "application/json")
+	getReq.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(getReq)
+	if err != nil {
+		return nil, false, err
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return nil, false, fmt.Errorf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	return body, true, nil
+}
+
+// Returns the connector as a create body, without the fields OpenSearch populates itself.
+// OpenSearch never returns the credential, so it is missing from the result.
+func normalizeConnectorBody(connector []byte) (string, error) {
+	var body map[string]any
+
+	if err := json.Unmarshal(connector, &body); err != nil {
+		return "", err
+	}
+
+	for _, field := range skpropensearch.ConnectorServerManagedFields {
+		delete(body, field)
+	}
+
+	normalized, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	return string(normalized), nil
+}
+
+// UpgradeState carries connector state written by earlier versions of the provider forward.
+func (r *ConnectorResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 only had the ID and body. Attributes added since are left null, for their defaults
+		// or the next read to fill in.
+		0: {
+			PriorSchema: &connectorSchemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior connectorModelV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), prior.ID)...)
+				resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("body"), JSONBody{StringValue: prior.Body})...)
+			},
+		},
+	}
 }
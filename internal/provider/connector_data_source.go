@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ConnectorDataSource{}
+
+// NewConnectorDataSource is a helper function to simplify the provider implementation.
+func NewConnectorDataSource() datasource.DataSource {
+	return &ConnectorDataSource{}
+}
+
+// ConnectorDataSource is the data source implementation.
+type ConnectorDataSource struct {
+	providerData *ProviderData
+}
+
+// ConnectorDataSourceModel describes the Connector data source data model.
+type ConnectorDataSourceModel struct {
+	Name types.String `tfsdk:"name"`
+	ID   types.String `tfsdk:"id"`
+	Body types.String `tfsdk:"body"`
+}
+
+// Metadata returns the data source type name.
+func (d *ConnectorDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_connector", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Connector data source.
+func (d *ConnectorDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up an existing connector by name, e.g. to reference a connector managed elsewhere without hardcoding its ID.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Exact name of the connector. OpenSearch does not enforce unique connector names, so the lookup fails if several connectors have it.",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the connector.",
+				Computed:            true,
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "The connector configuration as JSON, without the fields OpenSearch populates. OpenSearch never returns the `credential`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (d *ConnectorDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (d *ConnectorDataSource) client() (*opensearchapi.Client, error) {
+	return d.providerData.client()
+}
+
+// Read searches for the connector by name.
+func (d *ConnectorDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ConnectorDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	hits, err := searchMLByName(ctx, client, "/_plugins/_ml/connectors/_search", data.Name.ValueString(), nil, false)
+	if err != nil {
+		addSearchError(&resp.Diagnostics, "connector", data.Name.ValueString(), err)
+		return
+	}
+
+	hit, err := singleSearchHit(hits, false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error looking up connector",
+			fmt.Sprintf("Could not look up connector %q: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	// Read through the connector API, which leaves out the credential.
+	connector, exists, err := getConnector(ctx, client, hit.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading connector", err.Error())
+		return
+	}
+
+	if !exists {
+		resp.Diagnostics.AddError(
+			"Error looking up connector",
+			fmt.Sprintf("Connector %s named %q was deleted while it was being read.", hit.ID, data.Name.ValueString()),
+		)
+		return
+	}
+
+	body, err := normalizeConnectorBody(connector)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing connector",
+			fmt.Sprintf("Could not parse connector %s: %s", hit.ID, err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(hit.ID)
+	data.Body = types.StringValue(body)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,568 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+func TestAdoptedConnectorDifferences(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_plugins/_ml/connectors/connector-1" {
+			writeJSON(w, http.StatusNotFound, `{}`)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, `{"name":"embeddings","description":"Another team's connector","version":"1","protocol":"http","created_time":1700000000000}`)
+	})
+
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "same connector",
+			body: `{"name":"embeddings","description":"Another team's connector","version":"1","protocol":"http","credential":{"key":"secret"}}`,
+		},
+		{
+			name: "different connector",
+			body: `{"name":"embeddings","description":"Embeddings","version":"1","protocol":"aws_sigv4","parameters":{"region":"us-east-1"}}`,
+			want: []string{"description", "parameters", "protocol"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := ConnectorModel{Body: NewJSONBodyValue(tt.body)}
+
+			got, err := data.adoptedConnectorDifferences(context.Background(), client, "connector-1")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected differences %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestUpdateConnectorReturnsResponseHeaders(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/_plugins/_ml/connectors/connector-1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+
+		w.Header().Set("X-RateLimit-Remaining", "41")
+		w.Header().Set("Authorization", "Bearer secret")
+		writeJSON(w, http.StatusOK, `{"result":"updated"}`)
+	})
+
+	header, err := updateConnector(context.Background(), client, "connector-1", skpropensearch.ConnectorUpdateRequest{Parameters: map[string]any{"model": "gpt-4o"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := map[string]string{"x-ratelimit-remaining": "41"}
+	if got := allowedResponseHeaders(header); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected response headers %v, got %v", want, got)
+	}
+}
+
+func TestCreateConnectorWithEmptyResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		location string
+		hits     string
+		want     string
+		wantErr  string
+	}{
+		{
+			name: "connector ID in the body",
+			body: `{"connector_id":"connector-1"}`,
+			want: "connector-1",
+		},
+		{
+			name:     "location header",
+			location: "/_plugins/_ml/connectors/connector-2",
+			want:     "connector-2",
+		},
+		{
+			name: "search by name",
+			hits: `[{"_id":"connector-3","_source":{"name":"embeddings"}}]`,
+			want: "connector-3",
+		},
+		{
+			name:    "no connector found",
+			hits:    `[]`,
+			wantErr: `0 connectors are named "embeddings"`,
+		},
+		{
+			name:    "several connectors found",
+			hits:    `[{"_id":"connector-3","_source":{"name":"embeddings"}},{"_id":"connector-4","_source":{"name":"embeddings"}}]`,
+			wantErr: `2 connectors are named "embeddings"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/_plugins/_ml/connectors/_create":
+					if tt.body != "" {
+						writeJSON(w, http.StatusCreated, tt.body)
+						return
+					}
+
+					if tt.location != "" {
+						w.Header().Set("Location", tt.location)
+					}
+
+					w.WriteHeader(http.StatusCreated)
+				case "/_plugins/_ml/connectors/_search":
+					if tt.hits == "" {
+						t.Errorf("unexpected search")
+					}
+
+					writeJSON(w, http.StatusOK, `{"hits":{"hits":`+tt.hits+`}}`)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+			})
+
+			got, _, err := createConnector(context.Background(), client, []byte(`{"name":"embeddings","credential":{"key":"secret"}}`))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+
+				if strings.Contains(err.Error(), "secret") {
+					t.Errorf("expected the error not to include the credentials, got %s", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected connector ID %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConnectorUpdateKeepsServerFields(t *testing.T) {
+	var stored map[string]any
+
+	if err := json.Unmarshal([]byte(`{"name":"embeddings","protocol":"http","parameters":{"region":"us-east-1","model":"gpt-4","endpoint":"api.openai.com"},"created_time":1700000000000,"owner":{"name":"admin"}}`), &stored); err != nil {
+		t.Fatalf("could not parse connector: %s", err)
+	}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_plugins/_ml/connectors/connector-1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			return
+		}
+
+		switch r.Method {
+		case "GET":
+			body, _ := json.Marshal(stored)
+			writeJSON(w, http.StatusOK, string(body))
+		case "PUT":
+			var update map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+				t.Errorf("could not parse update: %s", err)
+			}
+
+			// OpenSearch only changes the fields given in the update.
+			maps.Copy(stored, update)
+			writeJSON(w, http.StatusOK, `{"result":"updated"}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+
+	update, err := connectorUpdateRequest(context.Background(), client, "connector-1", map[string]any{"model": "gpt-4o"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, err := updateConnector(context.Background(), client, "connector-1", update); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := map[string]any{"region": "us-east-1", "model": "gpt-4o", "endpoint": "api.openai.com"}
+	if !reflect.DeepEqual(stored["parameters"], want) {
+		t.Errorf("expected parameters %v, got %v", want, stored["parameters"])
+	}
+
+	if stored["created_time"] != float64(1700000000000) || !reflect.DeepEqual(stored["owner"], map[string]any{"name": "admin"}) {
+		t.Errorf("expected created_time and owner to survive the update, got %v", stored)
+	}
+}
+
+// testPrivateState is an in memory private state.
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p testPrivateState) SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func TestCredentialFingerprint(t *testing.T) {
+	ctx := context.Background()
+
+	data := ConnectorModel{
+		Body:       NewJSONBodyValue(`{"name":"embeddings","credential":{"key":"secret"}}`),
+		Credential: types.MapNull(types.StringType),
+	}
+
+	first, second := testPrivateState{}, testPrivateState{}
+
+	if diags := data.setCredentialFingerprint(ctx, first); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	fingerprint := data.CredentialFingerprint
+
+	salt, diags := credentialFingerprintSalt(ctx, first)
+	if diags.HasError() || len(salt) != 32 {
+		t.Fatalf("expected a 32 byte salt in private state, got %x: %v", salt, diags)
+	}
+
+	// The plan computes the same fingerprint from the stored salt.
+	planned, err := data.credentialFingerprint(ctx, salt)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !planned.Equal(fingerprint) {
+		t.Errorf("expected the planned fingerprint %s to match %s", planned, fingerprint)
+	}
+
+	// Without the salt, a fingerprint can't be matched to a guessed credential.
+	unsalted := sha256.Sum256([]byte(`{"key":"secret"}`))
+	if fingerprint.ValueString() == hex.EncodeToString(unsalted[:]) {
+		t.Errorf("expected the fingerprint not to be the plain SHA-256 of the credential")
+	}
+
+	if diags := data.setCredentialFingerprint(ctx, second); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if data.CredentialFingerprint.Equal(fingerprint) {
+		t.Errorf("expected connectors with the same credential to have different fingerprints")
+	}
+
+	// Rotating the credential changes the fingerprint.
+	data.Credential = types.MapValueMust(types.StringType, map[string]attr.Value{"key": types.StringValue("rotated")})
+
+	rotated, err := data.credentialFingerprint(ctx, salt)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if rotated.Equal(fingerprint) {
+		t.Errorf("expected a rotated credential to change the fingerprint")
+	}
+
+	empty := ConnectorModel{Body: NewJSONBodyValue(`{"name":"embeddings"}`), Credential: types.MapNull(types.StringType)}
+	if got, err := empty.credentialFingerprint(ctx, salt); err != nil || !got.IsNull() {
+		t.Errorf("expected a null fingerprint without a credential, got %s: %v", got, err)
+	}
+}
+
+// Returns a protocol value of the object type, with the given attributes set and the others null, or a null object
+// without any attributes.
+func testDynamicValue(t *testing.T, objectType tftypes.Object, values map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	object := tftypes.NewValue(objectType, nil)
+
+	if values != nil {
+		attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for name, attributeType := range objectType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+
+		maps.Copy(attributes, values)
+
+		object = tftypes.NewValue(objectType, attributes)
+	}
+
+	value, err := tfprotov6.NewDynamicValue(objectType, object)
+	if err != nil {
+		t.Fatalf("could not encode value: %s", err)
+	}
+
+	return &value
+}
+
+// Returns the attributes of a protocol value of the object type.
+func testDynamicValueAttributes(t *testing.T, objectType tftypes.Object, value *tfprotov6.DynamicValue) map[string]tftypes.Value {
+	t.Helper()
+
+	decoded, err := value.Unmarshal(objectType)
+	if err != nil {
+		t.Fatalf("could not decode value: %s", err)
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := decoded.As(&attributes); err != nil {
+		t.Fatalf("could not decode value: %s", err)
+	}
+
+	return attributes
+}
+
+// Fails the test on any error diagnostic returned over the protocol.
+func testProtocolDiagnostics(t *testing.T, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+}
+
+// Rotating the credential replaces the connector, which Terraform plans with a null prior state but the private state
+// of the connector being replaced, then creates with no private state. The planned fingerprint must match the applied one.
+func TestConnectorCredentialRotation(t *testing.T) {
+	ctx := context.Background()
+
+	var created atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_plugins/_ml/connectors/_create" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			writeJSON(w, http.StatusNotFound, `{}`)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, fmt.Sprintf(`{"connector_id":"connector-%d"}`, created.Add(1)))
+	}))
+	t.Cleanup(server.Close)
+
+	p := NewOpenSearchProvider("test")()
+
+	var providerSchema provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &providerSchema)
+
+	var connectorSchema resource.SchemaResponse
+	(&ConnectorResource{}).Schema(ctx, resource.SchemaRequest{}, &connectorSchema)
+
+	providerType := providerSchema.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	connectorType := connectorSchema.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	credentialType := connectorType.AttributeTypes["credential"]
+
+	protocolServer, err := providerserver.NewProtocol6WithError(p)()
+	if err != nil {
+		t.Fatalf("could not create provider server: %s", err)
+	}
+
+	configureResp, err := protocolServer.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: testDynamicValue(t, providerType, map[string]tftypes.Value{
+			"address": tftypes.NewValue(tftypes.String, server.URL),
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	testProtocolDiagnostics(t, configureResp.Diagnostics)
+
+	config := func(key string) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"body": tftypes.NewValue(tftypes.String, `{"name":"embeddings","protocol":"http","actions":[{"action_type":"predict","method":"POST","url":"https://example.com"}]}`),
+			"credential": tftypes.NewValue(credentialType, map[string]tftypes.Value{
+				"key": tftypes.NewValue(tftypes.String, key),
+			}),
+		}
+	}
+
+	// Plans and creates the connector, with the private state of the connector it replaces, if any.
+	create := func(config map[string]tftypes.Value, priorPrivate []byte) (map[string]tftypes.Value, []byte) {
+		t.Helper()
+
+		planResp, err := protocolServer.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+			TypeName:         "opensearch_connector",
+			PriorState:       testDynamicValue(t, connectorType, nil),
+			ProposedNewState: testDynamicValue(t, connectorType, config),
+			Config:           testDynamicValue(t, connectorType, config),
+			PriorPrivate:     priorPrivate,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		testProtocolDiagnostics(t, planResp.Diagnostics)
+
+		applyResp, err := protocolServer.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+			TypeName:       "opensearch_connector",
+			PriorState:     testDynamicValue(t, connectorType, nil),
+			PlannedState:   planResp.PlannedState,
+			Config:         testDynamicValue(t, connectorType, config),
+			PlannedPrivate: planResp.PlannedPrivate,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		testProtocolDiagnostics(t, applyResp.Diagnostics)
+
+		planned := testDynamicValueAttributes(t, connectorType, planResp.PlannedState)["credential_fingerprint"]
+		applied := testDynamicValueAttributes(t, connectorType, applyResp.NewState)
+
+		if !applied["credential_fingerprint"].IsKnown() || applied["credential_fingerprint"].IsNull() {
+			t.Fatalf("expected a fingerprint after apply, got %s", applied["credential_fingerprint"])
+		}
+
+		if planned.IsKnown() && !planned.Equal(applied["credential_fingerprint"]) {
+			t.Fatalf("planned fingerprint %s differs from the applied %s, which Terraform reports as an inconsistent result", planned, applied["credential_fingerprint"])
+		}
+
+		return applied, applyResp.Private
+	}
+
+	original, private := create(config("one"), nil)
+
+	// Terraform proposes the configuration with the computed attributes of the prior state.
+	proposed := maps.Clone(original)
+	maps.Copy(proposed, config("two"))
+
+	planResp, err := protocolServer.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "opensearch_connector",
+		PriorState:       testDynamicValue(t, connectorType, original),
+		ProposedNewState: testDynamicValue(t, connectorType, proposed),
+		Config:           testDynamicValue(t, connectorType, config("two")),
+		PriorPrivate:     private,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	testProtocolDiagnostics(t, planResp.Diagnostics)
+
+	if len(planResp.RequiresReplace) != 1 || !planResp.RequiresReplace[0].Equal(tftypes.NewAttributePath().WithAttributeName("credential")) {
+		t.Fatalf("expected rotating the credential to replace the connector, got %v", planResp.RequiresReplace)
+	}
+
+	replacement, _ := create(config("two"), planResp.PlannedPrivate)
+
+	if replacement["credential_fingerprint"].Equal(original["credential_fingerprint"]) {
+		t.Errorf("expected the rotated credential to change the fingerprint")
+	}
+
+	if got := created.Load(); got != 2 {
+		t.Errorf("expected 2 connectors to be created, got %d", got)
+	}
+}
+
+func TestConnectorParametersUpdate(t *testing.T) {
+	const actions = `"actions":[{"action_type":"predict","method":"POST","url":"https://api.openai.com/v1/embeddings"}]`
+
+	tests := []struct {
+		name        string
+		prior       string
+		planned     string
+		ignorePaths []string
+		want        map[string]any
+		wantInPlace bool
+	}{
+		{
+			name:        "unchanged",
+			prior:       `{"name":"embeddings","protocol":"http","parameters":{"model":"gpt-4"},` + actions + `}`,
+			planned:     `{"name":"embeddings","protocol":"http","parameters":{"model":"gpt-4"},` + actions + `}`,
+			wantInPlace: true,
+		},
+		{
+			name:        "parameter changed",
+			prior:       `{"name":"embeddings","protocol":"http","parameters":{"model":"gpt-4","region":"us-east-1"},` + actions + `}`,
+			planned:     `{"name":"embeddings","protocol":"http","parameters":{"model":"gpt-4o","region":"us-east-1"},` + actions + `}`,
+			want:        map[string]any{"model": "gpt-4o", "region": "us-east-1"},
+			wantInPlace: true,
+		},
+		{
+			name:        "parameter added",
+			prior:       `{"name":"embeddings","protocol":"http",` + actions + `}`,
+			planned:     `{"name":"embeddings","protocol":"http","parameters":{"model":"gpt-4o"},` + actions + `}`,
+			want:        map[string]any{"model": "gpt-4o"},
+			wantInPlace: true,
+		},
+		{
+			name:        "ignored parameter left out",
+			prior:       `{"name":"embeddings","protocol":"http","parameters":{"model":"gpt-4","endpoint":"a"},` + actions + `}`,
+			planned:     `{"name":"embeddings","protocol":"http","parameters":{"model":"gpt-4o","endpoint":"b"},` + actions + `}`,
+			ignorePaths: []string{"/parameters/endpoint"},
+			want:        map[string]any{"model": "gpt-4o"},
+			wantInPlace: true,
+		},
+		{
+			name:    "parameter removed",
+			prior:   `{"name":"embeddings","protocol":"http","parameters":{"model":"gpt-4","region":"us-east-1"},` + actions + `}`,
+			planned: `{"name":"embeddings","protocol":"http","parameters":{"model":"gpt-4"},` + actions + `}`,
+		},
+		{
+			name:    "protocol changed",
+			prior:   `{"name":"embeddings","protocol":"http","parameters":{"model":"gpt-4"},` + actions + `}`,
+			planned: `{"name":"embeddings","protocol":"aws_sigv4","parameters":{"model":"gpt-4"},` + actions + `}`,
+		},
+		{
+			name:    "actions changed",
+			prior:   `{"name":"embeddings","protocol":"http","parameters":{"model":"gpt-4"},` + actions + `}`,
+			planned: `{"name":"embeddings","protocol":"http","parameters":{"model":"gpt-4"},"actions":[{"action_type":"predict","method":"POST","url":"https://api.openai.com/v1/chat/completions"}]}`,
+		},
+		{
+			name:    "parameters and protocol changed",
+			prior:   `{"name":"embeddings","protocol":"http","parameters":{"model":"gpt-4"},` + actions + `}`,
+			planned: `{"name":"embeddings","protocol":"aws_sigv4","parameters":{"model":"gpt-4o"},` + actions + `}`,
+		},
+		{
+			name:    "parameters not an object",
+			prior:   `{"name":"embeddings","protocol":"http","parameters":{"model":"gpt-4"},` + actions + `}`,
+			planned: `{"name":"embeddings","protocol":"http","parameters":"gpt-4o",` + actions + `}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, inPlace, err := connectorParametersUpdate(tt.prior, tt.planned, tt.ignorePaths)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if inPlace != tt.wantInPlace {
+				t.Errorf("expected in place %t, got %t", tt.wantInPlace, inPlace)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected parameters %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &DataStreamResource{}
+	_ resource.ResourceWithImportState = &DataStreamResource{}
+)
+
+// NewDataStreamResource is a helper function to simplify the provider implementation.
+func NewDataStreamResource() resource.Resource {
+	return &DataStreamResource{}
+}
+
+// DataStreamResource is the resource implementation.
+type DataStreamResource struct {
+	providerData *ProviderData
+}
+
+// DataStreamModel describes the Data Stream resource data model.
+type DataStreamModel struct {
+	ID             types.String   `tfsdk:"id"`
+	Name           types.String   `tfsdk:"name"`
+	Template       types.String   `tfsdk:"template"`
+	Generation     types.Int64    `tfsdk:"generation"`
+	BackingIndices []types.String `tfsdk:"backing_indices"`
+}
+
+// Metadata returns the resource type name.
+func (r *DataStreamResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_data_stream", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Data Stream resource.
+func (r *DataStreamResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a data stream. An index template whose `index_patterns` match the name and which has `data_stream` enabled must exist first, " +
+			"e.g. an `opensearch_index_template` referenced with `depends_on`. Deleting the data stream **deletes its backing indices and their documents**.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the data stream.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the data stream.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(indexNamePattern, "must be a lower case index name which doesn't start with _, - or +, "+
+						"and doesn't contain spaces or any of \\ / * ? \" < > | , # :"),
+				},
+			},
+			"template": schema.StringAttribute{
+				MarkdownDescription: "Name of the index template the data stream was created from.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"generation": schema.Int64Attribute{
+				MarkdownDescription: "Generation of the data stream, which each rollover increments.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"backing_indices": schema.ListAttribute{
+				MarkdownDescription: "Names of the backing indices of the data stream, oldest first. The last one is the write index.",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *DataStreamResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *DataStreamResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// Create creates the data stream and reads back its first backing index.
+func (r *DataStreamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DataStreamModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	status, body, err := performJSONRequest(ctx, client, "PUT", fmt.Sprintf("/_data_stream/%s", name), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating data stream", err.Error())
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		detail := fmt.Sprintf("OpenSearch returned %d: %s", status, string(body))
+
+		// OpenSearch only says "no matching index template found for data stream [<name>]".
+		if strings.Contains(string(body), "no matching index template found") {
+			detail = fmt.Sprintf("No index template matches data stream %s. Create an index template with \"data_stream\": {} "+
+				"and index_patterns matching the name first, and add it to the depends_on of this resource. %s", name, detail)
+		}
+
+		resp.Diagnostics.AddError("Error creating data stream", detail)
+		return
+	}
+
+	dataStream, found, err := getDataStream(ctx, client, name)
+	if err != nil || !found {
+		if err == nil {
+			err = fmt.Errorf("data stream not found")
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading data stream",
+			fmt.Sprintf("Could not read data stream %s after creating it: %s", name, err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+	data.readBack(dataStream)
+
+	tflog.Trace(ctx, "created Data Stream resource", map[string]any{
+		"name": name,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read refreshes the generation and backing indices from OpenSearch.
+func (r *DataStreamResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DataStreamModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	dataStream, found, err := getDataStream(ctx, client, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading data stream", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = data.Name
+	data.readBack(dataStream)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Sets the computed attributes from the data stream in OpenSearch.
+func (m *DataStreamModel) readBack(dataStream skpropensearch.DataStream) {
+	m.Template = types.StringValue(dataStream.Template)
+	m.Generation = types.Int64Value(dataStream.Generation)
+
+	m.BackingIndices = make([]types.String, 0, len(dataStream.Indices))
+	for _, index := range dataStream.Indices {
+		m.BackingIndices = append(m.BackingIndices, types.StringValue(index.IndexName))
+	}
+}
+
+// Update is not supported; the name is the only argument and changing it replaces the data stream.
+func (r *DataStreamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DataStreamModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated Data Stream resource (no-op update)", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the data stream along with its backing indices.
+func (r *DataStreamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DataStreamModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "DELETE", fmt.Sprintf("/_data_stream/%s", data.Name.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting data stream", err.Error())
+		return
+	}
+
+	// Treat 404 as already deleted.
+	if status == http.StatusNotFound {
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error deleting data stream",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Data Stream resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+}
+
+// ImportState imports a data stream by its name.
+func (r *DataStreamResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
+
+// Returns the data stream and whether it exists.
+func getDataStream(ctx context.Context, client *opensearchapi.Client, name string) (skpropensearch.DataStream, bool, error) {
+	var dataStream skpropensearch.DataStream
+
+	status, body, err := performJSONRequest(ctx, client, "GET", fmt.Sprintf("/_data_stream/%s", name), nil)
+	if err != nil {
+		return dataStream, false, err
+	}
+
+	if status == http.StatusNotFound {
+		return dataStream, false, nil
+	}
+
+	if status < 200 || status >= 300 {
+		return dataStream, false, fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	var getResponse skpropensearch.DataStreamGetResponse
+
+	if err := json.Unmarshal(body, &getResponse); err != nil {
+		return dataStream, false, fmt.Errorf("could not parse data stream response: %w", err)
+	}
+
+	for _, item := range getResponse.DataStreams {
+		if item.Name == name {
+			return item, true, nil
+		}
+	}
+
+	return dataStream, false, nil
+}
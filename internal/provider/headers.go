@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Response headers which are safe to surface in state. These describe rate limits and quotas,
+// authentication related headers (e.g. Set-Cookie, WWW-Authenticate) are never captured.
+var responseHeaderAllowlist = []string{
+	"Retry-After",
+	"RateLimit-Limit",
+	"RateLimit-Remaining",
+	"RateLimit-Reset",
+	"X-RateLimit-Limit",
+	"X-RateLimit-Remaining",
+	"X-RateLimit-Reset",
+}
+
+// Request headers set by the provider itself or by authentication, which custom headers can't override.
+var reservedRequestHeaders = []string{
+	"Authorization",
+	"Host",
+	"Content-Length",
+	"Content-Type",
+	"Accept",
+	"User-Agent",
+}
+
+// Reports whether a custom request header would clash with the provider's own headers or SigV4 signing.
+func isReservedRequestHeader(name string) bool {
+	name = http.CanonicalHeaderKey(name)
+
+	for _, reserved := range reservedRequestHeaders {
+		if name == reserved {
+			return true
+		}
+	}
+
+	return strings.HasPrefix(name, "X-Amz-")
+}
+
+// Returns the allowlisted response headers, keyed by their lower cased name.
+func allowedResponseHeaders(header http.Header) map[string]string {
+	allowed := make(map[string]string)
+
+	for _, name := range responseHeaderAllowlist {
+		if values := header.Values(name); len(values) > 0 {
+			allowed[strings.ToLower(name)] = strings.Join(values, ", ")
+		}
+	}
+
+	return allowed
+}
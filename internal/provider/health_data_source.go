@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &HealthDataSource{}
+
+// NewHealthDataSource is a helper function to simplify the provider implementation.
+func NewHealthDataSource() datasource.DataSource {
+	return &HealthDataSource{}
+}
+
+// HealthDataSource is the data source implementation.
+type HealthDataSource struct {
+	providerData *ProviderData
+}
+
+// HealthModel describes the Health data source data model.
+type HealthModel struct {
+	ClusterName             types.String  `tfsdk:"cluster_name"`
+	Status                  types.String  `tfsdk:"status"`
+	TimedOut                types.Bool    `tfsdk:"timed_out"`
+	NumberOfNodes           types.Int64   `tfsdk:"number_of_nodes"`
+	NumberOfDataNodes       types.Int64   `tfsdk:"number_of_data_nodes"`
+	ActivePrimaryShards     types.Int64   `tfsdk:"active_primary_shards"`
+	ActiveShards            types.Int64   `tfsdk:"active_shards"`
+	RelocatingShards        types.Int64   `tfsdk:"relocating_shards"`
+	InitializingShards      types.Int64   `tfsdk:"initializing_shards"`
+	UnassignedShards        types.Int64   `tfsdk:"unassigned_shards"`
+	DelayedUnassignedShards types.Int64   `tfsdk:"delayed_unassigned_shards"`
+	NumberOfPendingTasks    types.Int64   `tfsdk:"number_of_pending_tasks"`
+	ActiveShardsPercent     types.Float64 `tfsdk:"active_shards_percent"`
+}
+
+// Metadata returns the data source type name.
+func (d *HealthDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_health", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Health data source.
+func (d *HealthDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Cluster health data source. Not available on OpenSearch Serverless.",
+
+		Attributes: map[string]schema.Attribute{
+			"cluster_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the cluster.",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Health status of the cluster (`green`, `yellow` or `red`).",
+				Computed:            true,
+			},
+			"timed_out": schema.BoolAttribute{
+				MarkdownDescription: "Whether the health request timed out.",
+				Computed:            true,
+			},
+			"number_of_nodes": schema.Int64Attribute{
+				MarkdownDescription: "Number of nodes in the cluster.",
+				Computed:            true,
+			},
+			"number_of_data_nodes": schema.Int64Attribute{
+				MarkdownDescription: "Number of data nodes in the cluster.",
+				Computed:            true,
+			},
+			"active_primary_shards": schema.Int64Attribute{
+				MarkdownDescription: "Number of active primary shards.",
+				Computed:            true,
+			},
+			"active_shards": schema.Int64Attribute{
+				MarkdownDescription: "Number of active primary and replica shards.",
+				Computed:            true,
+			},
+			"relocating_shards": schema.Int64Attribute{
+				MarkdownDescription: "Number of shards being relocated.",
+				Computed:            true,
+			},
+			"initializing_shards": schema.Int64Attribute{
+				MarkdownDescription: "Number of shards being initialized.",
+				Computed:            true,
+			},
+			"unassigned_shards": schema.Int64Attribute{
+				MarkdownDescription: "Number of unassigned shards.",
+				Computed:            true,
+			},
+			"delayed_unassigned_shards": schema.Int64Attribute{
+				MarkdownDescription: "Number of unassigned shards whose allocation has been delayed.",
+				Computed:            true,
+			},
+			"number_of_pending_tasks": schema.Int64Attribute{
+				MarkdownDescription: "Number of cluster-level changes which have not yet been executed.",
+				Computed:            true,
+			},
+			"active_shards_percent": schema.Float64Attribute{
+				MarkdownDescription: "Percentage of shards which are active.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (d *HealthDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (d *HealthDataSource) client() (*opensearchapi.Client, error) {
+	return d.providerData.client()
+}
+
+// Read the cluster health from OpenSearch.
+func (d *HealthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data HealthModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Serverless collections do not expose any of the _cluster APIs.
+	resp.Diagnostics.Append(d.providerData.serverlessDiagnostics("opensearch_health")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	getReq, err := http.NewRequestWithContext(ctx, "GET", "/_cluster/health", nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating cluster health request", err.Error())
+		return
+	}
+
+	getReq.Header.Set("Content-Type", "application/json")
+	getReq.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(getReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading cluster health", err.Error())
+		return
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading cluster health response", err.Error())
+		return
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		resp.Diagnostics.AddError(
+			"Error reading cluster health",
+			fmt.Sprintf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body)),
+		)
+		return
+	}
+
+	var health skpropensearch.ClusterHealthResponse
+
+	if err := json.Unmarshal(body, &health); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing cluster health response",
+			fmt.Sprintf("Could not parse cluster health response: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ClusterName = types.StringValue(health.ClusterName)
+	data.Status = types.StringValue(health.Status)
+	data.TimedOut = types.BoolValue(health.TimedOut)
+	data.NumberOfNodes = types.Int64Value(health.NumberOfNodes)
+	data.NumberOfDataNodes = types.Int64Value(health.NumberOfDataNodes)
+	data.ActivePrimaryShards = types.Int64Value(health.ActivePrimaryShards)
+	data.ActiveShards = types.Int64Value(health.ActiveShards)
+	data.RelocatingShards = types.Int64Value(health.RelocatingShards)
+	data.InitializingShards = types.Int64Value(health.InitializingShards)
+	data.UnassignedShards = types.Int64Value(health.UnassignedShards)
+	data.DelayedUnassignedShards = types.Int64Value(health.DelayedUnassignedShards)
+	data.NumberOfPendingTasks = types.Int64Value(health.NumberOfPendingTasks)
+	data.ActiveShardsPercent = types.Float64Value(health.ActiveShardsPercentAsNumber)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// Prefix of import IDs which identify an object by its name instead of its generated ID.
+const importIDNamePrefix = "name:"
+
+// Splits an import ID into either a raw ID or a name, e.g. `name:my-connector`.
+func parseImportID(importID string) (value string, byName bool) {
+	if name, ok := strings.CutPrefix(importID, importIDNamePrefix); ok {
+		return name, true
+	}
+
+	return importID, false
+}
+
+// Returns the ID to import, looking it up on the given ML Commons _search endpoint when the import ID is a name.
+func resolveImportID(ctx context.Context, client *opensearchapi.Client, searchPath, importID string) (string, error) {
+	value, byName := parseImportID(importID)
+	if value == "" {
+		return "", fmt.Errorf("import ID must be an ID or %q followed by a name, got %q", importIDNamePrefix, importID)
+	}
+
+	if !byName {
+		return value, nil
+	}
+
+	ids, err := searchMLIDsByName(ctx, client, searchPath, value)
+	if err != nil {
+		return "", fmt.Errorf("could not search for %q: %w", value, err)
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("nothing is named %q", value)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%d objects are named %q (%s), import by ID instead", len(ids), value, strings.Join(ids, ", "))
+	}
+}
@@ -0,0 +1,1325 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &IndexResource{}
+	_ resource.ResourceWithImportState    = &IndexResource{}
+	_ resource.ResourceWithModifyPlan     = &IndexResource{}
+	_ resource.ResourceWithValidateConfig = &IndexResource{}
+)
+
+// How long to wait for the index to reach wait_for_status by default.
+const defaultIndexWaitForStatusTimeout = "30s"
+
+// Matches valid index names, which are lower case and can't start with _, - or +.
+var indexNamePattern = regexp.MustCompile(`^[^_\-+A-Z\\/*?"<>|\s,#:][^A-Z\\/*?"<>|\s,#:]*$`)
+
+// NewIndexResource is a helper function to simplify the provider implementation.
+func NewIndexResource() resource.Resource {
+	return &IndexResource{}
+}
+
+// IndexResource is the resource implementation.
+type IndexResource struct {
+	providerData *ProviderData
+}
+
+// IndexModel describes the Index resource data model.
+type IndexModel struct {
+	ID                        types.String      `tfsdk:"id"`
+	Name                      types.String      `tfsdk:"name"`
+	Settings                  JSONBody          `tfsdk:"settings"`
+	Mappings                  JSONBody          `tfsdk:"mappings"`
+	Aliases                   []IndexAliasModel `tfsdk:"aliases"`
+	BlocksReadOnly            types.Bool        `tfsdk:"blocks_read_only"`
+	BlocksReadOnlyAllowDelete types.Bool        `tfsdk:"blocks_read_only_allow_delete"`
+	BlocksWrite               types.Bool        `tfsdk:"blocks_write"`
+	AdoptIfExists             types.Bool        `tfsdk:"adopt_if_exists"`
+	CloseForStaticSettings    types.Bool        `tfsdk:"close_for_static_settings"`
+	WaitForStatus             types.String      `tfsdk:"wait_for_status"`
+	WaitForStatusTimeout      types.String      `tfsdk:"wait_for_status_timeout"`
+	HealthStatus              types.String      `tfsdk:"health_status"`
+	DocsCount                 types.Int64       `tfsdk:"docs_count"`
+	StoreSizeBytes            types.Int64       `tfsdk:"store_size_bytes"`
+}
+
+// IndexAliasModel describes an alias of the index.
+type IndexAliasModel struct {
+	Name         types.String `tfsdk:"name"`
+	Filter       JSONBody     `tfsdk:"filter"`
+	Routing      types.String `tfsdk:"routing"`
+	IsWriteIndex types.Bool   `tfsdk:"is_write_index"`
+}
+
+// Sets the managed block attributes from the flattened index settings. read_only_allow_delete is left as is,
+// OpenSearch sets it by itself when disks fill up.
+func (m *IndexModel) readBackBlocks(settings map[string]string) {
+	if !m.BlocksReadOnly.IsNull() {
+		m.BlocksReadOnly = types.BoolValue(settings["blocks.read_only"] == "true")
+	}
+
+	if !m.BlocksWrite.IsNull() {
+		m.BlocksWrite = types.BoolValue(settings["blocks.write"] == "true")
+	}
+}
+
+// Returns the block settings to lift before the other changes to the index, and those to add after them.
+// Blocks which are no longer managed are reset to their default with null.
+func indexBlockChanges(prior, planned IndexModel) (map[string]any, map[string]any) {
+	lift := make(map[string]any)
+	add := make(map[string]any)
+
+	priorBlocks := prior.blocks()
+	for setting, value := range planned.blocks() {
+		if value.Equal(priorBlocks[setting]) {
+			continue
+		}
+
+		switch {
+		case value.IsNull():
+			lift["index."+setting] = nil
+		case value.ValueBool():
+			add["index."+setting] = true
+		default:
+			lift["index."+setting] = false
+		}
+	}
+
+	return lift, add
+}
+
+// Returns the block attributes by the index setting they manage, without the "index." prefix.
+func (m IndexModel) blocks() map[string]types.Bool {
+	return map[string]types.Bool{
+		"blocks.read_only":              m.BlocksReadOnly,
+		"blocks.read_only_allow_delete": m.BlocksReadOnlyAllowDelete,
+		"blocks.write":                  m.BlocksWrite,
+	}
+}
+
+// Metadata returns the resource type name.
+func (r *IndexResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_index", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Index resource.
+func (r *IndexResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates an index. Dynamic settings (e.g. `number_of_replicas`) and new mappings are updated in place, " +
+			"changing a static setting (e.g. `number_of_shards`) replaces the index, **deleting its documents**, " +
+			"unless `close_for_static_settings` allows closing the index to change it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the index.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the index.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(indexNamePattern, "must be a lower case index name which doesn't start with _, - or +, "+
+						"and doesn't contain spaces or any of \\ / * ? \" < > | , # :"),
+				},
+			},
+			"settings": schema.StringAttribute{
+				MarkdownDescription: "A JSON object of index settings, either nested or flat and with or without the `index.` prefix, " +
+					"e.g. `{\"number_of_shards\": 1}`. Only the settings given here are checked for drift.",
+				Optional:   true,
+				CustomType: JSONBodyType{},
+				PlanModifiers: []planmodifier.String{
+					UseStateForSemanticallyEqualJSON(),
+					indexSettingsReplaceModifier{},
+				},
+			},
+			"mappings": schema.StringAttribute{
+				MarkdownDescription: "A JSON object of index mappings, e.g. `{\"properties\": {\"title\": {\"type\": \"text\"}}}`. " +
+					"Changes are sent to the `_mapping` API, which can add fields but not change or remove existing ones. " +
+					"Only the mappings given here are checked for drift.",
+				Optional:   true,
+				CustomType: JSONBodyType{},
+				PlanModifiers: []planmodifier.String{
+					UseStateForSemanticallyEqualJSON(),
+				},
+			},
+			"aliases": schema.SetNestedAttribute{
+				MarkdownDescription: "Aliases of the index, created along with it, e.g. the read and write aliases of a rollover setup. " +
+					"When set, these are all the aliases the index has: aliases added outside Terraform show up as drift and are removed on the next apply. " +
+					"Don't also manage these aliases with `opensearch_alias`.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the alias.",
+							Required:            true,
+						},
+						"filter": schema.StringAttribute{
+							MarkdownDescription: "A JSON query limiting the documents visible through the alias, e.g. `{\"term\": {\"tenant\": \"a\"}}`.",
+							Optional:            true,
+							CustomType:          JSONBodyType{},
+						},
+						"routing": schema.StringAttribute{
+							MarkdownDescription: "Routing value used to index and search through the alias.",
+							Optional:            true,
+						},
+						"is_write_index": schema.BoolAttribute{
+							MarkdownDescription: "Whether documents written to the alias go to this index.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"blocks_read_only": schema.BoolAttribute{
+				MarkdownDescription: "Whether the index and its metadata are read only (`index.blocks.read_only`). " +
+					"The block is lifted before other changes to the index are applied, and before the index is deleted.",
+				Optional: true,
+			},
+			"blocks_read_only_allow_delete": schema.BoolAttribute{
+				MarkdownDescription: "Whether the index is read only but can still be deleted (`index.blocks.read_only_allow_delete`). " +
+					"OpenSearch also sets this block when a node passes the flood stage disk watermark, so the value in OpenSearch is not checked for drift.",
+				Optional: true,
+			},
+			"blocks_write": schema.BoolAttribute{
+				MarkdownDescription: "Whether writes to the index are blocked while reads and metadata changes are allowed (`index.blocks.write`).",
+				Optional:            true,
+			},
+			"wait_for_status": schema.StringAttribute{
+				MarkdownDescription: "Wait after creating or updating the index until its health is at least this status (`yellow` or `green`), " +
+					"e.g. so documents can be written to it straight away. Defaults to not waiting.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("yellow", "green"),
+				},
+			},
+			"wait_for_status_timeout": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("How long to wait for `wait_for_status`, e.g. `2m`. Defaults to `%s`. ", defaultIndexWaitForStatusTimeout) +
+					"The apply fails if the index doesn't reach the status in time. The provider's `request_timeout` must be longer.",
+				Optional: true,
+			},
+			"health_status": schema.StringAttribute{
+				MarkdownDescription: "Health status of the index (`green`, `yellow` or `red`) when the last create or update finished waiting for `wait_for_status`.",
+				Computed:            true,
+			},
+			"docs_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of documents in the index, not counting replicas, as of the last refresh.",
+				Computed:            true,
+			},
+			"store_size_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Size of the index on disk in bytes, including replicas, as of the last refresh.",
+				Computed:            true,
+			},
+			"adopt_if_exists": schema.BoolAttribute{
+				MarkdownDescription: "Adopt an index which already exists instead of failing, e.g. one created implicitly by an index template when a document was first written. " +
+					"Its dynamic settings are updated to the configured values and the configured mappings are added. " +
+					"Adopting fails if a static setting (e.g. `number_of_shards`) differs, as that can only be changed by recreating the index. Defaults to `false`.",
+				Optional: true,
+			},
+			"close_for_static_settings": schema.BoolAttribute{
+				MarkdownDescription: "Change static settings which OpenSearch allows on a closed index (e.g. `codec` or `analysis`) by closing the index, " +
+					"updating its settings and opening it again, instead of replacing the index. " +
+					"The index can't be read or written while it is closed. Settings fixed at creation (e.g. `number_of_shards`) still replace the index. Defaults to `false`.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+// ValidateConfig rejects aliases which are given more than once, and blocks which are set both as an attribute and in settings.
+func (r *IndexResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data IndexModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := make(map[string]bool, len(data.Aliases))
+
+	for _, alias := range data.Aliases {
+		if alias.Name.IsUnknown() {
+			continue
+		}
+
+		if seen[alias.Name.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("aliases"),
+				"Duplicate index alias",
+				fmt.Sprintf("The alias %q is given more than once, an index has a single filter, routing and is_write_index per alias.", alias.Name.ValueString()),
+			)
+		}
+
+		seen[alias.Name.ValueString()] = true
+	}
+
+	if !data.WaitForStatusTimeout.IsNull() && !data.WaitForStatusTimeout.IsUnknown() {
+		if timeout, err := time.ParseDuration(data.WaitForStatusTimeout.ValueString()); err != nil || timeout <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("wait_for_status_timeout"),
+				"Invalid wait timeout",
+				fmt.Sprintf("The wait_for_status_timeout %q must be a positive duration, e.g. \"30s\".", data.WaitForStatusTimeout.ValueString()),
+			)
+		}
+	}
+
+	if data.Settings.IsNull() || data.Settings.IsUnknown() {
+		return
+	}
+
+	// Invalid JSON is reported by the attribute's validation.
+	settings, err := parseIndexSettings(data.Settings.ValueString())
+	if err != nil {
+		return
+	}
+
+	for setting, value := range data.blocks() {
+		if _, ok := settings[setting]; ok && !value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("settings"),
+				"Conflicting index block",
+				fmt.Sprintf("The %s setting is managed by the %s attribute, remove it from settings.", setting, strings.ReplaceAll(setting, ".", "_")),
+			)
+		}
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *IndexResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *IndexResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// ModifyPlan warns about settings which OpenSearch Serverless manages itself, when the provider is configured for it.
+func (r *IndexResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying.
+	if req.Plan.Raw.IsNull() || r.providerData == nil || !r.providerData.Serverless {
+		return
+	}
+
+	var settings JSONBody
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("settings"), &settings)...)
+	if resp.Diagnostics.HasError() || settings.IsNull() || settings.IsUnknown() {
+		return
+	}
+
+	// Invalid JSON is reported by the attribute's validation.
+	planned, err := parseIndexSettings(settings.ValueString())
+	if err != nil {
+		return
+	}
+
+	names := make([]string, 0, len(planned))
+	for name := range planned {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if reason := skpropensearch.ServerlessManagedIndexSettingReason(name); reason != "" {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("settings"),
+				"Index setting managed by OpenSearch Serverless",
+				fmt.Sprintf("The %s setting can't be set on a Serverless collection: %s See %s.", name, reason, skpropensearch.ServerlessLimitationsURL),
+			)
+		}
+	}
+}
+
+// Create creates the index with its settings and mappings.
+func (r *IndexResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data IndexModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	var createRequest skpropensearch.IndexCreateRequest
+
+	if !data.Settings.IsNull() {
+		createRequest.Settings = json.RawMessage(data.Settings.ValueString())
+	}
+
+	if !data.Mappings.IsNull() {
+		createRequest.Mappings = json.RawMessage(data.Mappings.ValueString())
+	}
+
+	if len(data.Aliases) > 0 {
+		createRequest.Aliases = make(map[string]skpropensearch.IndexAlias, len(data.Aliases))
+		for _, alias := range data.Aliases {
+			createRequest.Aliases[alias.Name.ValueString()] = alias.indexAlias()
+		}
+	}
+
+	createBody, err := json.Marshal(createRequest)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating index request",
+			fmt.Sprintf("Could not encode index: %s", err.Error()),
+		)
+		return
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "PUT", fmt.Sprintf("/%s", data.Name.ValueString()), createBody)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating index", err.Error())
+		return
+	}
+
+	switch {
+	case status == http.StatusBadRequest && data.AdoptIfExists.ValueBool() && strings.Contains(string(body), "resource_already_exists_exception"):
+		if err := adoptIndex(ctx, client, data); err != nil {
+			resp.Diagnostics.AddError(
+				"Error adopting index",
+				fmt.Sprintf("Could not adopt existing index %s: %s", data.Name.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		tflog.Trace(ctx, "adopted existing index", map[string]any{
+			"index": data.Name.ValueString(),
+		})
+	case status < 200 || status >= 300:
+		resp.Diagnostics.AddError(
+			"Error creating index",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	blocks := make(map[string]any)
+	for setting, value := range data.blocks() {
+		if !value.IsNull() {
+			blocks["index."+setting] = value.ValueBool()
+		}
+	}
+
+	if len(blocks) > 0 {
+		if err := putIndexSettings(ctx, client, data.Name.ValueString(), blocks); err != nil {
+			resp.Diagnostics.AddError(
+				"Error setting index blocks",
+				fmt.Sprintf("Could not set the blocks of index %s: %s", data.Name.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+
+	if err := data.waitForStatus(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error waiting for index",
+			fmt.Sprintf("Could not wait for index %s to become %s: %s", data.Name.ValueString(), data.WaitForStatus.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if err := data.setStats(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading index stats",
+			fmt.Sprintf("Could not read the stats of index %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+
+	tflog.Trace(ctx, "created Index resource", map[string]any{
+		"index": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read refreshes the configured settings and mappings, and the stats of the index, from OpenSearch.
+func (r *IndexResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data IndexModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	index, found, err := getIndex(ctx, client, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading index", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if !data.Settings.IsNull() {
+		settings, err := readBackIndexSettings(data.Settings.ValueString(), remoteIndexSettings(index))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading index",
+				fmt.Sprintf("Could not compare index settings: %s", err.Error()),
+			)
+			return
+		}
+
+		data.Settings = NewJSONBodyValue(settings)
+	}
+
+	if !data.Mappings.IsNull() {
+		mappings, err := readBackIndexMappings(data.Mappings.ValueString(), index.Mappings)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading index",
+				fmt.Sprintf("Could not compare index mappings: %s", err.Error()),
+			)
+			return
+		}
+
+		data.Mappings = NewJSONBodyValue(mappings)
+	}
+
+	// Aliases are only tracked when configured, as rollovers and opensearch_alias also add them.
+	if data.Aliases != nil {
+		data.Aliases = readBackIndexAliases(data.Name.ValueString(), data.Aliases, index.Aliases)
+	}
+
+	data.readBackBlocks(flattenIndexSettings(index.Settings))
+
+	if err := data.setStats(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading index stats",
+			fmt.Sprintf("Could not read the stats of index %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update applies dynamic setting changes and new mappings to the index. Static setting changes only get here
+// with close_for_static_settings, and are applied while the index is closed, otherwise they replace the index.
+func (r *IndexResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state IndexModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	// Blocks are lifted first, as a read only index rejects the other changes, and added last.
+	lift, add := indexBlockChanges(state, data)
+
+	if len(lift) > 0 {
+		if err := putIndexSettings(ctx, client, name, lift); err != nil {
+			resp.Diagnostics.AddError(
+				"Error lifting index blocks",
+				fmt.Sprintf("Could not lift the blocks of index %s: %s", name, err.Error()),
+			)
+			return
+		}
+	}
+
+	prior, err := parseIndexSettings(state.Settings.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error parsing index settings", err.Error())
+		return
+	}
+
+	planned, err := parseIndexSettings(data.Settings.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error parsing index settings", err.Error())
+		return
+	}
+
+	// Settings which were removed from the configuration are reset to their default with null.
+	update := make(map[string]any)
+	closed := make(map[string]any)
+
+	for _, setting := range changedIndexSettings(prior, planned) {
+		var value any
+		if v, ok := planned[setting]; ok {
+			value = v
+		}
+
+		if data.CloseForStaticSettings.ValueBool() && skpropensearch.IndexSettingRequiresClose(setting) {
+			closed["index."+setting] = value
+		} else {
+			update["index."+setting] = value
+		}
+	}
+
+	if len(update) > 0 {
+		if err := putIndexSettings(ctx, client, name, update); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating index settings",
+				fmt.Sprintf("Could not update the settings of index %s: %s", name, err.Error()),
+			)
+			return
+		}
+	}
+
+	if len(closed) > 0 {
+		if err := putClosedIndexSettings(ctx, client, name, closed); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating static index settings",
+				fmt.Sprintf("Could not update the static settings of index %s: %s", name, err.Error()),
+			)
+			return
+		}
+	}
+
+	// Mappings can't be removed, so only a changed mapping is sent.
+	if !data.Mappings.IsNull() && !data.Mappings.Equal(state.Mappings) {
+		status, body, err := performJSONRequest(ctx, client, "PUT", fmt.Sprintf("/%s/_mapping", name), []byte(data.Mappings.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError("Error updating index mappings", err.Error())
+			return
+		}
+
+		if status < 200 || status >= 300 {
+			resp.Diagnostics.AddError(
+				"Error updating index mappings",
+				fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+			)
+			return
+		}
+	}
+
+	// Removing the attribute stops managing the aliases, so they are left as they are.
+	if data.Aliases != nil {
+		if actions := indexAliasActions(name, state.Aliases, data.Aliases); len(actions) > 0 {
+			if err := updateAliases(ctx, client, actions); err != nil {
+				resp.Diagnostics.AddError(
+					"Error updating index aliases",
+					fmt.Sprintf("Could not update the aliases of index %s: %s", name, err.Error()),
+				)
+				return
+			}
+		}
+	}
+
+	if len(add) > 0 {
+		if err := putIndexSettings(ctx, client, name, add); err != nil {
+			resp.Diagnostics.AddError(
+				"Error setting index blocks",
+				fmt.Sprintf("Could not set the blocks of index %s: %s", name, err.Error()),
+			)
+			return
+		}
+	}
+
+	if err := data.waitForStatus(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error waiting for index",
+			fmt.Sprintf("Could not wait for index %s to become %s: %s", name, data.WaitForStatus.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if err := data.setStats(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading index stats",
+			fmt.Sprintf("Could not read the stats of index %s: %s", name, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "updated Index resource", map[string]any{
+		"index": name,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes the index and its documents.
+func (r *IndexResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data IndexModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	// A read only index can't be deleted, unlike one with read_only_allow_delete.
+	if data.BlocksReadOnly.ValueBool() {
+		err := putIndexSettings(ctx, client, data.Name.ValueString(), map[string]any{"index.blocks.read_only": false})
+		if err != nil && !errors.Is(err, errIndexNotFound) {
+			resp.Diagnostics.AddError(
+				"Error lifting index blocks",
+				fmt.Sprintf("Could not lift the read only block of index %s: %s", data.Name.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "DELETE", fmt.Sprintf("/%s", data.Name.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting index", err.Error())
+		return
+	}
+
+	// Treat 404 as already deleted.
+	if status == http.StatusNotFound {
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error deleting index",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Index resource", map[string]any{
+		"index": data.Name.ValueString(),
+	})
+}
+
+// ImportState imports an index by its name. Settings and mappings are left unset, as only configured ones are tracked.
+func (r *IndexResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
+
+// Waits for the index health to reach wait_for_status, when set, recording the status it ended with.
+func (m *IndexModel) waitForStatus(ctx context.Context, client *opensearchapi.Client) error {
+	if m.WaitForStatus.IsNull() {
+		m.HealthStatus = types.StringNull()
+		return nil
+	}
+
+	timeout := defaultIndexWaitForStatusTimeout
+	if !m.WaitForStatusTimeout.IsNull() {
+		timeout = m.WaitForStatusTimeout.ValueString()
+	}
+
+	healthPath := fmt.Sprintf("/_cluster/health/%s?wait_for_status=%s&timeout=%s", m.Name.ValueString(), m.WaitForStatus.ValueString(), timeout)
+
+	status, body, err := performJSONRequest(ctx, client, "GET", healthPath, nil)
+	if err != nil {
+		return err
+	}
+
+	// OpenSearch answers 408 along with the health when the wait times out.
+	if (status < 200 || status >= 300) && status != http.StatusRequestTimeout {
+		return fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	var health skpropensearch.ClusterHealthResponse
+
+	if err := json.Unmarshal(body, &health); err != nil {
+		return fmt.Errorf("could not parse health response: %w", err)
+	}
+
+	if health.TimedOut {
+		return fmt.Errorf("the index is still %s after %s, check for unassigned shards with GET /_cluster/allocation/explain", health.Status, timeout)
+	}
+
+	m.HealthStatus = types.StringValue(health.Status)
+
+	return nil
+}
+
+// Sets docs_count and store_size_bytes from the stats of the index.
+func (m *IndexModel) setStats(ctx context.Context, client *opensearchapi.Client) error {
+	stats, err := getIndexStats(ctx, client, m.Name.ValueString())
+	if err != nil {
+		return err
+	}
+
+	m.DocsCount = types.Int64Value(stats.All.Primaries.Docs.Count)
+	m.StoreSizeBytes = types.Int64Value(stats.All.Total.Store.SizeInBytes)
+
+	return nil
+}
+
+// Returns the docs and store stats of the index.
+func getIndexStats(ctx context.Context, client *opensearchapi.Client, name string) (skpropensearch.IndexStatsResponse, error) {
+	var stats skpropensearch.IndexStatsResponse
+
+	status, body, err := performJSONRequest(ctx, client, "GET", fmt.Sprintf("/%s/_stats/docs,store", name), nil)
+	if err != nil {
+		return stats, err
+	}
+
+	if status == http.StatusNotFound {
+		return stats, errIndexNotFound
+	}
+
+	if status < 200 || status >= 300 {
+		return stats, fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	if err := json.Unmarshal(body, &stats); err != nil {
+		return stats, fmt.Errorf("could not parse index stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// Returns the index with its flat settings and their defaults, and whether it exists.
+func getIndex(ctx context.Context, client *opensearchapi.Client, name string) (skpropensearch.Index, bool, error) {
+	var index skpropensearch.Index
+
+	// Defaults are included so a setting reset to its default is compared against the default value.
+	status, body, err := performJSONRequest(ctx, client, "GET", fmt.Sprintf("/%s?flat_settings=true&include_defaults=true", name), nil)
+	if err != nil {
+		return index, false, err
+	}
+
+	if status == http.StatusNotFound {
+		return index, false, nil
+	}
+
+	if status < 200 || status >= 300 {
+		return index, false, fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	var getResponse skpropensearch.IndexGetResponse
+
+	if err := json.Unmarshal(body, &getResponse); err != nil {
+		return index, false, fmt.Errorf("could not parse index response: %w", err)
+	}
+
+	index, found := getResponse[name]
+
+	return index, found, nil
+}
+
+// Returns the flat settings of an index read with getIndex, with defaults for those which aren't set.
+func remoteIndexSettings(index skpropensearch.Index) map[string]string {
+	remote := flattenIndexSettings(index.Defaults)
+	for setting, value := range flattenIndexSettings(index.Settings) {
+		remote[setting] = value
+	}
+
+	return remote
+}
+
+// Reconciles an index which already exists with the configuration: dynamic settings which differ are updated,
+// the mappings are sent to the _mapping API, which adds new fields, and configured aliases replace the index's
+// aliases. A static setting which differs can't be changed without recreating the index, so the index is left
+// untouched and an error names the settings.
+// Blocks are applied by the caller, as for a created index.
+func adoptIndex(ctx context.Context, client *opensearchapi.Client, data IndexModel) error {
+	name := data.Name.ValueString()
+
+	index, found, err := getIndex(ctx, client, name)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("the index was reported to exist but could not be read")
+	}
+
+	planned, err := parseIndexSettings(data.Settings.ValueString())
+	if err != nil {
+		return err
+	}
+
+	remote := remoteIndexSettings(index)
+
+	update := make(map[string]any)
+
+	var static []string
+
+	for _, setting := range changedIndexSettings(remote, planned) {
+		value, ok := planned[setting]
+		if !ok {
+			// Only configured settings are managed, the rest are left as they are.
+			continue
+		}
+
+		if reason := skpropensearch.IndexSettingReplaceReason(setting); reason != "" {
+			static = append(static, fmt.Sprintf("%s is %q rather than %q (%s)", setting, remote[setting], value, reason))
+			continue
+		}
+
+		update["index."+setting] = value
+	}
+
+	if len(static) > 0 {
+		return fmt.Errorf("static settings differ, delete the index or change the configuration to match:\n\n%s", strings.Join(static, "\n"))
+	}
+
+	if len(update) > 0 {
+		if err := putIndexSettings(ctx, client, name, update); err != nil {
+			return fmt.Errorf("could not update settings: %w", err)
+		}
+	}
+
+	if !data.Mappings.IsNull() {
+		status, body, err := performJSONRequest(ctx, client, "PUT", fmt.Sprintf("/%s/_mapping", name), []byte(data.Mappings.ValueString()))
+		if err != nil {
+			return fmt.Errorf("could not update mappings: %w", err)
+		}
+
+		if status < 200 || status >= 300 {
+			return fmt.Errorf("could not update mappings, OpenSearch returned %d: %s", status, string(body))
+		}
+	}
+
+	if data.Aliases != nil {
+		if actions := indexAliasActions(name, readBackIndexAliases(name, nil, index.Aliases), data.Aliases); len(actions) > 0 {
+			if err := updateAliases(ctx, client, actions); err != nil {
+				return fmt.Errorf("could not update aliases: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Returns the alias as given in the body of an index create request.
+func (m IndexAliasModel) indexAlias() skpropensearch.IndexAlias {
+	alias := skpropensearch.IndexAlias{
+		Routing: m.Routing.ValueString(),
+	}
+
+	if !m.Filter.IsNull() {
+		alias.Filter = json.RawMessage(m.Filter.ValueString())
+	}
+
+	if !m.IsWriteIndex.IsNull() {
+		isWriteIndex := m.IsWriteIndex.ValueBool()
+		alias.IsWriteIndex = &isWriteIndex
+	}
+
+	return alias
+}
+
+// Groups the aliases by name as the index entries of an opensearch_alias, to share its comparison and read back.
+func aliasIndicesByName(index string, aliases []IndexAliasModel) map[string][]AliasIndexModel {
+	byName := make(map[string][]AliasIndexModel, len(aliases))
+
+	for _, alias := range aliases {
+		byName[alias.Name.ValueString()] = []AliasIndexModel{{
+			Index:        types.StringValue(index),
+			Filter:       alias.Filter,
+			Routing:      alias.Routing,
+			IsWriteIndex: alias.IsWriteIndex,
+		}}
+	}
+
+	return byName
+}
+
+// Returns the actions moving the index from the prior to the planned aliases, see aliasActions.
+func indexAliasActions(index string, prior, planned []IndexAliasModel) []skpropensearch.AliasAction {
+	priorAliases := aliasIndicesByName(index, prior)
+	plannedAliases := aliasIndicesByName(index, planned)
+
+	names := make([]string, 0, len(priorAliases)+len(plannedAliases))
+	for name := range priorAliases {
+		names = append(names, name)
+	}
+
+	for name := range plannedAliases {
+		if _, ok := priorAliases[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	var actions []skpropensearch.AliasAction
+	for _, name := range names {
+		actions = append(actions, aliasActions(name, priorAliases[name], plannedAliases[name])...)
+	}
+
+	return actions
+}
+
+// Returns the aliases the index has in OpenSearch, sorted by name. Values which match the state keep the
+// state's form, see readBackAliasIndices.
+func readBackIndexAliases(index string, state []IndexAliasModel, remote map[string]skpropensearch.Alias) []IndexAliasModel {
+	stateAliases := aliasIndicesByName(index, state)
+	response := skpropensearch.AliasGetResponse{index: {Aliases: remote}}
+
+	names := make([]string, 0, len(remote))
+	for name := range remote {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	aliases := make([]IndexAliasModel, 0, len(names))
+
+	for _, name := range names {
+		for _, alias := range readBackAliasIndices(name, stateAliases[name], response) {
+			aliases = append(aliases, IndexAliasModel{
+				Name:         types.StringValue(name),
+				Filter:       alias.Filter,
+				Routing:      alias.Routing,
+				IsWriteIndex: alias.IsWriteIndex,
+			})
+		}
+	}
+
+	return aliases
+}
+
+// Returned by putIndexSettings when the index doesn't exist.
+var errIndexNotFound = errors.New("index not found")
+
+// Updates the given index settings, which have the "index." prefix. A null value resets a setting to its default.
+func putIndexSettings(ctx context.Context, client *opensearchapi.Client, name string, settings map[string]any) error {
+	requestBody, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("could not encode index settings: %w", err)
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "PUT", fmt.Sprintf("/%s/_settings", name), requestBody)
+	if err != nil {
+		return err
+	}
+
+	if status == http.StatusNotFound {
+		return errIndexNotFound
+	}
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	return nil
+}
+
+// Updates static index settings, which have the "index." prefix, by closing the index and opening it again.
+// The index is opened again even when the update fails, so it isn't left unavailable.
+func putClosedIndexSettings(ctx context.Context, client *opensearchapi.Client, name string, settings map[string]any) error {
+	if err := postIndexAction(ctx, client, name, "_close"); err != nil {
+		return fmt.Errorf("could not close the index: %w", err)
+	}
+
+	updateErr := putIndexSettings(ctx, client, name, settings)
+
+	if err := postIndexAction(ctx, client, name, "_open"); err != nil {
+		return errors.Join(updateErr, fmt.Errorf("could not open the index again: %w", err))
+	}
+
+	return updateErr
+}
+
+// Calls an index API without a body, e.g. _close or _open.
+func postIndexAction(ctx context.Context, client *opensearchapi.Client, name, action string) error {
+	status, body, err := performJSONRequest(ctx, client, "POST", fmt.Sprintf("/%s/%s", name, action), nil)
+	if err != nil {
+		return err
+	}
+
+	if status == http.StatusNotFound {
+		return errIndexNotFound
+	}
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	return nil
+}
+
+// Requires replacing the index when a static setting is added, changed or removed,
+// unless close_for_static_settings is set and the setting can be changed on a closed index.
+type indexSettingsReplaceModifier struct{}
+
+// Description returns a plain text description of the modifier's behavior.
+func (m indexSettingsReplaceModifier) Description(ctx context.Context) string {
+	return "Requires replacing the index when a static setting changes."
+}
+
+// MarkdownDescription returns a markdown formatted description of the modifier's behavior.
+func (m indexSettingsReplaceModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString requires replacement, with a warning explaining why, when a changed setting can't be updated in place.
+func (m indexSettingsReplaceModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to replace while creating.
+	if req.State.Raw.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	// Invalid JSON is reported by the attribute's validation.
+	prior, err := parseIndexSettings(req.StateValue.ValueString())
+	if err != nil {
+		return
+	}
+
+	planned, err := parseIndexSettings(req.PlanValue.ValueString())
+	if err != nil {
+		return
+	}
+
+	var closeForStaticSettings types.Bool
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("close_for_static_settings"), &closeForStaticSettings)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var reasons, closed []string
+
+	for _, setting := range changedIndexSettings(prior, planned) {
+		reason := skpropensearch.IndexSettingReplaceReason(setting)
+
+		switch {
+		case reason == "":
+		case closeForStaticSettings.ValueBool() && skpropensearch.IndexSettingRequiresClose(setting):
+			closed = append(closed, fmt.Sprintf("- %s", setting))
+		default:
+			reasons = append(reasons, fmt.Sprintf("- %s: %s", setting, reason))
+		}
+	}
+
+	if len(reasons) == 0 {
+		if len(closed) > 0 {
+			resp.Diagnostics.AddAttributeWarning(
+				req.Path,
+				"Index will be closed",
+				fmt.Sprintf("Changing these settings closes the index, so it can't be read or written until it is opened again:\n\n%s", strings.Join(closed, "\n")),
+			)
+		}
+
+		return
+	}
+
+	resp.RequiresReplace = true
+
+	resp.Diagnostics.AddAttributeWarning(
+		req.Path,
+		"Index will be replaced",
+		fmt.Sprintf("Changing these settings deletes the index and its documents before creating it again:\n\n%s", strings.Join(reasons, "\n")),
+	)
+}
+
+// Parses a settings JSON object into flat setting names, see flattenIndexSettings. An empty string has no settings.
+func parseIndexSettings(settings string) (map[string]string, error) {
+	if settings == "" {
+		return map[string]string{}, nil
+	}
+
+	var parsed map[string]any
+
+	if err := json.Unmarshal([]byte(settings), &parsed); err != nil {
+		return nil, fmt.Errorf("settings must be a JSON object: %w", err)
+	}
+
+	return flattenIndexSettings(parsed), nil
+}
+
+// Flattens nested settings into names without the "index." prefix, with values as strings as OpenSearch
+// reports them, e.g. {"index": {"number_of_replicas": 1}} becomes {"number_of_replicas": "1"}.
+func flattenIndexSettings(settings map[string]any) map[string]string {
+	flat := make(map[string]string)
+	flattenIndexSettingsInto(flat, "", settings)
+
+	return flat
+}
+
+func flattenIndexSettingsInto(flat map[string]string, prefix string, settings map[string]any) {
+	for key, value := range settings {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+
+		if nested, ok := value.(map[string]any); ok {
+			flattenIndexSettingsInto(flat, name, nested)
+			continue
+		}
+
+		flat[strings.TrimPrefix(name, "index.")] = indexSettingString(value)
+	}
+}
+
+// Returns a setting value as a string, as OpenSearch stores every setting as one, e.g. 1 and "1" are the same.
+func indexSettingString(value any) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case nil:
+		return ""
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+
+		return string(encoded)
+	}
+}
+
+// Returns the sorted names of settings which were added, changed or removed.
+func changedIndexSettings(prior, planned map[string]string) []string {
+	var changed []string
+
+	for setting, value := range planned {
+		if priorValue, ok := prior[setting]; !ok || priorValue != value {
+			changed = append(changed, setting)
+		}
+	}
+
+	for setting := range prior {
+		if _, ok := planned[setting]; !ok {
+			changed = append(changed, setting)
+		}
+	}
+
+	sort.Strings(changed)
+
+	return changed
+}
+
+// Returns the settings from state with any values which differ in OpenSearch replaced, keeping the
+// state's layout so unchanged settings don't show as a diff. Settings OpenSearch doesn't report are kept.
+func readBackIndexSettings(state string, remote map[string]string) (string, error) {
+	var settings map[string]any
+
+	if err := json.Unmarshal([]byte(state), &settings); err != nil {
+		return "", err
+	}
+
+	if !readBackIndexSettingsInto(settings, "", remote) {
+		return state, nil
+	}
+
+	encoded, err := json.Marshal(settings)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+func readBackIndexSettingsInto(settings map[string]any, prefix string, remote map[string]string) bool {
+	changed := false
+
+	for key, value := range settings {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+
+		if nested, ok := value.(map[string]any); ok {
+			if readBackIndexSettingsInto(nested, name, remote) {
+				changed = true
+			}
+			continue
+		}
+
+		remoteValue, ok := remote[strings.TrimPrefix(name, "index.")]
+		if !ok || remoteValue == indexSettingString(value) {
+			continue
+		}
+
+		settings[key] = remoteValue
+		changed = true
+	}
+
+	return changed
+}
+
+// Returns the mappings from state when OpenSearch has them, ignoring anything else OpenSearch reports
+// (e.g. dynamically mapped fields). Otherwise returns the parts of the remote mappings the state covers.
+func readBackIndexMappings(state string, remote json.RawMessage) (string, error) {
+	var actual any
+
+	if len(remote) > 0 {
+		if err := json.Unmarshal(remote, &actual); err != nil {
+			return "", err
+		}
+	}
+
+	return readBackJSONSubset(state, actual)
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &IndexDataSource{}
+
+// NewIndexDataSource is a helper function to simplify the provider implementation.
+func NewIndexDataSource() datasource.DataSource {
+	return &IndexDataSource{}
+}
+
+// IndexDataSource is the data source implementation.
+type IndexDataSource struct {
+	providerData *ProviderData
+}
+
+// IndexDataSourceModel describes the Index data source data model.
+type IndexDataSourceModel struct {
+	Name           types.String `tfsdk:"name"`
+	ID             types.String `tfsdk:"id"`
+	DocsCount      types.Int64  `tfsdk:"docs_count"`
+	StoreSizeBytes types.Int64  `tfsdk:"store_size_bytes"`
+}
+
+// Metadata returns the data source type name.
+func (d *IndexDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_index", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Index data source.
+func (d *IndexDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the stats of an existing index, e.g. to alarm on its size or document count when it is managed elsewhere.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the index.",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the index.",
+				Computed:            true,
+			},
+			"docs_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of documents in the index, not counting replicas.",
+				Computed:            true,
+			},
+			"store_size_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Size of the index on disk in bytes, including replicas.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (d *IndexDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (d *IndexDataSource) client() (*opensearchapi.Client, error) {
+	return d.providerData.client()
+}
+
+// Read reads the stats of the index.
+func (d *IndexDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data IndexDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	stats, err := getIndexStats(ctx, client, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading index stats",
+			fmt.Sprintf("Could not read the stats of index %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+	data.DocsCount = types.Int64Value(stats.All.Primaries.Docs.Count)
+	data.StoreSizeBytes = types.Int64Value(stats.All.Total.Store.SizeInBytes)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,308 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource               = &IndexForceMergeResource{}
+	_ resource.ResourceWithModifyPlan = &IndexForceMergeResource{}
+)
+
+// Force merges rewrite every segment of the index, which can take a long time for large indices.
+const defaultForceMergeCreateTimeout = 30 * time.Minute
+
+// NewIndexForceMergeResource is a helper function to simplify the provider implementation.
+func NewIndexForceMergeResource() resource.Resource {
+	return &IndexForceMergeResource{}
+}
+
+// IndexForceMergeResource is the resource implementation.
+type IndexForceMergeResource struct {
+	providerData *ProviderData
+}
+
+// IndexForceMergeModel describes the Index Force Merge resource data model.
+type IndexForceMergeModel struct {
+	ID                types.String   `tfsdk:"id"`
+	Index             types.String   `tfsdk:"index"`
+	MaxNumSegments    types.Int64    `tfsdk:"max_num_segments"`
+	WaitForCompletion types.Bool     `tfsdk:"wait_for_completion"`
+	Triggers          types.Map      `tfsdk:"triggers"`
+	TaskID            types.String   `tfsdk:"task_id"`
+	TotalShards       types.Int64    `tfsdk:"total_shards"`
+	SuccessfulShards  types.Int64    `tfsdk:"successful_shards"`
+	FailedShards      types.Int64    `tfsdk:"failed_shards"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *IndexForceMergeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_index_force_merge", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Index Force Merge resource.
+func (r *IndexForceMergeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Force merges the segments of an index when created, e.g. after bulk loading it. " +
+			"Change `triggers` to merge again. Destroying it does nothing.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the merged index.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"index": schema.StringAttribute{
+				MarkdownDescription: "Name of the index (or a comma separated list or pattern of indices) to merge.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_num_segments": schema.Int64Attribute{
+				MarkdownDescription: "Number of segments to merge each shard down to, e.g. `1` for an index which is no longer written to. " +
+					"Defaults to merging only as far as the merge policy would.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				MarkdownDescription: "Whether to wait for the merge to finish, bounded by the create timeout and the provider's `request_timeout`. " +
+					"Set to `false` to start the merge as a task and return straight away. Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values which merge the index again when changed, e.g. the version of the data loaded into it.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"task_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the task running the merge when `wait_for_completion` is `false`, e.g. to check it with `GET /_tasks/<task_id>`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"total_shards": schema.Int64Attribute{
+				MarkdownDescription: "Number of shards the merge ran on. Zero when not waiting for completion.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"successful_shards": schema.Int64Attribute{
+				MarkdownDescription: "Number of shards which were merged. Zero when not waiting for completion.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"failed_shards": schema.Int64Attribute{
+				MarkdownDescription: "Number of shards which failed to merge. Zero when not waiting for completion.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *IndexForceMergeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *IndexForceMergeResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// ModifyPlan rejects the resource when the provider is configured for OpenSearch Serverless.
+func (r *IndexForceMergeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.providerData.serverlessDiagnostics("opensearch_index_force_merge")...)
+}
+
+// Create force merges the index, waiting for it to finish unless wait_for_completion is false.
+func (r *IndexForceMergeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data IndexForceMergeModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultForceMergeCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	index := data.Index.ValueString()
+
+	query := url.Values{}
+	if !data.MaxNumSegments.IsNull() {
+		query.Set("max_num_segments", strconv.FormatInt(data.MaxNumSegments.ValueInt64(), 10))
+	}
+
+	if !data.WaitForCompletion.ValueBool() {
+		query.Set("wait_for_completion", "false")
+	}
+
+	mergePath := fmt.Sprintf("/%s/_forcemerge", index)
+	if len(query) > 0 {
+		mergePath += "?" + query.Encode()
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "POST", mergePath, nil)
+	if err != nil {
+		// The merge keeps running in OpenSearch when the request is cut short.
+		resp.Diagnostics.AddError(
+			"Error force merging index",
+			fmt.Sprintf("Could not force merge %s, a merge which was started keeps running in OpenSearch: %s", index, err.Error()),
+		)
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error force merging index",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	var mergeResponse skpropensearch.ForceMergeResponse
+
+	if err := json.Unmarshal(body, &mergeResponse); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing force merge response",
+			fmt.Sprintf("Could not parse force merge response: %s", err.Error()),
+		)
+		return
+	}
+
+	if mergeResponse.Shards.Failed > 0 {
+		resp.Diagnostics.AddError(
+			"Error force merging index",
+			fmt.Sprintf("The force merge of %s failed on %d of %d shards: %s", index, mergeResponse.Shards.Failed, mergeResponse.Shards.Total, string(body)),
+		)
+		return
+	}
+
+	data.ID = data.Index
+	data.TaskID = types.StringValue(mergeResponse.Task)
+	data.TotalShards = types.Int64Value(mergeResponse.Shards.Total)
+	data.SuccessfulShards = types.Int64Value(mergeResponse.Shards.Successful)
+	data.FailedShards = types.Int64Value(mergeResponse.Shards.Failed)
+
+	tflog.Trace(ctx, "created Index Force Merge resource", map[string]any{
+		"index":   index,
+		"task_id": mergeResponse.Task,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read keeps the state as is, a merge is a one off action.
+func (r *IndexForceMergeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data IndexForceMergeModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only persists the plan, every argument which changes the merge requires replacement.
+func (r *IndexForceMergeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data IndexForceMergeModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete does nothing, a merge can't be undone.
+func (r *IndexForceMergeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Trace(ctx, "deleted Index Force Merge resource (no-op)")
+}
@@ -0,0 +1,448 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &IndexTemplateResource{}
+	_ resource.ResourceWithImportState = &IndexTemplateResource{}
+)
+
+// NewIndexTemplateResource is a helper function to simplify the provider implementation.
+func NewIndexTemplateResource() resource.Resource {
+	return &IndexTemplateResource{}
+}
+
+// IndexTemplateResource is the resource implementation.
+type IndexTemplateResource struct {
+	providerData *ProviderData
+}
+
+// IndexTemplateModel describes the Index Template resource data model.
+type IndexTemplateModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Body              JSONBody     `tfsdk:"body"`
+	Simulate          types.Bool   `tfsdk:"simulate"`
+	SimulatedTemplate JSONBody     `tfsdk:"simulated_template"`
+}
+
+// Metadata returns the resource type name.
+func (r *IndexTemplateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_index_template", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Index Template resource.
+func (r *IndexTemplateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a composable index template, which is applied to indices created with a matching name. " +
+			"Changes only apply to indices created afterwards.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the index template.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the index template.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "A JSON payload which defines the template, e.g. its `index_patterns`, `template`, `composed_of` and `priority`. " +
+					"Only the fields given here are checked for drift.",
+				Required:   true,
+				CustomType: JSONBodyType{},
+				PlanModifiers: []planmodifier.String{
+					UseStateForSemanticallyEqualJSON(),
+				},
+				Validators: []validator.String{
+					JSONBodyFields("index template", skpropensearch.IndexTemplateBodyFields),
+				},
+			},
+			"simulate": schema.BoolAttribute{
+				MarkdownDescription: "Simulate the template before creating or updating it, failing the apply if OpenSearch rejects it " +
+					"(e.g. because its component templates conflict) and recording the result in `simulated_template`. Defaults to `false`.",
+				Optional: true,
+			},
+			"simulated_template": schema.StringAttribute{
+				MarkdownDescription: "The settings, mappings and aliases an index created from the template gets, combined with its component templates, " +
+					"as simulated when the template was last created or updated. Only set when `simulate` is enabled.",
+				Computed:   true,
+				CustomType: JSONBodyType{},
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *IndexTemplateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *IndexTemplateResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// Create creates the index template.
+func (r *IndexTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data IndexTemplateModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	data.SimulatedTemplate = NewJSONBodyNull()
+
+	if data.Simulate.ValueBool() {
+		simulated, err := simulateIndexTemplate(ctx, client, data.Name.ValueString(), data.Body.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("body"),
+				"Error simulating index template",
+				fmt.Sprintf("OpenSearch rejected index template %s: %s", data.Name.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		for _, overlap := range simulated.Overlapping {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("body"),
+				"Index template overlaps another",
+				fmt.Sprintf("Index template %s matches some of the same index patterns as %s (%s), which has a lower priority so it is ignored for those indices.",
+					data.Name.ValueString(), overlap.Name, strings.Join(overlap.IndexPatterns, ", ")),
+			)
+		}
+
+		data.SimulatedTemplate = NewJSONBodyValue(string(simulated.Template))
+	}
+
+	if err := putIndexTemplate(ctx, client, data.Name.ValueString(), data.Body.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating index template",
+			fmt.Sprintf("Could not create index template %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+
+	tflog.Trace(ctx, "created Index Template resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read compares the index template in OpenSearch with the state.
+func (r *IndexTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data IndexTemplateModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	status, body, err := performJSONRequest(ctx, client, "GET", fmt.Sprintf("/_index_template/%s", name), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading index template", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if status == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error reading index template",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	var getResponse skpropensearch.IndexTemplateGetResponse
+
+	if err := json.Unmarshal(body, &getResponse); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing index template response",
+			fmt.Sprintf("Could not parse index template response: %s", err.Error()),
+		)
+		return
+	}
+
+	var template json.RawMessage
+
+	for _, item := range getResponse.IndexTemplates {
+		if item.Name == name {
+			template = item.IndexTemplate
+		}
+	}
+
+	if template == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	templateBody, err := readBackIndexTemplateBody(data.Body.ValueString(), template)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading index template",
+			fmt.Sprintf("Could not compare index template %s: %s", name, err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+	data.Body = NewJSONBodyValue(templateBody)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update replaces the index template in place, templates are mutable.
+func (r *IndexTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data IndexTemplateModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	data.SimulatedTemplate = NewJSONBodyNull()
+
+	if data.Simulate.ValueBool() {
+		simulated, err := simulateIndexTemplate(ctx, client, data.Name.ValueString(), data.Body.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("body"),
+				"Error simulating index template",
+				fmt.Sprintf("OpenSearch rejected index template %s: %s", data.Name.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		for _, overlap := range simulated.Overlapping {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("body"),
+				"Index template overlaps another",
+				fmt.Sprintf("Index template %s matches some of the same index patterns as %s (%s), which has a lower priority so it is ignored for those indices.",
+					data.Name.ValueString(), overlap.Name, strings.Join(overlap.IndexPatterns, ", ")),
+			)
+		}
+
+		data.SimulatedTemplate = NewJSONBodyValue(string(simulated.Template))
+	}
+
+	if err := putIndexTemplate(ctx, client, data.Name.ValueString(), data.Body.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating index template",
+			fmt.Sprintf("Could not update index template %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "updated Index Template resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the index template, indices created from it are kept.
+func (r *IndexTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data IndexTemplateModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "DELETE", fmt.Sprintf("/_index_template/%s", data.Name.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting index template", err.Error())
+		return
+	}
+
+	// Treat 404 as already deleted.
+	if status == http.StatusNotFound {
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error deleting index template",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Index Template resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+}
+
+// ImportState imports an index template by its name, reading its whole body back from OpenSearch.
+func (r *IndexTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
+
+// Simulates creating the named index template with the body, returning what an index created from it gets.
+func simulateIndexTemplate(ctx context.Context, client *opensearchapi.Client, name, body string) (skpropensearch.IndexTemplateSimulateResponse, error) {
+	var simulated skpropensearch.IndexTemplateSimulateResponse
+
+	status, respBody, err := performJSONRequest(ctx, client, "POST", fmt.Sprintf("/_index_template/_simulate/%s", name), []byte(body))
+	if err != nil {
+		return simulated, err
+	}
+
+	if status < 200 || status >= 300 {
+		return simulated, fmt.Errorf("OpenSearch returned %d: %s", status, string(respBody))
+	}
+
+	if err := json.Unmarshal(respBody, &simulated); err != nil {
+		return simulated, fmt.Errorf("could not parse simulate response: %w", err)
+	}
+
+	return simulated, nil
+}
+
+// Creates or replaces the named index template.
+func putIndexTemplate(ctx context.Context, client *opensearchapi.Client, name, body string) error {
+	status, respBody, err := performJSONRequest(ctx, client, "PUT", fmt.Sprintf("/_index_template/%s", name), []byte(body))
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", status, string(respBody))
+	}
+
+	return nil
+}
+
+// Returns the template body from state when OpenSearch still has it, ignoring fields the state doesn't set.
+// Settings are compared by their flat names, as OpenSearch nests them under "index" with string values.
+// An empty state (i.e. an import) gets the whole template.
+func readBackIndexTemplateBody(state string, remote json.RawMessage) (string, error) {
+	var actual map[string]any
+
+	if err := json.Unmarshal(remote, &actual); err != nil {
+		return "", err
+	}
+
+	if state == "" {
+		return readBackJSONSubset(state, actual)
+	}
+
+	var configured map[string]any
+
+	if err := json.Unmarshal([]byte(state), &configured); err != nil {
+		return "", err
+	}
+
+	configuredTemplate, _ := configured["template"].(map[string]any)
+	actualTemplate, _ := actual["template"].(map[string]any)
+
+	if configuredSettings, ok := configuredTemplate["settings"].(map[string]any); ok {
+		if actualSettings, ok := actualTemplate["settings"].(map[string]any); ok {
+			encoded, err := json.Marshal(configuredSettings)
+			if err != nil {
+				return "", err
+			}
+
+			settings, err := readBackIndexSettings(string(encoded), flattenIndexSettings(actualSettings))
+			if err != nil {
+				return "", err
+			}
+
+			var readBack map[string]any
+
+			if err := json.Unmarshal([]byte(settings), &readBack); err != nil {
+				return "", err
+			}
+
+			actualTemplate["settings"] = readBack
+		}
+	}
+
+	return readBackJSONSubset(state, actual)
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSimulateIndexTemplate(t *testing.T) {
+	body := `{"index_patterns":["logs-*"],"composed_of":["logs-mappings"],"priority":10}`
+
+	tests := []struct {
+		name            string
+		status          int
+		response        string
+		wantTemplate    string
+		wantOverlapping []string
+		wantErr         string
+	}{
+		{
+			name:            "resolved",
+			status:          http.StatusOK,
+			response:        `{"template":{"settings":{"index":{"number_of_shards":"1"}},"mappings":{"properties":{"message":{"type":"text"}}},"aliases":{}},"overlapping":[{"name":"logs-legacy","index_patterns":["logs-*"]}]}`,
+			wantTemplate:    `{"settings":{"index":{"number_of_shards":"1"}},"mappings":{"properties":{"message":{"type":"text"}}},"aliases":{}}`,
+			wantOverlapping: []string{"logs-legacy"},
+		},
+		{
+			name:     "conflicting component templates",
+			status:   http.StatusBadRequest,
+			response: `{"error":{"type":"illegal_argument_exception","reason":"composable template [logs] template after composition is invalid"},"status":400}`,
+			wantErr:  "template after composition is invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" || r.URL.Path != "/_index_template/_simulate/logs" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+
+				if got, _ := io.ReadAll(r.Body); string(got) != body {
+					t.Errorf("expected the template body to be simulated, got %s", got)
+				}
+
+				writeJSON(w, tt.status, tt.response)
+			})
+
+			simulated, err := simulateIndexTemplate(context.Background(), client, "logs", body)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if string(simulated.Template) != tt.wantTemplate {
+				t.Errorf("expected template %s, got %s", tt.wantTemplate, simulated.Template)
+			}
+
+			var overlapping []string
+			for _, overlap := range simulated.Overlapping {
+				overlapping = append(overlapping, overlap.Name)
+			}
+
+			if strings.Join(overlapping, ",") != strings.Join(tt.wantOverlapping, ",") {
+				t.Errorf("expected overlapping templates %v, got %v", tt.wantOverlapping, overlapping)
+			}
+		})
+	}
+}
@@ -0,0 +1,533 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+func TestIndexAliasActions(t *testing.T) {
+	read := IndexAliasModel{Name: types.StringValue("logs-read"), Filter: NewJSONBodyNull(), Routing: types.StringNull(), IsWriteIndex: types.BoolNull()}
+	write := IndexAliasModel{Name: types.StringValue("logs-write"), Filter: NewJSONBodyNull(), Routing: types.StringNull(), IsWriteIndex: types.BoolValue(true)}
+	stale := IndexAliasModel{Name: types.StringValue("logs-old"), Filter: NewJSONBodyNull(), Routing: types.StringNull(), IsWriteIndex: types.BoolNull()}
+
+	filtered := read
+	filtered.Filter = NewJSONBodyValue(`{"term": {"tenant": "a"}}`)
+
+	tests := []struct {
+		name    string
+		prior   []IndexAliasModel
+		planned []IndexAliasModel
+		want    string
+	}{
+		{
+			name:    "unchanged",
+			prior:   []IndexAliasModel{read, write},
+			planned: []IndexAliasModel{write, read},
+			want:    `null`,
+		},
+		{
+			name:    "added and removed out of band",
+			prior:   []IndexAliasModel{read, stale},
+			planned: []IndexAliasModel{read, write},
+			want:    `[{"remove":{"index":"logs-1","alias":"logs-old"}},{"add":{"index":"logs-1","alias":"logs-write","is_write_index":true}}]`,
+		},
+		{
+			name:    "changed filter",
+			prior:   []IndexAliasModel{read},
+			planned: []IndexAliasModel{filtered},
+			want:    `[{"add":{"index":"logs-1","alias":"logs-read","filter":{"term":{"tenant":"a"}}}}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(indexAliasActions("logs-1", tt.prior, tt.planned))
+			if err != nil {
+				t.Fatalf("could not encode actions: %s", err)
+			}
+
+			if equal, err := jsonEqualIgnoring(string(got), tt.want, nil); err != nil || !equal {
+				t.Errorf("expected actions %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestReadBackIndexAliases(t *testing.T) {
+	isWriteIndex := true
+
+	state := []IndexAliasModel{{
+		Name:         types.StringValue("logs-read"),
+		Filter:       NewJSONBodyValue(`{ "term": { "tenant": "a" } }`),
+		Routing:      types.StringNull(),
+		IsWriteIndex: types.BoolNull(),
+	}}
+
+	remote := map[string]skpropensearch.Alias{
+		"logs-read":  {Filter: json.RawMessage(`{"term":{"tenant":"a"}}`)},
+		"logs-write": {IsWriteIndex: &isWriteIndex},
+	}
+
+	got := readBackIndexAliases("logs-1", state, remote)
+
+	if len(got) != 2 {
+		t.Fatalf("expected both aliases, got %d", len(got))
+	}
+
+	if !got[0].Filter.Equal(state[0].Filter) {
+		t.Errorf("expected the state's form of an equal filter, got %s", got[0].Filter.ValueString())
+	}
+
+	if got[1].Name.ValueString() != "logs-write" || !got[1].IsWriteIndex.ValueBool() {
+		t.Errorf("expected the write alias added out of band, got %+v", got[1])
+	}
+}
+
+func TestIndexWaitForStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "reached",
+			status: http.StatusOK,
+			body:   `{"status":"green","timed_out":false}`,
+			want:   "green",
+		},
+		{
+			name:    "timed out",
+			status:  http.StatusRequestTimeout,
+			body:    `{"status":"red","timed_out":true}`,
+			wantErr: "the index is still red after 30s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/_cluster/health/logs-1" || r.URL.Query().Get("wait_for_status") != "green" || r.URL.Query().Get("timeout") != "30s" {
+					t.Errorf("unexpected request %s", r.URL)
+				}
+
+				writeJSON(w, tt.status, tt.body)
+			})
+
+			data := IndexModel{Name: types.StringValue("logs-1"), WaitForStatus: types.StringValue("green")}
+
+			err := data.waitForStatus(context.Background(), client)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if data.HealthStatus.ValueString() != tt.want {
+				t.Errorf("expected health status %q, got %s", tt.want, data.HealthStatus)
+			}
+		})
+	}
+}
+
+func TestPutClosedIndexSettings(t *testing.T) {
+	tests := []struct {
+		name           string
+		settingsStatus int
+		want           []string
+		wantErr        string
+	}{
+		{
+			name:           "updated",
+			settingsStatus: http.StatusOK,
+			want:           []string{"POST /logs-1/_close", "PUT /logs-1/_settings", "POST /logs-1/_open"},
+		},
+		{
+			name:           "rejected",
+			settingsStatus: http.StatusBadRequest,
+			want:           []string{"POST /logs-1/_close", "PUT /logs-1/_settings", "POST /logs-1/_open"},
+			wantErr:        "OpenSearch returned 400",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+
+				if r.URL.Path == "/logs-1/_settings" {
+					writeJSON(w, tt.settingsStatus, `{"acknowledged":true}`)
+					return
+				}
+
+				writeJSON(w, http.StatusOK, `{"acknowledged":true}`)
+			})
+
+			err := putClosedIndexSettings(context.Background(), client, "logs-1", map[string]any{"index.codec": "best_compression"})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if strings.Join(requests, ", ") != strings.Join(tt.want, ", ") {
+				t.Errorf("expected requests %v, got %v", tt.want, requests)
+			}
+		})
+	}
+}
+
+func TestIndexSettingRequiresClose(t *testing.T) {
+	tests := map[string]bool{
+		"codec":                    true,
+		"index.analysis.analyzer":  true,
+		"number_of_shards":         false,
+		"sort.field":               false,
+		"number_of_replicas":       false,
+		"index.knn.algo_param.m":   false,
+		"shard.check_on_startup":   true,
+		"routing.allocation.total": false,
+	}
+
+	for setting, want := range tests {
+		if got := skpropensearch.IndexSettingRequiresClose(setting); got != want {
+			t.Errorf("expected IndexSettingRequiresClose(%q) to be %t, got %t", setting, want, got)
+		}
+	}
+}
+
+func TestIndexSettingsReplaceModifier(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	(&IndexResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	tests := []struct {
+		name                   string
+		prior                  string
+		planned                string
+		closeForStaticSettings bool
+		wantReplace            bool
+		wantWarning            string
+	}{
+		{
+			name:    "dynamic setting",
+			prior:   `{"number_of_replicas":1}`,
+			planned: `{"number_of_replicas":2}`,
+		},
+		{
+			name:        "create only setting",
+			prior:       `{"number_of_routing_shards":30}`,
+			planned:     `{"number_of_routing_shards":60}`,
+			wantReplace: true,
+			wantWarning: "- number_of_routing_shards: The number of routing shards is fixed when an index is created, as it determines how documents are split across shards.",
+		},
+		{
+			name:        "removed create only setting",
+			prior:       `{"index":{"number_of_shards":3}}`,
+			planned:     `{}`,
+			wantReplace: true,
+			wantWarning: "- number_of_shards: The number of primary shards is fixed when an index is created",
+		},
+		{
+			name:        "static setting",
+			prior:       `{"codec":"default"}`,
+			planned:     `{"codec":"best_compression"}`,
+			wantReplace: true,
+			wantWarning: "- codec: The setting is static, so it can only be changed while the index is closed",
+		},
+		{
+			name:                   "static setting changed on the closed index",
+			prior:                  `{"codec":"default"}`,
+			planned:                `{"codec":"best_compression"}`,
+			closeForStaticSettings: true,
+			wantWarning:            "Changing these settings closes the index, so it can't be read or written until it is opened again:\n\n- codec",
+		},
+		{
+			name:                   "create only setting with the closed index",
+			prior:                  `{"number_of_shards":1}`,
+			planned:                `{"number_of_shards":2}`,
+			closeForStaticSettings: true,
+			wantReplace:            true,
+			wantWarning:            "- number_of_shards: The number of primary shards is fixed when an index is created",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+			attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+			for name, attributeType := range objectType.AttributeTypes {
+				attributes[name] = tftypes.NewValue(attributeType, nil)
+			}
+
+			attributes["close_for_static_settings"] = tftypes.NewValue(tftypes.Bool, tt.closeForStaticSettings)
+
+			req := planmodifier.StringRequest{
+				Path:        path.Root("settings"),
+				StateValue:  types.StringValue(tt.prior),
+				PlanValue:   types.StringValue(tt.planned),
+				ConfigValue: types.StringValue(tt.planned),
+				State:       tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)},
+				Plan:        tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)},
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+
+			indexSettingsReplaceModifier{}.PlanModifyString(ctx, req, resp)
+
+			if resp.RequiresReplace != tt.wantReplace {
+				t.Errorf("expected RequiresReplace to be %t, got %t", tt.wantReplace, resp.RequiresReplace)
+			}
+
+			warnings := resp.Diagnostics.Warnings()
+			if tt.wantWarning == "" {
+				if len(warnings) > 0 {
+					t.Errorf("expected no warnings, got %v", warnings)
+				}
+				return
+			}
+
+			if len(warnings) != 1 || !strings.Contains(warnings[0].Detail(), tt.wantWarning) {
+				t.Errorf("expected a warning containing %q, got %v", tt.wantWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestIndexSetStats(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/logs-1/_stats/docs,store" {
+			writeJSON(w, http.StatusNotFound, `{"error":{"type":"index_not_found_exception"},"status":404}`)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, `{"_all":{"primaries":{"docs":{"count":1200,"deleted":3},"store":{"size_in_bytes":52000}},"total":{"docs":{"count":2400,"deleted":6},"store":{"size_in_bytes":104000}}}}`)
+	})
+
+	data := IndexModel{Name: types.StringValue("logs-1")}
+
+	if err := data.setStats(context.Background(), client); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if data.DocsCount.ValueInt64() != 1200 {
+		t.Errorf("expected the primaries' document count 1200, got %s", data.DocsCount)
+	}
+
+	if data.StoreSizeBytes.ValueInt64() != 104000 {
+		t.Errorf("expected the total store size 104000, got %s", data.StoreSizeBytes)
+	}
+
+	missing := IndexModel{Name: types.StringValue("logs-2")}
+
+	if err := missing.setStats(context.Background(), client); !errors.Is(err, errIndexNotFound) {
+		t.Errorf("expected errIndexNotFound, got %v", err)
+	}
+}
+
+func TestIndexBlockChanges(t *testing.T) {
+	blocks := func(readOnly, readOnlyAllowDelete, write types.Bool) IndexModel {
+		return IndexModel{BlocksReadOnly: readOnly, BlocksReadOnlyAllowDelete: readOnlyAllowDelete, BlocksWrite: write}
+	}
+
+	null := types.BoolNull()
+
+	tests := []struct {
+		name     string
+		prior    IndexModel
+		planned  IndexModel
+		wantLift map[string]any
+		wantAdd  map[string]any
+	}{
+		{
+			name:     "unchanged",
+			prior:    blocks(types.BoolValue(true), null, types.BoolValue(false)),
+			planned:  blocks(types.BoolValue(true), null, types.BoolValue(false)),
+			wantLift: map[string]any{},
+			wantAdd:  map[string]any{},
+		},
+		{
+			name:     "block added",
+			prior:    blocks(types.BoolValue(false), null, null),
+			planned:  blocks(types.BoolValue(true), types.BoolValue(true), types.BoolValue(true)),
+			wantLift: map[string]any{},
+			wantAdd:  map[string]any{"index.blocks.read_only": true, "index.blocks.read_only_allow_delete": true, "index.blocks.write": true},
+		},
+		{
+			name:     "block lifted",
+			prior:    blocks(types.BoolValue(true), null, types.BoolValue(true)),
+			planned:  blocks(types.BoolValue(false), null, types.BoolValue(true)),
+			wantLift: map[string]any{"index.blocks.read_only": false},
+			wantAdd:  map[string]any{},
+		},
+		{
+			name:     "block no longer managed",
+			prior:    blocks(null, types.BoolValue(true), null),
+			planned:  blocks(null, null, null),
+			wantLift: map[string]any{"index.blocks.read_only_allow_delete": nil},
+			wantAdd:  map[string]any{},
+		},
+		{
+			name:     "blocks swapped",
+			prior:    blocks(types.BoolValue(true), null, types.BoolValue(false)),
+			planned:  blocks(types.BoolValue(false), null, types.BoolValue(true)),
+			wantLift: map[string]any{"index.blocks.read_only": false},
+			wantAdd:  map[string]any{"index.blocks.write": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lift, add := indexBlockChanges(tt.prior, tt.planned)
+
+			if !reflect.DeepEqual(lift, tt.wantLift) {
+				t.Errorf("expected to lift %v, got %v", tt.wantLift, lift)
+			}
+
+			if !reflect.DeepEqual(add, tt.wantAdd) {
+				t.Errorf("expected to add %v, got %v", tt.wantAdd, add)
+			}
+		})
+	}
+}
+
+func TestIndexReadBackBlocks(t *testing.T) {
+	data := IndexModel{
+		BlocksReadOnly:            types.BoolValue(false),
+		BlocksReadOnlyAllowDelete: types.BoolValue(false),
+		BlocksWrite:               types.BoolNull(),
+	}
+
+	// OpenSearch set read_only_allow_delete when the disk filled up, and write outside of Terraform.
+	data.readBackBlocks(map[string]string{
+		"blocks.read_only":              "true",
+		"blocks.read_only_allow_delete": "true",
+		"blocks.write":                  "true",
+	})
+
+	if !data.BlocksReadOnly.Equal(types.BoolValue(true)) {
+		t.Errorf("expected the read only block to be read back, got %s", data.BlocksReadOnly)
+	}
+
+	if !data.BlocksReadOnlyAllowDelete.Equal(types.BoolValue(false)) {
+		t.Errorf("expected the read only allow delete block set by OpenSearch to be ignored, got %s", data.BlocksReadOnlyAllowDelete)
+	}
+
+	if !data.BlocksWrite.IsNull() {
+		t.Errorf("expected the unmanaged write block to stay null, got %s", data.BlocksWrite)
+	}
+}
+
+func TestAdoptIndex(t *testing.T) {
+	// The index was created implicitly by a template, with one replica and a refresh interval set by the template.
+	const existing = `{"logs-2024":{"aliases":{},"mappings":{"properties":{"message":{"type":"text"}}},` +
+		`"settings":{"index.number_of_shards":"1","index.number_of_replicas":"0","index.refresh_interval":"30s"},` +
+		`"defaults":{"index.codec":"default"}}}`
+
+	tests := []struct {
+		name         string
+		settings     string
+		mappings     JSONBody
+		wantSettings string
+		wantMappings string
+		wantErr      string
+	}{
+		{
+			name:         "dynamic settings reconciled",
+			settings:     `{"number_of_shards":1,"number_of_replicas":1,"refresh_interval":"30s"}`,
+			mappings:     NewJSONBodyValue(`{"properties":{"level":{"type":"keyword"}}}`),
+			wantSettings: `{"index.number_of_replicas":"1"}`,
+			wantMappings: `{"properties":{"level":{"type":"keyword"}}}`,
+		},
+		{
+			name:     "already matches",
+			settings: `{"index":{"number_of_replicas":0,"codec":"default"}}`,
+			mappings: NewJSONBodyNull(),
+		},
+		{
+			name:     "static setting differs",
+			settings: `{"number_of_shards":3,"number_of_replicas":1}`,
+			mappings: NewJSONBodyNull(),
+			wantErr:  `number_of_shards is "1" rather than "3"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSettings, gotMappings string
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+
+				switch {
+				case r.Method == "GET" && r.URL.Path == "/logs-2024":
+					writeJSON(w, http.StatusOK, existing)
+				case r.Method == "PUT" && r.URL.Path == "/logs-2024/_settings":
+					gotSettings = string(body)
+					writeJSON(w, http.StatusOK, `{"acknowledged":true}`)
+				case r.Method == "PUT" && r.URL.Path == "/logs-2024/_mapping":
+					gotMappings = string(body)
+					writeJSON(w, http.StatusOK, `{"acknowledged":true}`)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+			})
+
+			data := IndexModel{
+				Name:     types.StringValue("logs-2024"),
+				Settings: NewJSONBodyValue(tt.settings),
+				Mappings: tt.mappings,
+			}
+
+			err := adoptIndex(context.Background(), client, data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+
+				if gotSettings != "" || gotMappings != "" {
+					t.Errorf("expected the index to be left untouched, got settings %q and mappings %q", gotSettings, gotMappings)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if gotSettings != tt.wantSettings {
+				t.Errorf("expected settings update %q, got %q", tt.wantSettings, gotSettings)
+			}
+
+			if gotMappings != tt.wantMappings {
+				t.Errorf("expected mappings update %q, got %q", tt.wantMappings, gotMappings)
+			}
+		})
+	}
+}
@@ -0,0 +1,309 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &IngestPipelineResource{}
+	_ resource.ResourceWithImportState = &IngestPipelineResource{}
+)
+
+// NewIngestPipelineResource is a helper function to simplify the provider implementation.
+func NewIngestPipelineResource() resource.Resource {
+	return &IngestPipelineResource{}
+}
+
+// IngestPipelineResource is the resource implementation.
+type IngestPipelineResource struct {
+	providerData *ProviderData
+}
+
+// IngestPipelineModel describes the Ingest Pipeline resource data model.
+type IngestPipelineModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Body JSONBody     `tfsdk:"body"`
+}
+
+// Metadata returns the resource type name.
+func (r *IngestPipelineResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_ingest_pipeline", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Ingest Pipeline resource.
+func (r *IngestPipelineResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an ingest pipeline, which runs processors on documents before they are indexed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name (ID) of the ingest pipeline.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name (ID) of the ingest pipeline.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "A JSON payload which defines the pipeline, e.g. its `description`, `processors` and `on_failure`. " +
+					"Only the fields given here are checked for drift.",
+				Required:   true,
+				CustomType: JSONBodyType{},
+				PlanModifiers: []planmodifier.String{
+					UseStateForSemanticallyEqualJSON(),
+				},
+				Validators: []validator.String{
+					JSONBodyFields("ingest pipeline", skpropensearch.IngestPipelineBodyFields),
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *IngestPipelineResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *IngestPipelineResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// Create creates the ingest pipeline.
+func (r *IngestPipelineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data IngestPipelineModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := putIngestPipeline(ctx, client, data.Name.ValueString(), data.Body.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating ingest pipeline",
+			fmt.Sprintf("Could not create ingest pipeline %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+
+	tflog.Trace(ctx, "created Ingest Pipeline resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read compares the ingest pipeline in OpenSearch with the state.
+func (r *IngestPipelineResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data IngestPipelineModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	status, body, err := performJSONRequest(ctx, client, "GET", fmt.Sprintf("/_ingest/pipeline/%s", name), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading ingest pipeline", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if status == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error reading ingest pipeline",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	// The response is keyed by pipeline name, as GET accepts wildcards.
+	var getResponse map[string]any
+
+	if err := json.Unmarshal(body, &getResponse); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing ingest pipeline response",
+			fmt.Sprintf("Could not parse ingest pipeline response: %s", err.Error()),
+		)
+		return
+	}
+
+	pipeline, ok := getResponse[name]
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	pipelineBody, err := readBackJSONSubset(data.Body.ValueString(), pipeline)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ingest pipeline",
+			fmt.Sprintf("Could not compare ingest pipeline %s: %s", name, err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+	data.Body = NewJSONBodyValue(pipelineBody)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update replaces the ingest pipeline in place, pipelines are mutable.
+func (r *IngestPipelineResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data IngestPipelineModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := putIngestPipeline(ctx, client, data.Name.ValueString(), data.Body.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating ingest pipeline",
+			fmt.Sprintf("Could not update ingest pipeline %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "updated Ingest Pipeline resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the ingest pipeline. Indexing into an index which uses it as its default pipeline fails until it is removed from the index settings.
+func (r *IngestPipelineResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data IngestPipelineModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "DELETE", fmt.Sprintf("/_ingest/pipeline/%s", data.Name.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting ingest pipeline", err.Error())
+		return
+	}
+
+	// Treat 404 as already deleted.
+	if status == http.StatusNotFound {
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error deleting ingest pipeline",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Ingest Pipeline resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+}
+
+// ImportState imports an ingest pipeline by its name, reading its whole body back from OpenSearch.
+func (r *IngestPipelineResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
+
+// Creates or replaces the named ingest pipeline.
+func putIngestPipeline(ctx context.Context, client *opensearchapi.Client, name, body string) error {
+	status, respBody, err := performJSONRequest(ctx, client, "PUT", fmt.Sprintf("/_ingest/pipeline/%s", name), []byte(body))
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", status, string(respBody))
+	}
+
+	return nil
+}
@@ -0,0 +1,460 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                 = &InternalUserResource{}
+	_ resource.ResourceWithImportState  = &InternalUserResource{}
+	_ resource.ResourceWithUpgradeState = &InternalUserResource{}
+)
+
+// NewInternalUserResource is a helper function to simplify the provider implementation.
+func NewInternalUserResource() resource.Resource {
+	return &InternalUserResource{}
+}
+
+// InternalUserResource is the resource implementation.
+type InternalUserResource struct {
+	providerData *ProviderData
+}
+
+// InternalUserModel describes the Internal User resource data model.
+type InternalUserModel struct {
+	ID                      types.String            `tfsdk:"id"`
+	Name                    types.String            `tfsdk:"name"`
+	Password                types.String            `tfsdk:"password"`
+	PasswordVersion         types.Int64             `tfsdk:"password_version"`
+	Description             types.String            `tfsdk:"description"`
+	BackendRoles            []types.String          `tfsdk:"backend_roles"`
+	Attributes              map[string]types.String `tfsdk:"attributes"`
+	OpendistroSecurityRoles []types.String          `tfsdk:"opendistro_security_roles"`
+}
+
+// Metadata returns the resource type name.
+func (r *InternalUserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_user", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Internal User resource.
+func (r *InternalUserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// Version 1 made the password write-only, see UpgradeState.
+		Version:             1,
+		MarkdownDescription: "Manages a user of the security plugin's internal user database. Reserved, static and hidden users, e.g. `admin`, can't be managed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the user.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the user.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password of the user. Write-only, so it is never stored in state, and OpenSearch only keeps a hash of it: " +
+					"changes made outside Terraform aren't detected. Change `password_version` to set the current password in place. " +
+					"Required when the user is created.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"password_version": schema.Int64Attribute{
+				MarkdownDescription: "Changing this sets the user's password to the current `password`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("password")),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the user.",
+				Optional:            true,
+			},
+			"backend_roles": schema.ListAttribute{
+				MarkdownDescription: "Backend roles of the user, which role mappings can map to roles.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"attributes": schema.MapAttribute{
+				MarkdownDescription: "Custom attributes of the user, which can be used in document level security queries and index patterns.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"opendistro_security_roles": schema.ListAttribute{
+				MarkdownDescription: "Roles assigned to the user directly, without a role mapping.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *InternalUserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *InternalUserResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// Create creates the user with its password.
+func (r *InternalUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data InternalUserModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write-only attributes are only available from the configuration.
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &data.Password)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Password.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Missing user password",
+			"A password is required to create a user.",
+		)
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := putInternalUser(ctx, client, data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating user",
+			fmt.Sprintf("Could not create user %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+	data.Password = types.StringNull()
+
+	tflog.Trace(ctx, "created Internal User resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read refreshes the user from OpenSearch. The password is never read back.
+func (r *InternalUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data InternalUserModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	user, found, err := getInternalUser(ctx, client, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading user", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = data.Name
+	data.Description = readBackOptionalString(data.Description, user.Description)
+	data.BackendRoles = readBackStrings(data.BackendRoles, user.BackendRoles)
+	data.Attributes = readBackStringMap(data.Attributes, user.Attributes)
+	data.OpendistroSecurityRoles = readBackStrings(data.OpendistroSecurityRoles, user.OpendistroSecurityRoles)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update replaces the user in place. The password is only set again when password_version changes,
+// otherwise OpenSearch keeps the current one.
+func (r *InternalUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, prior InternalUserModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.PasswordVersion.Equal(prior.PasswordVersion) {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &data.Password)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := putInternalUser(ctx, client, data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating user",
+			fmt.Sprintf("Could not update user %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.Password = types.StringNull()
+
+	tflog.Trace(ctx, "updated Internal User resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the user.
+func (r *InternalUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data InternalUserModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	existing, found, err := getInternalUser(ctx, client, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting user", err.Error())
+		return
+	}
+
+	// Nothing to delete.
+	if !found {
+		return
+	}
+
+	if err := securityObjectReadOnlyError("user", existing.SecurityObjectFlags); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting user",
+			fmt.Sprintf("Could not delete user %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "DELETE", securityAPIPath("internalusers", data.Name.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting user", err.Error())
+		return
+	}
+
+	// Treat 404 as already deleted.
+	if status == http.StatusNotFound {
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error deleting user",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Internal User resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+}
+
+// ImportState imports a user by its name. The password can't be read back, so it is set by the first apply which sets password_version.
+func (r *InternalUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
+
+// UpgradeState carries user state written by earlier versions of the provider forward.
+func (r *InternalUserResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 kept the password in state. It is dropped, and OpenSearch keeps the user's password
+		// until password_version is set.
+		0: {
+			PriorSchema: &internalUserSchemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior internalUserModelV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, &InternalUserModel{
+					ID:                      prior.ID,
+					Name:                    prior.Name,
+					Password:                types.StringNull(),
+					PasswordVersion:         types.Int64Null(),
+					Description:             prior.Description,
+					BackendRoles:            prior.BackendRoles,
+					Attributes:              prior.Attributes,
+					OpendistroSecurityRoles: prior.OpendistroSecurityRoles,
+				})...)
+			},
+		},
+	}
+}
+
+// Returns the user and whether it exists.
+func getInternalUser(ctx context.Context, client *opensearchapi.Client, name string) (skpropensearch.InternalUserGetItem, bool, error) {
+	var user skpropensearch.InternalUserGetItem
+
+	status, body, err := performJSONRequest(ctx, client, "GET", securityAPIPath("internalusers", name), nil)
+	if err != nil {
+		return user, false, err
+	}
+
+	if status == http.StatusNotFound {
+		return user, false, nil
+	}
+
+	if status < 200 || status >= 300 {
+		return user, false, fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	var getResponse skpropensearch.InternalUserGetResponse
+
+	if err := json.Unmarshal(body, &getResponse); err != nil {
+		return user, false, fmt.Errorf("could not parse user response: %w", err)
+	}
+
+	user, found := getResponse[name]
+
+	return user, found, nil
+}
+
+// Creates or replaces the user. A reserved, static or hidden user is never changed, see securityObjectReadOnlyError.
+// Errors never include the request body, which has the password.
+func putInternalUser(ctx context.Context, client *opensearchapi.Client, data InternalUserModel) error {
+	name := data.Name.ValueString()
+
+	existing, found, err := getInternalUser(ctx, client, name)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		if err := securityObjectReadOnlyError("user", existing.SecurityObjectFlags); err != nil {
+			return err
+		}
+	}
+
+	attributes := make(map[string]string, len(data.Attributes))
+	for key, value := range data.Attributes {
+		attributes[key] = value.ValueString()
+	}
+
+	// Without a password OpenSearch keeps the user's current one.
+	requestBody, err := json.Marshal(skpropensearch.InternalUser{
+		Password:                data.Password.ValueString(),
+		Description:             data.Description.ValueString(),
+		BackendRoles:            stringValues(data.BackendRoles),
+		Attributes:              attributes,
+		OpendistroSecurityRoles: stringValues(data.OpendistroSecurityRoles),
+	})
+	if err != nil {
+		return err
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "PUT", securityAPIPath("internalusers", name), requestBody)
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	return nil
+}
+
+// Returns the values OpenSearch reports for a map attribute. No values are null, unless the prior value was an empty map.
+func readBackStringMap(prior map[string]types.String, actual map[string]string) map[string]types.String {
+	if len(actual) == 0 && prior == nil {
+		return nil
+	}
+
+	result := make(map[string]types.String, len(actual))
+	for key, value := range actual {
+		result[key] = types.StringValue(value)
+	}
+
+	return result
+}
@@ -0,0 +1,434 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &ISMPolicyResource{}
+	_ resource.ResourceWithImportState = &ISMPolicyResource{}
+	_ resource.ResourceWithModifyPlan  = &ISMPolicyResource{}
+)
+
+// NewISMPolicyResource is a helper function to simplify the provider implementation.
+func NewISMPolicyResource() resource.Resource {
+	return &ISMPolicyResource{}
+}
+
+// ISMPolicyResource is the resource implementation.
+type ISMPolicyResource struct {
+	providerData *ProviderData
+}
+
+// ISMPolicyModel describes the ISM Policy resource data model.
+type ISMPolicyModel struct {
+	ID                  types.String `tfsdk:"id"`
+	PolicyID            types.String `tfsdk:"policy_id"`
+	Body                JSONBody     `tfsdk:"body"`
+	SeqNo               types.Int64  `tfsdk:"seq_no"`
+	PrimaryTerm         types.Int64  `tfsdk:"primary_term"`
+	CountManagedIndices types.Bool   `tfsdk:"count_managed_indices"`
+	ManagedIndexCount   types.Int64  `tfsdk:"managed_index_count"`
+}
+
+// Metadata returns the resource type name.
+func (r *ISMPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_ism_policy", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the ISM Policy resource.
+func (r *ISMPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an Index State Management (ISM) policy, which moves indices through states such as hot, warm and delete.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the policy.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"policy_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the policy.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "A JSON payload with the `policy`, e.g. its `description`, `default_state`, `states` and `ism_template`. " +
+					"Only the fields given here are checked for drift.",
+				Required:   true,
+				CustomType: JSONBodyType{},
+				PlanModifiers: []planmodifier.String{
+					UseStateForSemanticallyEqualJSON(),
+				},
+				Validators: []validator.String{
+					JSONBodyFields("ISM policy", skpropensearch.ISMPolicyBodyFields),
+				},
+			},
+			"seq_no": schema.Int64Attribute{
+				MarkdownDescription: "Sequence number of the policy, updates only apply when it is unchanged since the policy was last read.",
+				Computed:            true,
+			},
+			"primary_term": schema.Int64Attribute{
+				MarkdownDescription: "Primary term of the policy, updates only apply when it is unchanged since the policy was last read.",
+				Computed:            true,
+			},
+			"count_managed_indices": schema.BoolAttribute{
+				MarkdownDescription: "Count the indices the policy manages into `managed_index_count` whenever the policy is read, " +
+					"with one extra ISM explain request filtered by the policy. Defaults to `false`.",
+				Optional: true,
+			},
+			"managed_index_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of indices the policy manages, i.e. the ones changing it affects, when `count_managed_indices` is set. " +
+					"Requires OpenSearch 2.12 or later.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *ISMPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *ISMPolicyResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// ModifyPlan rejects the resource when the provider is configured for OpenSearch Serverless.
+func (r *ISMPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.providerData.serverlessDiagnostics("opensearch_ism_policy")...)
+}
+
+// Create creates the ISM policy.
+func (r *ISMPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ISMPolicyModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	policy, err := putISMPolicy(ctx, client, fmt.Sprintf("/_plugins/_ism/policies/%s", data.PolicyID.ValueString()), data.Body.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating ISM policy",
+			fmt.Sprintf("Could not create ISM policy %s: %s", data.PolicyID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.PolicyID
+	data.SeqNo = types.Int64Value(policy.SeqNo)
+	data.PrimaryTerm = types.Int64Value(policy.PrimaryTerm)
+
+	if err := data.setManagedIndexCount(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error counting managed indices",
+			fmt.Sprintf("Could not count the indices ISM policy %s manages: %s", data.PolicyID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "created ISM Policy resource", map[string]any{
+		"policy_id": data.PolicyID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read compares the ISM policy in OpenSearch with the state and refreshes its sequence number and primary term.
+func (r *ISMPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ISMPolicyModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "GET", fmt.Sprintf("/_plugins/_ism/policies/%s", data.PolicyID.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading ISM policy", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if status == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error reading ISM policy",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	var policy skpropensearch.ISMPolicyResponse
+
+	if err := json.Unmarshal(body, &policy); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing ISM policy response",
+			fmt.Sprintf("Could not parse ISM policy response: %s", err.Error()),
+		)
+		return
+	}
+
+	policyBody, err := readBackISMPolicyBody(data.Body.ValueString(), policy.Policy)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ISM policy",
+			fmt.Sprintf("Could not compare ISM policy %s: %s", data.PolicyID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.PolicyID
+	data.Body = NewJSONBodyValue(policyBody)
+	data.SeqNo = types.Int64Value(policy.SeqNo)
+	data.PrimaryTerm = types.Int64Value(policy.PrimaryTerm)
+
+	if err := data.setManagedIndexCount(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error counting managed indices",
+			fmt.Sprintf("Could not count the indices ISM policy %s manages: %s", data.PolicyID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update replaces the ISM policy, only when it hasn't changed since it was last read.
+func (r *ISMPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ISMPolicyModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	updatePath := fmt.Sprintf("/_plugins/_ism/policies/%s?if_seq_no=%d&if_primary_term=%d",
+		data.PolicyID.ValueString(), state.SeqNo.ValueInt64(), state.PrimaryTerm.ValueInt64())
+
+	policy, err := putISMPolicy(ctx, client, updatePath, data.Body.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating ISM policy",
+			fmt.Sprintf("Could not update ISM policy %s: %s", data.PolicyID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.PolicyID
+	data.SeqNo = types.Int64Value(policy.SeqNo)
+	data.PrimaryTerm = types.Int64Value(policy.PrimaryTerm)
+
+	if err := data.setManagedIndexCount(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error counting managed indices",
+			fmt.Sprintf("Could not count the indices ISM policy %s manages: %s", data.PolicyID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "updated ISM Policy resource", map[string]any{
+		"policy_id": data.PolicyID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the ISM policy. Indices it manages keep their current state but are no longer managed.
+func (r *ISMPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ISMPolicyModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "DELETE", fmt.Sprintf("/_plugins/_ism/policies/%s", data.PolicyID.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting ISM policy", err.Error())
+		return
+	}
+
+	// Treat 404 as already deleted.
+	if status == http.StatusNotFound {
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error deleting ISM policy",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted ISM Policy resource", map[string]any{
+		"policy_id": data.PolicyID.ValueString(),
+	})
+}
+
+// ImportState imports an ISM policy by its ID, reading its whole body back from OpenSearch.
+func (r *ISMPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("policy_id"), req.ID)...)
+}
+
+// Sets the number of indices the policy manages when counting is enabled, null otherwise.
+func (m *ISMPolicyModel) setManagedIndexCount(ctx context.Context, client *opensearchapi.Client) error {
+	if !m.CountManagedIndices.ValueBool() {
+		m.ManagedIndexCount = types.Int64Null()
+		return nil
+	}
+
+	requestBody, err := json.Marshal(skpropensearch.ISMExplainRequest{
+		Filter: skpropensearch.ISMExplainFilter{PolicyID: m.PolicyID.ValueString()},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Only the total is needed, so a single index is explained however many the policy manages.
+	status, body, err := performJSONRequest(ctx, client, "POST", "/_plugins/_ism/explain?size=1", requestBody)
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	var explain skpropensearch.ISMExplainResponse
+
+	if err := json.Unmarshal(body, &explain); err != nil {
+		return fmt.Errorf("could not parse ISM explain response: %w", err)
+	}
+
+	m.ManagedIndexCount = types.Int64Value(explain.TotalManagedIndices)
+
+	return nil
+}
+
+// Creates or updates an ISM policy, returning its new sequence number and primary term.
+// A 409 means the policy changed since the sequence number in the path was read.
+func putISMPolicy(ctx context.Context, client *opensearchapi.Client, policyPath, body string) (skpropensearch.ISMPolicyResponse, error) {
+	var policy skpropensearch.ISMPolicyResponse
+
+	status, respBody, err := performJSONRequest(ctx, client, "PUT", policyPath, []byte(body))
+	if err != nil {
+		return policy, err
+	}
+
+	if status == http.StatusConflict {
+		return policy, fmt.Errorf("the policy was changed outside Terraform since it was last read, run terraform refresh and apply again: %s", string(respBody))
+	}
+
+	if status < 200 || status >= 300 {
+		return policy, fmt.Errorf("OpenSearch returned %d: %s", status, string(respBody))
+	}
+
+	if err := json.Unmarshal(respBody, &policy); err != nil {
+		return policy, fmt.Errorf("could not parse ISM policy response: %w", err)
+	}
+
+	return policy, nil
+}
+
+// Returns the policy body from state when OpenSearch still has it, ignoring fields the state doesn't set
+// (e.g. retry defaults OpenSearch adds to each action). An empty state (i.e. an import) gets the whole policy
+// without its server managed fields.
+func readBackISMPolicyBody(state string, remote json.RawMessage) (string, error) {
+	var policy map[string]any
+
+	if err := json.Unmarshal(remote, &policy); err != nil {
+		return "", err
+	}
+
+	for _, field := range skpropensearch.ISMPolicyServerManagedFields {
+		delete(policy, field)
+	}
+
+	return readBackJSONSubset(state, map[string]any{"policy": policy})
+}
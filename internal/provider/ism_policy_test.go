@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestISMPolicySetManagedIndexCount(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if r.Method != "POST" || r.URL.Path != "/_plugins/_ism/explain" || string(body) != `{"filter":{"policy_id":"hot-warm"}}` {
+			t.Errorf("unexpected request %s %s: %s", r.Method, r.URL, body)
+		}
+
+		writeJSON(w, http.StatusOK, `{"logs-1":{"index.plugins.index_state_management.policy_id":"hot-warm"},"total_managed_indices":42}`)
+	})
+
+	data := ISMPolicyModel{PolicyID: types.StringValue("hot-warm"), CountManagedIndices: types.BoolValue(true)}
+
+	if err := data.setManagedIndexCount(context.Background(), client); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if data.ManagedIndexCount.ValueInt64() != 42 {
+		t.Errorf("expected 42 managed indices, got %s", data.ManagedIndexCount)
+	}
+
+	data.CountManagedIndices = types.BoolNull()
+
+	if err := data.setManagedIndexCount(context.Background(), client); err != nil || !data.ManagedIndexCount.IsNull() {
+		t.Errorf("expected no count when disabled, got %s (%v)", data.ManagedIndexCount, err)
+	}
+}
@@ -0,0 +1,357 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the custom type and value fully satisfy framework interfaces.
+var (
+	_ basetypes.StringTypable                    = JSONBodyType{}
+	_ basetypes.StringValuableWithSemanticEquals = JSONBody{}
+	_ xattr.ValidateableAttribute                = JSONBody{}
+)
+
+// JSONBodyType is a string type holding a JSON document, used for the opaque request bodies.
+//
+// Values which only differ in formatting or key order are semantically equal, so the prior
+// value is kept and reformatting a body in configuration plans no change.
+type JSONBodyType struct {
+	basetypes.StringType
+}
+
+// String returns a human readable name for the type.
+func (t JSONBodyType) String() string {
+	return "JSONBodyType"
+}
+
+// ValueType returns the value type of this type.
+func (t JSONBodyType) ValueType(ctx context.Context) attr.Value {
+	return JSONBody{}
+}
+
+// Equal returns true if the given type is equivalent.
+func (t JSONBodyType) Equal(o attr.Type) bool {
+	other, ok := o.(JSONBodyType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+// ValueFromString converts a string value into a JSON body value.
+func (t JSONBodyType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return JSONBody{StringValue: in}, nil
+}
+
+// ValueFromTerraform converts a Terraform value into a JSON body value.
+func (t JSONBodyType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+
+	return stringValuable, nil
+}
+
+// JSONBody is a string value holding a JSON document.
+type JSONBody struct {
+	basetypes.StringValue
+}
+
+// NewJSONBodyValue returns a known JSON body value.
+func NewJSONBodyValue(value string) JSONBody {
+	return JSONBody{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewJSONBodyNull returns a null JSON body value.
+func NewJSONBodyNull() JSONBody {
+	return JSONBody{StringValue: basetypes.NewStringNull()}
+}
+
+// Type returns the type of the value.
+func (v JSONBody) Type(ctx context.Context) attr.Type {
+	return JSONBodyType{}
+}
+
+// Equal returns true if the given value is exactly equal, use StringSemanticEquals for JSON equality.
+func (v JSONBody) Equal(o attr.Value) bool {
+	other, ok := o.(JSONBody)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether both values decode to the same JSON document.
+func (v JSONBody) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(JSONBody)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T but got value type %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	equal, err := jsonEqualIgnoring(v.ValueString(), newValue.ValueString(), nil)
+	if err != nil {
+		// Invalid JSON is reported by ValidateAttribute, so fall back to comparing strings.
+		return v.ValueString() == newValue.ValueString(), diags
+	}
+
+	return equal, diags
+}
+
+// ValidateAttribute ensures the value is a valid JSON document.
+func (v JSONBody) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if !json.Valid([]byte(v.ValueString())) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON body",
+			"The value must be a valid JSON document, consider building it with jsonencode().",
+		)
+	}
+}
+
+// jsonBodySemanticEqualityModifier keeps the prior state when the planned JSON body only differs in formatting, key
+// order or at ignored paths, so those changes do not trigger RequiresReplace. It must be listed before RequiresReplace.
+type jsonBodySemanticEqualityModifier struct {
+	// Optional sibling list attribute holding JSON pointers which are excluded from the comparison.
+	ignorePathsAttribute string
+}
+
+// UseStateForSemanticallyEqualJSON returns a plan modifier which ignores formatting-only changes to a JSON body.
+func UseStateForSemanticallyEqualJSON() planmodifier.String {
+	return jsonBodySemanticEqualityModifier{}
+}
+
+// UseStateForSemanticallyEqualJSONIgnoring returns a plan modifier which ignores formatting-only changes to a JSON body,
+// as well as changes at the JSON pointers listed in the given sibling attribute.
+func UseStateForSemanticallyEqualJSONIgnoring(ignorePathsAttribute string) planmodifier.String {
+	return jsonBodySemanticEqualityModifier{ignorePathsAttribute: ignorePathsAttribute}
+}
+
+// Description returns a plain text description of the modifier's behavior.
+func (m jsonBodySemanticEqualityModifier) Description(ctx context.Context) string {
+	return "Keeps the prior value when the planned JSON is semantically equal."
+}
+
+// MarkdownDescription returns a markdown formatted description of the modifier's behavior.
+func (m jsonBodySemanticEqualityModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString replaces the planned value with the prior state when both are the same JSON document.
+func (m jsonBodySemanticEqualityModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	var ignorePaths []string
+
+	if m.ignorePathsAttribute != "" {
+		var ignore types.List
+
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(m.ignorePathsAttribute), &ignore)...)
+		if resp.Diagnostics.HasError() || ignore.IsUnknown() {
+			return
+		}
+
+		resp.Diagnostics.Append(ignore.ElementsAs(ctx, &ignorePaths, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	equal, err := jsonEqualIgnoring(req.StateValue.ValueString(), req.PlanValue.ValueString(), ignorePaths)
+	if err != nil || !equal {
+		return
+	}
+
+	resp.PlanValue = req.StateValue
+}
+
+// Reports whether two JSON documents are equal, ignoring formatting, key order and the values at the
+// given JSON pointers (RFC 6901), e.g. "/created_time" or "/actions/0/headers".
+func jsonEqualIgnoring(a, b string, ignorePaths []string) (bool, error) {
+	var aValue, bValue any
+
+	if err := json.Unmarshal([]byte(a), &aValue); err != nil {
+		return false, err
+	}
+
+	if err := json.Unmarshal([]byte(b), &bValue); err != nil {
+		return false, err
+	}
+
+	for _, pointer := range ignorePaths {
+		aValue = removeJSONPointer(aValue, pointer)
+		bValue = removeJSONPointer(bValue, pointer)
+	}
+
+	return reflect.DeepEqual(aValue, bValue), nil
+}
+
+// Returns the document with the value at the JSON pointer removed. Pointers which don't resolve are ignored.
+func removeJSONPointer(doc any, pointer string) any {
+	if pointer == "" {
+		return nil
+	}
+
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+
+	parent := doc
+
+	for i, token := range tokens {
+		last := i == len(tokens)-1
+
+		switch node := parent.(type) {
+		case map[string]any:
+			if last {
+				delete(node, token)
+				return doc
+			}
+
+			parent = node[token]
+		case []any:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node) {
+				return doc
+			}
+
+			if last {
+				// Blank the element rather than removing it so later indexes keep pointing at the same values.
+				node[index] = nil
+				return doc
+			}
+
+			parent = node[index]
+		default:
+			return doc
+		}
+	}
+
+	return doc
+}
+
+// Returns the state when the actual document has everything the state sets, ignoring anything else the actual
+// document has (e.g. server managed fields and defaults), otherwise returns the parts of the actual document the
+// state covers so only real drift shows as a diff. An empty state (i.e. an import) gets the whole actual document.
+func readBackJSONSubset(state string, actual any) (string, error) {
+	if state == "" {
+		encoded, err := json.Marshal(actual)
+		if err != nil {
+			return "", err
+		}
+
+		return string(encoded), nil
+	}
+
+	var configured any
+
+	if err := json.Unmarshal([]byte(state), &configured); err != nil {
+		return "", err
+	}
+
+	projected, same := projectJSONSubset(configured, actual)
+	if same {
+		return state, nil
+	}
+
+	encoded, err := json.Marshal(projected)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+// Returns the actual value limited to the configured object keys, and whether it matches the configured value.
+// Arrays of the same length are compared element by element, so defaults OpenSearch adds to their objects are ignored.
+// Leaf values are compared as strings, as OpenSearch accepts e.g. "false" for false.
+func projectJSONSubset(configured, actual any) (any, bool) {
+	configuredArray, configuredIsArray := configured.([]any)
+	actualArray, actualIsArray := actual.([]any)
+
+	if configuredIsArray && actualIsArray && len(configuredArray) == len(actualArray) {
+		projected := make([]any, len(actualArray))
+		same := true
+
+		for i := range actualArray {
+			var sameElement bool
+
+			projected[i], sameElement = projectJSONSubset(configuredArray[i], actualArray[i])
+			if !sameElement {
+				same = false
+			}
+		}
+
+		return projected, same
+	}
+
+	configuredObject, configuredIsObject := configured.(map[string]any)
+	actualObject, actualIsObject := actual.(map[string]any)
+
+	if configuredIsObject != actualIsObject {
+		return actual, false
+	}
+
+	if !configuredIsObject {
+		return actual, indexSettingString(configured) == indexSettingString(actual)
+	}
+
+	projected := make(map[string]any, len(configuredObject))
+	same := true
+
+	for key, value := range configuredObject {
+		actualValue, ok := actualObject[key]
+		if !ok {
+			same = false
+			continue
+		}
+
+		projectedValue, sameValue := projectJSONSubset(value, actualValue)
+		projected[key] = projectedValue
+
+		if !sameValue {
+			same = false
+		}
+	}
+
+	return projected, same
+}
@@ -0,0 +1,63 @@
+package provider
+
+import "testing"
+
+func TestJSONEqualIgnoring(t *testing.T) {
+	configured := `{"name":"embeddings","actions":[{"method":"POST","headers":{"content-type":"application/json"}}]}`
+
+	tests := []struct {
+		name        string
+		remote      string
+		ignorePaths []string
+		want        bool
+	}{
+		{
+			name:   "server injected field",
+			remote: `{"name":"embeddings","created_time":1700000000000,"actions":[{"method":"POST","headers":{"content-type":"application/json"}}]}`,
+			want:   false,
+		},
+		{
+			name:        "server injected field ignored",
+			remote:      `{"name":"embeddings","created_time":1700000000000,"actions":[{"method":"POST","headers":{"content-type":"application/json"}}]}`,
+			ignorePaths: []string{"/created_time"},
+			want:        true,
+		},
+		{
+			name:        "nested server injected field ignored",
+			remote:      `{"name":"embeddings","actions":[{"method":"POST","headers":{"content-type":"application/json","x-amz-date":"20240101T000000Z"}}]}`,
+			ignorePaths: []string{"/actions/0/headers/x-amz-date"},
+			want:        true,
+		},
+		{
+			name:        "other changes are still compared",
+			remote:      `{"name":"other","created_time":1700000000000,"actions":[{"method":"POST","headers":{"content-type":"application/json"}}]}`,
+			ignorePaths: []string{"/created_time"},
+			want:        false,
+		},
+		{
+			name:        "escaped pointer",
+			remote:      `{"name":"embeddings","a/b":1,"actions":[{"method":"POST","headers":{"content-type":"application/json"}}]}`,
+			ignorePaths: []string{"/a~1b"},
+			want:        true,
+		},
+		{
+			name:        "pointer which doesn't resolve",
+			remote:      `{"name":"embeddings","actions":[{"method":"POST","headers":{"content-type":"application/json"}}]}`,
+			ignorePaths: []string{"/actions/5/headers", "/missing/field"},
+			want:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsonEqualIgnoring(configured, tt.remote, tt.ignorePaths)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected %t, got %t", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+var _ validator.String = jsonBodyFieldsValidator{}
+
+// jsonBodyFieldsValidator checks a JSON body has the fields OpenSearch requires, reporting each problem with
+// the JSON path of the field (e.g. actions[0].url) so it can be found in a large body.
+type jsonBodyFieldsValidator struct {
+	// What the body defines, e.g. "connector", used in diagnostics.
+	kind   string
+	fields []skpropensearch.BodyField
+}
+
+// JSONBodyFields returns a validator checking a JSON body against the described fields.
+func JSONBodyFields(kind string, fields []skpropensearch.BodyField) validator.String {
+	return jsonBodyFieldsValidator{
+		kind:   kind,
+		fields: fields,
+	}
+}
+
+// Description returns a plain text description of the validator's behavior.
+func (v jsonBodyFieldsValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("body must be a valid %s definition", v.kind)
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior.
+func (v jsonBodyFieldsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString reports every missing or mistyped field of the body.
+func (v jsonBodyFieldsValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var body any
+
+	// Invalid JSON is reported by the JSON body type itself.
+	if err := json.Unmarshal([]byte(req.ConfigValue.ValueString()), &body); err != nil {
+		return
+	}
+
+	object, ok := body.(map[string]any)
+	if !ok {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			fmt.Sprintf("Invalid %s body", v.kind),
+			"The body must be a JSON object.",
+		)
+		return
+	}
+
+	for _, problem := range validateBodyFields("", object, v.fields) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			fmt.Sprintf("Invalid %s body", v.kind),
+			problem,
+		)
+	}
+}
+
+// Returns a description of each field of the object which is missing or has the wrong type.
+func validateBodyFields(prefix string, object map[string]any, fields []skpropensearch.BodyField) []string {
+	var problems []string
+
+	for _, field := range fields {
+		fieldPath := field.Name
+		if prefix != "" {
+			fieldPath = prefix + "." + field.Name
+		}
+
+		value, ok := object[field.Name]
+		if !ok || value == nil {
+			if field.Required {
+				problems = append(problems, fmt.Sprintf("%s is required.", fieldPath))
+			}
+			continue
+		}
+
+		switch field.Type {
+		case skpropensearch.BodyFieldString:
+			if _, ok := value.(string); !ok {
+				problems = append(problems, fmt.Sprintf("%s must be a string.", fieldPath))
+			}
+		case skpropensearch.BodyFieldObject:
+			nested, ok := value.(map[string]any)
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s must be an object.", fieldPath))
+				continue
+			}
+
+			problems = append(problems, validateBodyFields(fieldPath, nested, field.Fields)...)
+		case skpropensearch.BodyFieldArray:
+			items, ok := value.([]any)
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s must be an array.", fieldPath))
+				continue
+			}
+
+			if field.Required && len(items) == 0 {
+				problems = append(problems, fmt.Sprintf("%s must not be empty.", fieldPath))
+			}
+
+			if len(field.Fields) == 0 {
+				continue
+			}
+
+			for i, item := range items {
+				itemPath := fmt.Sprintf("%s[%d]", fieldPath, i)
+
+				nested, ok := item.(map[string]any)
+				if !ok {
+					problems = append(problems, fmt.Sprintf("%s must be an object.", itemPath))
+					continue
+				}
+
+				problems = append(problems, validateBodyFields(itemPath, nested, field.Fields)...)
+			}
+		}
+	}
+
+	return problems
+}
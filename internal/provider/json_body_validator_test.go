@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+func TestJSONBodyFieldsValidator(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []skpropensearch.BodyField
+		body   string
+		want   []string
+	}{
+		{
+			name:   "valid connector",
+			fields: skpropensearch.ConnectorBodyFields,
+			body:   `{"name":"embeddings","protocol":"http","actions":[{"action_type":"predict","method":"POST","url":"https://example.com"}]}`,
+		},
+		{
+			name:   "missing top level fields",
+			fields: skpropensearch.ConnectorBodyFields,
+			body:   `{"description":"Embeddings"}`,
+			want:   []string{"name is required.", "protocol is required.", "actions is required."},
+		},
+		{
+			name:   "missing field of an array item",
+			fields: skpropensearch.ConnectorBodyFields,
+			body:   `{"name":"embeddings","protocol":"http","actions":[{"action_type":"predict","method":"POST","url":"https://example.com"},{"action_type":"predict","method":"POST"}]}`,
+			want:   []string{"actions[1].url is required."},
+		},
+		{
+			name:   "mistyped field of an array item",
+			fields: skpropensearch.ConnectorBodyFields,
+			body:   `{"name":"embeddings","protocol":"http","actions":[{"action_type":"predict","method":"POST","url":"https://example.com","headers":"none"}]}`,
+			want:   []string{"actions[0].headers must be an object."},
+		},
+		{
+			name:   "array item is not an object",
+			fields: skpropensearch.ConnectorBodyFields,
+			body:   `{"name":"embeddings","protocol":"http","actions":["predict"]}`,
+			want:   []string{"actions[0] must be an object."},
+		},
+		{
+			name:   "empty required array",
+			fields: skpropensearch.ConnectorBodyFields,
+			body:   `{"name":"embeddings","protocol":"http","actions":[]}`,
+			want:   []string{"actions must not be empty."},
+		},
+		{
+			name:   "nested object",
+			fields: skpropensearch.ModelRegisterBodyFields,
+			body:   `{"name":"embeddings","rate_limiter":{"limit":1}}`,
+			want:   []string{"rate_limiter.limit must be a string.", "rate_limiter.unit is required."},
+		},
+		{
+			name:   "array nested in objects",
+			fields: skpropensearch.ModelRegisterBodyFields,
+			body:   `{"name":"embeddings","connector":{"name":"embeddings","protocol":"http","actions":[{"action_type":"predict","method":"POST","url":80}]}}`,
+			want:   []string{"connector.actions[0].url must be a string."},
+		},
+		{
+			name:   "objects nested in an array",
+			fields: skpropensearch.ISMPolicyBodyFields,
+			body:   `{"policy":{"default_state":"hot","states":[{"name":"hot"},{"actions":[]}]}}`,
+			want:   []string{"policy.states[1].name is required."},
+		},
+		{
+			name:   "mistyped object",
+			fields: skpropensearch.SnapshotPolicyBodyFields,
+			body:   `{"creation":{"schedule":{}},"snapshot_config":"backups"}`,
+			want:   []string{"snapshot_config must be an object."},
+		},
+		{
+			name:   "not an object",
+			fields: skpropensearch.ConnectorBodyFields,
+			body:   `["embeddings"]`,
+			want:   []string{"The body must be a JSON object."},
+		},
+		{
+			name:   "invalid JSON",
+			fields: skpropensearch.ConnectorBodyFields,
+			body:   `{"name":`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("body"),
+				ConfigValue: types.StringValue(tt.body),
+			}
+			resp := &validator.StringResponse{}
+
+			JSONBodyFields("connector", tt.fields).ValidateString(context.Background(), req, resp)
+
+			var got []string
+			for _, diag := range resp.Diagnostics {
+				got = append(got, diag.Detail())
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected problems %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &MLStatsDataSource{}
+
+// NewMLStatsDataSource is a helper function to simplify the provider implementation.
+func NewMLStatsDataSource() datasource.DataSource {
+	return &MLStatsDataSource{}
+}
+
+// MLStatsDataSource is the data source implementation.
+type MLStatsDataSource struct {
+	providerData *ProviderData
+}
+
+// MLStatsModel describes the ML Stats data source data model.
+type MLStatsModel struct {
+	ModelCount           types.Int64        `tfsdk:"model_count"`
+	ConnectorCount       types.Int64        `tfsdk:"connector_count"`
+	ModelIndexStatus     types.String       `tfsdk:"model_index_status"`
+	ConnectorIndexStatus types.String       `tfsdk:"connector_index_status"`
+	TaskIndexStatus      types.String       `tfsdk:"task_index_status"`
+	ConfigIndexStatus    types.String       `tfsdk:"config_index_status"`
+	Nodes                []MLNodeStatsModel `tfsdk:"nodes"`
+}
+
+// MLNodeStatsModel describes the ML stats of a single node.
+type MLNodeStatsModel struct {
+	NodeID                     types.String  `tfsdk:"node_id"`
+	RequestCount               types.Int64   `tfsdk:"request_count"`
+	FailureCount               types.Int64   `tfsdk:"failure_count"`
+	ExecutingTaskCount         types.Int64   `tfsdk:"executing_task_count"`
+	DeployedModelCount         types.Int64   `tfsdk:"deployed_model_count"`
+	CircuitBreakerTriggerCount types.Int64   `tfsdk:"circuit_breaker_trigger_count"`
+	JVMHeapUsage               types.Float64 `tfsdk:"jvm_heap_usage"`
+}
+
+// Metadata returns the data source type name.
+func (d *MLStatsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_ml_stats", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the ML Stats data source.
+func (d *MLStatsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Cluster and per node ML Commons statistics.",
+
+		Attributes: map[string]schema.Attribute{
+			"model_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of models registered in the cluster.",
+				Computed:            true,
+			},
+			"connector_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of connectors in the cluster.",
+				Computed:            true,
+			},
+			"model_index_status": schema.StringAttribute{
+				MarkdownDescription: "Health status of the ML model index.",
+				Computed:            true,
+			},
+			"connector_index_status": schema.StringAttribute{
+				MarkdownDescription: "Health status of the ML connector index.",
+				Computed:            true,
+			},
+			"task_index_status": schema.StringAttribute{
+				MarkdownDescription: "Health status of the ML task index.",
+				Computed:            true,
+			},
+			"config_index_status": schema.StringAttribute{
+				MarkdownDescription: "Health status of the ML config index.",
+				Computed:            true,
+			},
+			"nodes": schema.ListNestedAttribute{
+				MarkdownDescription: "ML statistics of each node, ordered by node ID.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"node_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the node.",
+							Computed:            true,
+						},
+						"request_count": schema.Int64Attribute{
+							MarkdownDescription: "Number of ML requests handled by the node.",
+							Computed:            true,
+						},
+						"failure_count": schema.Int64Attribute{
+							MarkdownDescription: "Number of failed ML requests on the node.",
+							Computed:            true,
+						},
+						"executing_task_count": schema.Int64Attribute{
+							MarkdownDescription: "Number of ML tasks currently executing on the node.",
+							Computed:            true,
+						},
+						"deployed_model_count": schema.Int64Attribute{
+							MarkdownDescription: "Number of models deployed to the node.",
+							Computed:            true,
+						},
+						"circuit_breaker_trigger_count": schema.Int64Attribute{
+							MarkdownDescription: "Number of times the ML circuit breaker has tripped on the node.",
+							Computed:            true,
+						},
+						"jvm_heap_usage": schema.Float64Attribute{
+							MarkdownDescription: "JVM heap usage percentage of the node.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (d *MLStatsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (d *MLStatsDataSource) client() (*opensearchapi.Client, error) {
+	return d.providerData.client()
+}
+
+// Read the ML stats from OpenSearch.
+func (d *MLStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data MLStatsModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	getReq, err := http.NewRequestWithContext(ctx, "GET", "/_plugins/_ml/stats", nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating ML stats request", err.Error())
+		return
+	}
+
+	getReq.Header.Set("Content-Type", "application/json")
+	getReq.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(getReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading ML stats", err.Error())
+		return
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading ML stats response", err.Error())
+		return
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		resp.Diagnostics.AddError(
+			"Error reading ML stats",
+			fmt.Sprintf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body)),
+		)
+		return
+	}
+
+	var stats skpropensearch.MLStatsResponse
+
+	if err := json.Unmarshal(body, &stats); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing ML stats response",
+			fmt.Sprintf("Could not parse ML stats response: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ModelCount = types.Int64Value(stats.MLModelCount)
+	data.ConnectorCount = types.Int64Value(stats.MLConnectorCount)
+	data.ModelIndexStatus = types.StringValue(stats.MLModelIndexStatus)
+	data.ConnectorIndexStatus = types.StringValue(stats.MLConnectorIndexStatus)
+	data.TaskIndexStatus = types.StringValue(stats.MLTaskIndexStatus)
+	data.ConfigIndexStatus = types.StringValue(stats.MLConfigIndexStatus)
+
+	// Sorted so the list doesn't reorder between reads.
+	nodeIDs := make([]string, 0, len(stats.Nodes))
+	for nodeID := range stats.Nodes {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+
+	sort.Strings(nodeIDs)
+
+	data.Nodes = make([]MLNodeStatsModel, 0, len(nodeIDs))
+
+	for _, nodeID := range nodeIDs {
+		node := stats.Nodes[nodeID]
+
+		data.Nodes = append(data.Nodes, MLNodeStatsModel{
+			NodeID:                     types.StringValue(nodeID),
+			RequestCount:               types.Int64Value(node.MLRequestCount),
+			FailureCount:               types.Int64Value(node.MLFailureCount),
+			ExecutingTaskCount:         types.Int64Value(node.MLExecutingTaskCount),
+			DeployedModelCount:         types.Int64Value(node.MLDeployedModelCount),
+			CircuitBreakerTriggerCount: types.Int64Value(node.MLCircuitBreakerTriggerCount),
+			JVMHeapUsage:               types.Float64Value(node.MLJVMHeapUsage),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
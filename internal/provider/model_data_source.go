@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ModelDataSource{}
+
+// NewModelDataSource is a helper function to simplify the provider implementation.
+func NewModelDataSource() datasource.DataSource {
+	return &ModelDataSource{}
+}
+
+// ModelDataSource is the data source implementation.
+type ModelDataSource struct {
+	providerData *ProviderData
+}
+
+// ModelDataSourceModel describes the Model data source data model.
+type ModelDataSourceModel struct {
+	Name         types.String `tfsdk:"name"`
+	ModelGroupID types.String `tfsdk:"model_group_id"`
+	MostRecent   types.Bool   `tfsdk:"most_recent"`
+	ModelID      types.String `tfsdk:"model_id"`
+	ModelState   types.String `tfsdk:"model_state"`
+}
+
+// Metadata returns the data source type name.
+func (d *ModelDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_model", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Model data source.
+func (d *ModelDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a registered model by name, e.g. to use a model registered in another workspace.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Exact name of the model.",
+				Required:            true,
+			},
+			"model_group_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the model group the model belongs to. Set it to tell apart models with the same name in different groups.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"most_recent": schema.BoolAttribute{
+				MarkdownDescription: "Use the most recently registered model when several match, instead of failing.",
+				Optional:            true,
+			},
+			"model_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the model.",
+				Computed:            true,
+			},
+			"model_state": schema.StringAttribute{
+				MarkdownDescription: "State of the model, e.g. `REGISTERED` or `DEPLOYED`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (d *ModelDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (d *ModelDataSource) client() (*opensearchapi.Client, error) {
+	return d.providerData.client()
+}
+
+// Read searches for the model by name.
+func (d *ModelDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ModelDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	var filters map[string]string
+	if !data.ModelGroupID.IsNull() {
+		filters = map[string]string{"model_group_id": data.ModelGroupID.ValueString()}
+	}
+
+	hits, err := searchMLByName(ctx, client, "/_plugins/_ml/models/_search", data.Name.ValueString(), filters, data.MostRecent.ValueBool())
+	if err != nil {
+		addSearchError(&resp.Diagnostics, "model", data.Name.ValueString(), err)
+		return
+	}
+
+	hit, err := singleSearchHit(hits, data.MostRecent.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error looking up model",
+			fmt.Sprintf("Could not look up model %q: %s. Set model_group_id or most_recent to choose between models with the same name.", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	var model skpropensearch.ModelGetResponse
+
+	if err := json.Unmarshal(hit.Source, &model); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing model",
+			fmt.Sprintf("Could not parse model %s: %s", hit.ID, err.Error()),
+		)
+		return
+	}
+
+	data.ModelID = types.StringValue(hit.ID)
+	data.ModelGroupID = types.StringValue(model.ModelGroupID)
+	data.ModelState = types.StringValue(model.ModelState)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Returns the only search hit, or the first when any is allowed (e.g. the hits are sorted newest first).
+func singleSearchHit(hits []skpropensearch.SearchHit, first bool) (skpropensearch.SearchHit, error) {
+	if len(hits) == 0 {
+		return skpropensearch.SearchHit{}, fmt.Errorf("no match found")
+	}
+
+	if len(hits) > 1 && !first {
+		ids := make([]string, 0, len(hits))
+		for _, hit := range hits {
+			ids = append(ids, hit.ID)
+		}
+
+		return skpropensearch.SearchHit{}, fmt.Errorf("%d matches found (%s)", len(hits), strings.Join(ids, ", "))
+	}
+
+	return hits[0], nil
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ModelDeployResource{}
+
+// NewModelDeployResource is a helper function to simplify the provider implementation.
+func NewModelDeployResource() resource.Resource {
+	return &ModelDeployResource{}
+}
+
+// ModelDeployResource is the resource implementation.
+type ModelDeployResource struct {
+	providerData *ProviderData
+}
+
+// ModelDeployModel describes the Model Deploy resource data model.
+type ModelDeployModel struct {
+	ModelID    types.String `tfsdk:"model_id"`
+	ModelState types.String `tfsdk:"model_state"`
+}
+
+// Metadata returns the data source type name.
+func (r *ModelDeployResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_model_deploy", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Model Deploy resource.
+func (r *ModelDeployResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Deploys a registered model, and undeploys it when destroyed. " +
+			"Use with a model registered without deploying it, so deployment can be managed separately.",
+
+		Attributes: map[string]schema.Attribute{
+			"model_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the registered model to deploy.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"model_state": schema.StringAttribute{
+				MarkdownDescription: "State of the model reported by OpenSearch, e.g. `DEPLOYED` or `PARTIALLY_DEPLOYED`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *ModelDeployResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *ModelDeployResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// Create deploys the model and waits for the deploy task to complete.
+func (r *ModelDeployResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ModelDeployModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	modelID := data.ModelID.ValueString()
+
+	if err := deployModel(ctx, client, modelID, nil, defaultModelPollInterval); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deploying model",
+			fmt.Sprintf("Could not deploy model %s: %s", modelID, err.Error()),
+		)
+		return
+	}
+
+	model, _, err := getModel(ctx, client, modelID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading model",
+			fmt.Sprintf("Could not read model %s after deploying it: %s", modelID, err.Error()),
+		)
+		return
+	}
+
+	data.ModelState = types.StringValue(model.ModelState)
+
+	tflog.Trace(ctx, "created Model Deploy resource", map[string]any{
+		"model_id": modelID,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read checks the model is still deployed.
+func (r *ModelDeployResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ModelDeployModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	model, exists, err := getModel(ctx, client, data.ModelID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading model", err.Error())
+		return
+	}
+
+	// A model which is gone or no longer deployed needs deploying again.
+	if !exists || (model.ModelState != skpropensearch.ModelStateDeployed && model.ModelState != skpropensearch.ModelStatePartiallyDeployed) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ModelState = types.StringValue(model.ModelState)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update is not supported; changing the model replaces the deployment.
+func (r *ModelDeployResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ModelDeployModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete undeploys the model, leaving it registered.
+func (r *ModelDeployResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ModelDeployModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := undeployModel(ctx, client, data.ModelID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error undeploying model",
+			fmt.Sprintf("Could not undeploy model %s: %s", data.ModelID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Model Deploy resource", map[string]any{
+		"model_id": data.ModelID.ValueString(),
+	})
+}
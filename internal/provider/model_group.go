@@ -7,11 +7,19 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
@@ -20,7 +28,11 @@ import (
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
-var _ resource.Resource = &ModelGroupResource{}
+var (
+	_ resource.Resource                 = &ModelGroupResource{}
+	_ resource.ResourceWithImportState  = &ModelGroupResource{}
+	_ resource.ResourceWithUpgradeState = &ModelGroupResource{}
+)
 
 // NewModelGroupResource is a helper function to simplify the provider implementation.
 func NewModelGroupResource() resource.Resource {
@@ -29,16 +41,36 @@ func NewModelGroupResource() resource.Resource {
 
 // ModelGroupResource is the resource implementation.
 type ModelGroupResource struct {
-	config opensearchapi.Config
+	providerData *ProviderData
 }
 
 // ModelGroupModel describes the Model Register resource data model.
 type ModelGroupModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
+	ID                   types.String `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	Description          types.String `tfsdk:"description"`
+	DeleteReferenceCheck types.String `tfsdk:"delete_reference_check"`
+	CascadeDelete        types.Bool   `tfsdk:"cascade_delete"`
+	Access               types.String `tfsdk:"access"`
+	Owner                types.Object `tfsdk:"owner"`
+	PopulateModels       types.Bool   `tfsdk:"populate_models"`
+	ModelIDs             types.List   `tfsdk:"model_ids"`
+}
+
+// Attribute types of the computed owner object.
+var modelGroupOwnerAttrTypes = map[string]attr.Type{
+	"name":          types.StringType,
+	"backend_roles": types.ListType{ElemType: types.StringType},
+	"roles":         types.ListType{ElemType: types.StringType},
 }
 
+const (
+	// Delete the model group without checking for references.
+	deleteReferenceCheckNone = "none"
+	// Refuse to delete the model group while models still belong to it.
+	deleteReferenceCheckModels = "models"
+)
+
 // Metadata returns the data source type name.
 func (r *ModelGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = fmt.Sprintf("%s_model_group", req.ProviderTypeName)
@@ -47,6 +79,9 @@ func (r *ModelGroupResource) Metadata(ctx context.Context, req resource.Metadata
 // Schema defines the schema for the Model Register resource.
 func (r *ModelGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// Version 1 added computed and defaulted attributes, see UpgradeState.
+		Version: 1,
+
 		MarkdownDescription: "Model group resource",
 
 		Attributes: map[string]schema.Attribute{
@@ -72,6 +107,62 @@ func (r *ModelGroupResource) Schema(ctx context.Context, req resource.SchemaRequ
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"delete_reference_check": schema.StringAttribute{
+				MarkdownDescription: "References to verify before deleting the model group. " +
+					"`none` (default) deletes immediately. " +
+					"`models` refuses to delete while any model still belongs to the group, which costs one extra model search per delete. " +
+					"Snapshots and ISM policies cannot reference a model group, so there is nothing further to check for them.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(deleteReferenceCheckNone),
+				Validators: []validator.String{
+					stringvalidator.OneOf(deleteReferenceCheckNone, deleteReferenceCheckModels),
+				},
+			},
+			"cascade_delete": schema.BoolAttribute{
+				MarkdownDescription: "Undeploy and delete every model in the group before deleting the group itself. Defaults to `false`.",
+				Optional:            true,
+			},
+			"populate_models": schema.BoolAttribute{
+				MarkdownDescription: "Whether to list the models in the group in `model_ids`, which costs one extra model search (more for groups over 1000 models) " +
+					"every time the model group is read. Defaults to `false`.",
+				Optional: true,
+			},
+			"model_ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the models in the group, in no particular order. Only populated when `populate_models` is enabled.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"access": schema.StringAttribute{
+				MarkdownDescription: "Access mode of the model group (`public`, `private` or `restricted`), when access control is enabled.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"owner": schema.SingleNestedAttribute{
+				MarkdownDescription: "User which created the model group, when the security plugin is enabled.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						MarkdownDescription: "Name of the user.",
+						Computed:            true,
+					},
+					"backend_roles": schema.ListAttribute{
+						MarkdownDescription: "Backend roles of the user.",
+						Computed:            true,
+						ElementType:         types.StringType,
+					},
+					"roles": schema.ListAttribute{
+						MarkdownDescription: "Roles of the user.",
+						Computed:            true,
+						ElementType:         types.StringType,
+					},
+				},
+			},
 		},
 	}
 }
@@ -82,22 +173,22 @@ func (r *ModelGroupResource) Configure(ctx context.Context, req resource.Configu
 		return
 	}
 
-	opensearchConfig, ok := req.ProviderData.(opensearchapi.Config)
+	providerData, ok := req.ProviderData.(*ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected opensearchapi.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.config = opensearchConfig
+	r.providerData = providerData
 }
 
-// Returns a configured OpenSearch client.
+// Returns the OpenSearch client shared by the provider.
 // https://github.com/opensearch-project/opensearch-go/blob/main/_samples/json.go
 func (r *ModelGroupResource) client() (*opensearchapi.Client, error) {
-	return opensearchapi.NewClient(r.config)
+	return r.providerData.client()
 }
 
 // Create registers a new model in OpenSearch.
@@ -181,6 +272,25 @@ func (r *ModelGroupResource) Create(ctx context.Context, req resource.CreateRequ
 
 	data.ID = types.StringValue(createResponse.ModelGroupID)
 
+	modelGroup, _, err := getModelGroup(ctx, client, createResponse.ModelGroupID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading model group",
+			fmt.Sprintf("Could not read model group %s after creating it: %s", createResponse.ModelGroupID, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(data.setAccess(ctx, modelGroup)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.setModelIDs(ctx, client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Trace(ctx, "created Model Group resource", map[string]any{
 		"model_group_id": createResponse.ModelGroupID,
 	})
@@ -188,6 +298,110 @@ func (r *ModelGroupResource) Create(ctx context.Context, req resource.CreateRequ
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// Sets model_ids to the models in the group when populate_models is enabled, otherwise null.
+func (m *ModelGroupModel) setModelIDs(ctx context.Context, client *opensearchapi.Client) diag.Diagnostics {
+	if !m.PopulateModels.ValueBool() {
+		m.ModelIDs = types.ListNull(types.StringType)
+		return nil
+	}
+
+	modelIDs, err := searchModelIDs(ctx, client, "model_group_id", m.ID.ValueString())
+	if err != nil {
+		var diags diag.Diagnostics
+
+		diags.AddError(
+			"Error listing models",
+			fmt.Sprintf("Could not search for the models in model group %s: %s", m.ID.ValueString(), err.Error()),
+		)
+
+		return diags
+	}
+
+	modelIDsValue, diags := types.ListValueFrom(ctx, types.StringType, modelIDs)
+	m.ModelIDs = modelIDsValue
+
+	return diags
+}
+
+// Reports whether the model group exists.
+func modelGroupExists(ctx context.Context, client *opensearchapi.Client, modelGroupID string) (bool, error) {
+	_, exists, err := getModelGroup(ctx, client, modelGroupID)
+
+	return exists, err
+}
+
+// Returns the model group and whether it exists.
+func getModelGroup(ctx context.Context, client *opensearchapi.Client, modelGroupID string) (skpropensearch.ModelGroupGetResponse, bool, error) {
+	var modelGroup skpropensearch.ModelGroupGetResponse
+
+	getReq, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/_plugins/_ml/model_groups/%s", modelGroupID), nil)
+	if err != nil {
+		return modelGroup, false, err
+	}
+
+	getReq.Header.Set("Content-Type", "application/json")
+	getReq.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(getReq)
+	if err != nil {
+		return modelGroup, false, err
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		return modelGroup, false, err
+	}
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return modelGroup, false, nil
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return modelGroup, false, fmt.Errorf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, &modelGroup); err != nil {
+		return modelGroup, false, fmt.Errorf("could not parse model group get response: %w", err)
+	}
+
+	return modelGroup, true, nil
+}
+
+// Sets the computed access mode and owner reported by OpenSearch.
+func (m *ModelGroupModel) setAccess(ctx context.Context, modelGroup skpropensearch.ModelGroupGetResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.Access = types.StringNull()
+	if modelGroup.Access != "" {
+		m.Access = types.StringValue(modelGroup.Access)
+	}
+
+	m.Owner = types.ObjectNull(modelGroupOwnerAttrTypes)
+	if modelGroup.Owner == nil {
+		return diags
+	}
+
+	backendRoles, d := types.ListValueFrom(ctx, types.StringType, modelGroup.Owner.BackendRoles)
+	diags.Append(d...)
+
+	roles, d := types.ListValueFrom(ctx, types.StringType, modelGroup.Owner.Roles)
+	diags.Append(d...)
+
+	if diags.HasError() {
+		return diags
+	}
+
+	m.Owner, d = types.ObjectValue(modelGroupOwnerAttrTypes, map[string]attr.Value{
+		"name":          types.StringValue(modelGroup.Owner.Name),
+		"backend_roles": backendRoles,
+		"roles":         roles,
+	})
+	diags.Append(d...)
+
+	return diags
+}
+
 // Read the resource state from OpenSearch for our model.
 func (r *ModelGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var data ModelGroupModel
@@ -212,46 +426,39 @@ func (r *ModelGroupResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	getReq, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/_plugins/_ml/model_groups/%s", data.ID.ValueString()), nil)
+	modelGroup, exists, err := getModelGroup(ctx, client, data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating model get request", err.Error())
-		return
-	}
-
-	getReq.Header.Set("Content-Type", "application/json")
-	getReq.Header.Set("Accept", "application/json")
-
-	httpResp, err := client.Client.Perform(getReq)
-	if err != nil {
-		resp.Diagnostics.AddError("Error reading model", err.Error())
+		resp.Diagnostics.AddError("Error reading model group", err.Error())
 		return
 	}
 
 	// If it’s gone, tell Terraform to drop it from state.
-	if httpResp.StatusCode == http.StatusNotFound {
+	if !exists {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	body, err := io.ReadAll(httpResp.Body)
-	_ = httpResp.Body.Close()
-	if err != nil {
-		resp.Diagnostics.AddError("Error reading model get response", err.Error())
+	data.Name = types.StringValue(modelGroup.Name)
+
+	// An unset description reads back empty, keep it null so it doesn't show as drift.
+	if modelGroup.Description != "" || !data.Description.IsNull() {
+		data.Description = types.StringValue(modelGroup.Description)
+	}
+
+	resp.Diagnostics.Append(data.setAccess(ctx, modelGroup)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
-		resp.Diagnostics.AddError(
-			"Error reading model",
-			fmt.Sprintf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body)),
-		)
+	resp.Diagnostics.Append(data.setModelIDs(ctx, client)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-// Update is not supported; registering a new model is the only way to change anything.
+// Update refreshes model_ids, as the name and description can only change by registering the group again.
 func (r *ModelGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data ModelGroupModel
 
@@ -260,9 +467,23 @@ func (r *ModelGroupResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	// All updatable fields are RequiresReplace, so Update should not be called for changes.
-	// Still, if called (e.g. drift-only), just persist planned state.
-	tflog.Trace(ctx, "updated Model Group resource (no-op update)", map[string]any{
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	// model_ids is unknown in the plan, e.g. when populate_models was just enabled.
+	resp.Diagnostics.Append(data.setModelIDs(ctx, client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The other attributes are RequiresReplace or only change what the provider does, so nothing is sent to OpenSearch.
+	tflog.Trace(ctx, "updated Model Group resource", map[string]any{
 		"model_group_id": data.ID.ValueString(),
 	})
 
@@ -292,6 +513,65 @@ func (r *ModelGroupResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
+	if data.CascadeDelete.ValueBool() {
+		modelIDs, err := searchModelIDs(ctx, client, "model_group_id", data.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error listing models in model group",
+				fmt.Sprintf("Could not search for models in model group %s: %s", data.ID.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		var failures []string
+
+		for _, modelID := range modelIDs {
+			if err := undeployModel(ctx, client, modelID); err != nil {
+				failures = append(failures, fmt.Sprintf("%s (undeploy: %s)", modelID, err.Error()))
+				continue
+			}
+
+			if err := deleteModel(ctx, client, modelID); err != nil {
+				failures = append(failures, fmt.Sprintf("%s (delete: %s)", modelID, err.Error()))
+				continue
+			}
+
+			tflog.Trace(ctx, "deleted model in Model Group resource", map[string]any{
+				"model_group_id": data.ID.ValueString(),
+				"model_id":       modelID,
+			})
+		}
+
+		if len(failures) > 0 {
+			resp.Diagnostics.AddError(
+				"Error deleting models in model group",
+				fmt.Sprintf("The model group %s was not deleted because %d of %d models could not be deleted:\n%s",
+					data.ID.ValueString(), len(failures), len(modelIDs), strings.Join(failures, "\n")),
+			)
+			return
+		}
+	}
+
+	if data.DeleteReferenceCheck.ValueString() == deleteReferenceCheckModels {
+		modelIDs, err := searchModelIDs(ctx, client, "model_group_id", data.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error checking model group references",
+				fmt.Sprintf("Could not search for models in model group %s: %s", data.ID.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		if len(modelIDs) > 0 {
+			resp.Diagnostics.AddError(
+				"Model group is still referenced",
+				fmt.Sprintf("Model group %s still contains models: %s. Delete these models first or set delete_reference_check to %q.",
+					data.ID.ValueString(), strings.Join(modelIDs, ", "), deleteReferenceCheckNone),
+			)
+			return
+		}
+	}
+
 	delReq, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("/_plugins/_ml/model_groups/%s", data.ID.ValueString()), nil)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating model group delete request", err.Error())
@@ -334,3 +614,50 @@ func (r *ModelGroupResource) Delete(ctx context.Context, req resource.DeleteRequ
 		"model_group_id": data.ID.ValueString(),
 	})
 }
+
+// ImportState imports the model group by ID, or by name using a `name:<value>` import ID.
+func (r *ModelGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	id, err := resolveImportID(ctx, client, "/_plugins/_ml/model_groups/_search", req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing model group",
+			fmt.Sprintf("Could not resolve model group import ID: %s", err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// UpgradeState carries model group state written by earlier versions of the provider forward.
+func (r *ModelGroupResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 only had the ID, name and description. The delete reference check keeps the version 0
+		// behaviour of not checking, and the other attributes added since are left null for the next read.
+		0: {
+			PriorSchema: &modelGroupSchemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior modelGroupModelV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), prior.ID)...)
+				resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), prior.Name)...)
+				resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("description"), prior.Description)...)
+				resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("delete_reference_check"), types.StringValue(deleteReferenceCheckNone))...)
+			},
+		},
+	}
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ModelGroupDataSource{}
+
+// NewModelGroupDataSource is a helper function to simplify the provider implementation.
+func NewModelGroupDataSource() datasource.DataSource {
+	return &ModelGroupDataSource{}
+}
+
+// ModelGroupDataSource is the data source implementation.
+type ModelGroupDataSource struct {
+	providerData *ProviderData
+}
+
+// ModelGroupDataSourceModel describes the Model Group data source data model.
+type ModelGroupDataSourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	ID          types.String `tfsdk:"id"`
+	Description types.String `tfsdk:"description"`
+}
+
+// Metadata returns the data source type name.
+func (d *ModelGroupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_model_group", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Model Group data source.
+func (d *ModelGroupDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up an existing model group by name, e.g. to register models into a group managed elsewhere.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Exact name of the model group.",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the model group.",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the model group.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (d *ModelGroupDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (d *ModelGroupDataSource) client() (*opensearchapi.Client, error) {
+	return d.providerData.client()
+}
+
+// Read searches for the model group by name.
+func (d *ModelGroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ModelGroupDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	hits, err := searchMLByName(ctx, client, "/_plugins/_ml/model_groups/_search", data.Name.ValueString(), nil, false)
+	if err != nil {
+		addSearchError(&resp.Diagnostics, "model group", data.Name.ValueString(), err)
+		return
+	}
+
+	hit, err := singleSearchHit(hits, false)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error looking up model group",
+			fmt.Sprintf("Could not look up model group %q: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	var group skpropensearch.ModelGroupGetResponse
+
+	if err := json.Unmarshal(hit.Source, &group); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing model group",
+			fmt.Sprintf("Could not parse model group %s: %s", hit.ID, err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(hit.ID)
+	data.Description = types.StringValue(group.Description)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -4,15 +4,37 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
@@ -21,7 +43,12 @@ import (
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
-var _ resource.Resource = &ModelRegisterResource{}
+var (
+	_ resource.Resource                   = &ModelRegisterResource{}
+	_ resource.ResourceWithValidateConfig = &ModelRegisterResource{}
+	_ resource.ResourceWithImportState    = &ModelRegisterResource{}
+	_ resource.ResourceWithUpgradeState   = &ModelRegisterResource{}
+)
 
 // NewModelRegisterResource is a helper function to simplify the provider implementation.
 func NewModelRegisterResource() resource.Resource {
@@ -30,15 +57,45 @@ func NewModelRegisterResource() resource.Resource {
 
 // ModelRegisterResource is the resource implementation.
 type ModelRegisterResource struct {
-	config opensearchapi.Config
+	providerData *ProviderData
 }
 
 // ModelRegisterModel describes the Model Register resource data model.
 type ModelRegisterModel struct {
-	ModelID types.String `tfsdk:"model_id"`
-	Body    types.String `tfsdk:"body"`
+	ModelID                 types.String   `tfsdk:"model_id"`
+	Body                    JSONBody       `tfsdk:"body"`
+	IgnoreBodyPaths         types.List     `tfsdk:"ignore_body_paths"`
+	ModelFormat             types.String   `tfsdk:"model_format"`
+	ModelConfig             types.String   `tfsdk:"model_config"`
+	DeployParameters        types.Map      `tfsdk:"deploy_parameters"`
+	Enabled                 types.Bool     `tfsdk:"enabled"`
+	Deploy                  types.Bool     `tfsdk:"deploy"`
+	ConnectorVersion        types.String   `tfsdk:"connector_version"`
+	GuardrailModelID        types.String   `tfsdk:"guardrail_model_id"`
+	ModelContentHash        types.String   `tfsdk:"model_content_hash"`
+	RateLimiter             types.Object   `tfsdk:"rate_limiter"`
+	DeployNodeCount         types.Int64    `tfsdk:"deploy_node_count"`
+	WorkerNodes             types.List     `tfsdk:"worker_nodes"`
+	DeployedNodeCount       types.Int64    `tfsdk:"deployed_node_count"`
+	RequireFullDeployment   types.Bool     `tfsdk:"require_full_deployment"`
+	ModelState              types.String   `tfsdk:"model_state"`
+	RollbackOnDeployFailure types.Bool     `tfsdk:"rollback_on_deploy_failure"`
+	PollInterval            types.String   `tfsdk:"poll_interval"`
+	Timeouts                timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Attribute types of the rate_limiter object.
+var modelRateLimiterAttrTypes = map[string]attr.Type{
+	"limit": types.Float64Type,
+	"unit":  types.StringType,
 }
 
+const (
+	defaultModelPollInterval  = 2 * time.Second
+	defaultModelCreateTimeout = 15 * time.Minute
+	defaultModelDeleteTimeout = 5 * time.Minute
+)
+
 // Metadata returns the data source type name.
 func (r *ModelRegisterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = fmt.Sprintf("%s_model_register", req.ProviderTypeName)
@@ -47,6 +104,9 @@ func (r *ModelRegisterResource) Metadata(ctx context.Context, req resource.Metad
 // Schema defines the schema for the Model Register resource.
 func (r *ModelRegisterResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// Version 1 added computed and defaulted attributes, see UpgradeState.
+		Version: 1,
+
 		MarkdownDescription: "Model registration resource",
 
 		Attributes: map[string]schema.Attribute{
@@ -58,39 +118,482 @@ func (r *ModelRegisterResource) Schema(ctx context.Context, req resource.SchemaR
 				},
 			},
 			"body": schema.StringAttribute{
-				MarkdownDescription: "A JSON payload which defines the model registration configuration.",
-				Required:            true,
+				MarkdownDescription: "A JSON payload which defines the model registration configuration. " +
+					"Changes made outside Terraform to `name`, `description`, `function_name`, `model_group_id`, `connector_id`, `connector` and `interface` show up as drift.",
+				Required:   true,
+				CustomType: JSONBodyType{},
+				Validators: []validator.String{
+					JSONBodyFields("model registration", skpropensearch.ModelRegisterBodyFields),
+				},
 				PlanModifiers: []planmodifier.String{
+					UseStateForSemanticallyEqualJSONIgnoring("ignore_body_paths"),
 					// Registering again is the only supported “update”.
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"ignore_body_paths": schema.ListAttribute{
+				MarkdownDescription: "JSON pointers (RFC 6901, e.g. `/description` or `/model_config/all_config`) excluded when comparing `body`, " +
+					"so server managed fields don't cause changes.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(regexp.MustCompile(`^/`), "must be a JSON pointer starting with \"/\""),
+					),
+				},
+			},
+			"model_format": schema.StringAttribute{
+				MarkdownDescription: "Format of a local or pretrained model (`TORCH_SCRIPT` or `ONNX`). Overrides `model_format` in `body` when set.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(skpropensearch.ModelFormatTorchScript, skpropensearch.ModelFormatONNX),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"model_config": schema.StringAttribute{
+				MarkdownDescription: "A JSON object describing the model configuration (e.g. `model_type`, `embedding_dimension`, `framework_type`). Overrides `model_config` in `body` when set.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"deploy_parameters": schema.MapAttribute{
+				MarkdownDescription: "Parameters sent in the body of the `_deploy` request. " +
+					"Values are decoded as JSON when possible (e.g. `node_ids = jsonencode([\"node-1\"])`) and sent as strings otherwise. " +
+					"When set, the model is registered first and then deployed with these parameters. " +
+					fmt.Sprintf("Known keys are validated: `%s`. ", strings.Join(skpropensearch.DeployParameterKeys, "`, `")) +
+					"Other keys are sent as they are with a warning, for parameters added by newer versions of ML Commons.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					// Deploying again is the only way to change deploy parameters.
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"deploy_node_count": schema.Int64Attribute{
+				MarkdownDescription: "Deploy the model to this many ML nodes, chosen automatically from the nodes with the `ml` role. " +
+					"Creation fails if fewer nodes end up running the model. Conflicts with `node_ids` in `deploy_parameters`.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"worker_nodes": schema.ListAttribute{
+				MarkdownDescription: "IDs of the nodes the model is deployed to.",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deployed_node_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of nodes the model is deployed to. After deploying, this is checked against the nodes " +
+					"OpenSearch planned to deploy the model to with `GET /_plugins/_ml/profile/models/<model_id>`.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"require_full_deployment": schema.BoolAttribute{
+				MarkdownDescription: "Fail creation when the model is deployed to only some of its worker nodes (`PARTIALLY_DEPLOYED`), " +
+					"usually because some nodes lack the memory for it. Set to `false` to only warn and keep the partially deployed model. " +
+					"Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"model_state": schema.StringAttribute{
+				MarkdownDescription: "State of the model reported by OpenSearch, e.g. `REGISTERED`, `DEPLOYED`, `PARTIALLY_DEPLOYED` or `DEPLOY_FAILED`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deploy": schema.BoolAttribute{
+				MarkdownDescription: "Whether to deploy the model once it is registered. Set to `false` to only register (stage) the model, " +
+					"e.g. to deploy it with `opensearch_model_deploy`. Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					// State from before deploy existed (or from an import) has no value, which isn't a change.
+					boolplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = !req.StateValue.IsNull()
+						},
+						"Changing deploy on an existing model requires replacement.",
+						"Changing `deploy` on an existing model requires replacement.",
+					),
+				},
+			},
+			"guardrail_model_id": schema.StringAttribute{
+				MarkdownDescription: "ID of a deployed model which checks the input and output of this model, " +
+					"e.g. `opensearch_model_register.guardrail.model_id`. It is set as the `model_id` of the `input_guardrail` and `output_guardrail` " +
+					"of the `guardrails` in `body` (both are added when neither is given), and `guardrails.type` defaults to `model`.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"model_content_hash": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 hash of the model artifact of a local model registered from a URL. It is sent as `model_content_hash_value`, " +
+					"and once registered the hash OpenSearch reports for the model is compared with it. " +
+					"On a mismatch (a corrupted or tampered artifact) the model is deleted and creation fails. Setting it for the first time doesn't replace the model.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[0-9a-fA-F]{64}$`), "must be a SHA-256 hash of 64 hexadecimal characters"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = !req.StateValue.IsNull()
+						},
+						"Changing the model content hash registers the model again.",
+						"Changing `model_content_hash` registers the model again.",
+					),
+				},
+			},
+			"rate_limiter": schema.SingleNestedAttribute{
+				MarkdownDescription: "Caps the predict requests the model accepts to `limit` per `unit`, e.g. to protect the quota of a remote model. " +
+					"It is sent as `rate_limiter` when the model is registered and updated in place afterwards. Removing it registers the model again.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"limit": schema.Float64Attribute{
+						MarkdownDescription: "Number of predict requests allowed per `unit`.",
+						Required:            true,
+						Validators: []validator.Float64{
+							float64validator.AtLeast(0),
+						},
+					},
+					"unit": schema.StringAttribute{
+						MarkdownDescription: "Time unit of the limit, one of `" + strings.Join(skpropensearch.RateLimiterUnits, "`, `") + "`.",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(skpropensearch.RateLimiterUnits...),
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					// OpenSearch has no way to remove a rate limiter from a model.
+					objectplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = req.PlanValue.IsNull() && !req.StateValue.IsNull()
+						},
+						"Removing the rate limiter registers the model again.",
+						"Removing `rate_limiter` registers the model again.",
+					),
+				},
+			},
+			"connector_version": schema.StringAttribute{
+				MarkdownDescription: "Any value describing the version of the connector the model uses, e.g. a hash of its configuration. " +
+					"Changing it registers and deploys the model again, so connector changes which keep its ID (new credentials or endpoints) reach the model. " +
+					"Setting it for the first time doesn't replace the model.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = !req.StateValue.IsNull()
+						},
+						"Changing the connector version registers the model again.",
+						"Changing `connector_version` registers the model again.",
+					),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the model accepts inference requests. Disabling a model keeps it registered and deployed. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"rollback_on_deploy_failure": schema.BoolAttribute{
+				MarkdownDescription: "Whether to delete the model when it registers but fails to deploy, or a later step such as disabling it fails, " +
+					"so a failed apply leaves nothing behind. " +
+					"Defaults to `false`, which keeps the registered model for debugging.",
+				Optional: true,
+			},
+			"poll_interval": schema.StringAttribute{
+				MarkdownDescription: "How often registration and deployment tasks are polled as a duration (e.g. `500ms`, `10s`). Defaults to `2s`.",
+				Optional:            true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
+// ValidateConfig checks the typed model attributes before anything is sent to OpenSearch.
+func (r *ModelRegisterResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ModelRegisterModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.PollInterval.IsNull() && !data.PollInterval.IsUnknown() {
+		if interval, err := time.ParseDuration(data.PollInterval.ValueString()); err != nil || interval <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("poll_interval"),
+				"Invalid poll interval",
+				fmt.Sprintf("The poll_interval %q must be a positive duration, e.g. \"2s\".", data.PollInterval.ValueString()),
+			)
+		}
+	}
+
+	if !data.Deploy.IsNull() && !data.Deploy.ValueBool() && data.explicitDeploy() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deploy"),
+			"Conflicting deploy configuration",
+			"deploy_parameters and deploy_node_count can't be set when deploy is false.",
+		)
+	}
+
+	if !data.DeployParameters.IsNull() && !data.DeployParameters.IsUnknown() {
+		var deployParameters map[string]types.String
+
+		resp.Diagnostics.Append(data.DeployParameters.ElementsAs(ctx, &deployParameters, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		keys := make([]string, 0, len(deployParameters))
+		for key := range deployParameters {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if !slices.Contains(skpropensearch.DeployParameterKeys, key) {
+				resp.Diagnostics.AddAttributeWarning(
+					path.Root("deploy_parameters").AtMapKey(key),
+					"Unknown deploy parameter",
+					fmt.Sprintf("The %s deploy parameter is not known to the provider, so it is sent to OpenSearch without validation.", key),
+				)
+			}
+		}
+
+		if _, ok := deployParameters["node_ids"]; ok && !data.DeployNodeCount.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("deploy_node_count"),
+				"Conflicting deploy configuration",
+				"Only one of deploy_node_count and the node_ids deploy parameter can be set.",
+			)
+		}
+
+		if nodeIDs, ok := deployParameters["node_ids"]; ok && !nodeIDs.IsUnknown() {
+			var ids []string
+
+			if err := json.Unmarshal([]byte(nodeIDs.ValueString()), &ids); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("deploy_parameters").AtMapKey("node_ids"),
+					"Invalid deploy parameter",
+					"The node_ids deploy parameter must be a JSON list of node IDs, e.g. jsonencode([\"node-1\"]).",
+				)
+			}
+		}
+	}
+
+	if !data.ModelContentHash.IsNull() && !data.ModelContentHash.IsUnknown() && !data.Body.IsUnknown() {
+		var body struct {
+			ModelContentHashValue string `json:"model_content_hash_value"`
+		}
+
+		if err := json.Unmarshal([]byte(data.Body.ValueString()), &body); err == nil &&
+			body.ModelContentHashValue != "" && !strings.EqualFold(body.ModelContentHashValue, data.ModelContentHash.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("model_content_hash"),
+				"Conflicting model content hash",
+				"The model_content_hash_value in body differs from model_content_hash, set only one of them.",
+			)
+		}
+	}
+
+	if !data.RateLimiter.IsNull() && !data.Body.IsUnknown() {
+		var body map[string]any
+
+		if err := json.Unmarshal([]byte(data.Body.ValueString()), &body); err == nil {
+			if _, ok := body["rate_limiter"]; ok {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("rate_limiter"),
+					"Conflicting rate limiter",
+					"The body has a rate_limiter as well as the rate_limiter attribute, set only one of them.",
+				)
+			}
+		}
+	}
+
+	if data.ModelConfig.IsNull() || data.ModelConfig.IsUnknown() {
+		return
+	}
+
+	var modelConfig map[string]any
+
+	if err := json.Unmarshal([]byte(data.ModelConfig.ValueString()), &modelConfig); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("model_config"),
+			"Invalid model config",
+			fmt.Sprintf("Could not parse model_config as a JSON object: %s", err.Error()),
+		)
+		return
+	}
+
+	for _, field := range skpropensearch.ModelConfigRequiredFields {
+		if _, ok := modelConfig[field]; !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("model_config"),
+				"Invalid model config",
+				fmt.Sprintf("The model_config object is missing the required field %q.", field),
+			)
+		}
+	}
+}
+
+// Returns the registration body with the typed model attributes merged over the raw body.
+func (m ModelRegisterModel) registerBody() ([]byte, error) {
+	if m.ModelFormat.IsNull() && m.ModelConfig.IsNull() && m.GuardrailModelID.IsNull() && m.ModelContentHash.IsNull() && m.RateLimiter.IsNull() {
+		return []byte(m.Body.ValueString()), nil
+	}
+
+	var body map[string]any
+
+	if err := json.Unmarshal([]byte(m.Body.ValueString()), &body); err != nil {
+		return nil, fmt.Errorf("could not parse body: %w", err)
+	}
+
+	if !m.ModelFormat.IsNull() {
+		body["model_format"] = m.ModelFormat.ValueString()
+	}
+
+	if !m.ModelConfig.IsNull() {
+		var modelConfig map[string]any
+
+		if err := json.Unmarshal([]byte(m.ModelConfig.ValueString()), &modelConfig); err != nil {
+			return nil, fmt.Errorf("could not parse model_config: %w", err)
+		}
+
+		body["model_config"] = modelConfig
+	}
+
+	if !m.ModelContentHash.IsNull() {
+		body["model_content_hash_value"] = strings.ToLower(m.ModelContentHash.ValueString())
+	}
+
+	if rateLimiter := m.rateLimiter(); rateLimiter != nil {
+		body["rate_limiter"] = rateLimiter
+	}
+
+	if !m.GuardrailModelID.IsNull() {
+		guardrails, ok := body["guardrails"].(map[string]any)
+		if !ok {
+			guardrails = map[string]any{}
+		}
+
+		if _, ok := guardrails["type"]; !ok {
+			guardrails["type"] = "model"
+		}
+
+		_, hasInput := guardrails["input_guardrail"]
+		_, hasOutput := guardrails["output_guardrail"]
+
+		for _, key := range []string{"input_guardrail", "output_guardrail"} {
+			guardrail, ok := guardrails[key].(map[string]any)
+			if !ok {
+				if hasInput || hasOutput {
+					continue
+				}
+
+				guardrail = map[string]any{}
+			}
+
+			guardrail["model_id"] = m.GuardrailModelID.ValueString()
+			guardrails[key] = guardrail
+		}
+
+		body["guardrails"] = guardrails
+	}
+
+	return json.Marshal(body)
+}
+
+// Returns how often ML tasks are polled, poll_interval is validated in ValidateConfig.
+func (m ModelRegisterModel) pollInterval() time.Duration {
+	interval, err := time.ParseDuration(m.PollInterval.ValueString())
+	if err != nil || interval <= 0 {
+		return defaultModelPollInterval
+	}
+
+	return interval
+}
+
+// Reports whether the model is deployed with an explicit _deploy call rather than ?deploy=true on register.
+func (m ModelRegisterModel) explicitDeploy() bool {
+	return !m.DeployParameters.IsNull() || !m.DeployNodeCount.IsNull()
+}
+
+// Returns the _deploy request body assembled from the deploy parameters and the chosen nodes, if any.
+func (m ModelRegisterModel) deployBody(ctx context.Context, nodeIDs []string) ([]byte, error) {
+	var deployParameters map[string]string
+
+	if !m.DeployParameters.IsNull() {
+		if diags := m.DeployParameters.ElementsAs(ctx, &deployParameters, false); diags.HasError() {
+			return nil, fmt.Errorf("could not read deploy_parameters")
+		}
+	}
+
+	body := make(map[string]any, len(deployParameters)+1)
+
+	if nodeIDs != nil {
+		body["node_ids"] = nodeIDs
+	}
+
+	for key, value := range deployParameters {
+		var decoded any
+
+		// Values are sent as JSON when they decode and as plain strings otherwise.
+		if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+			body[key] = value
+			continue
+		}
+
+		body[key] = decoded
+	}
+
+	return json.Marshal(body)
+}
+
 // Configure prepares the OpenSearch client for data sources and resources.
 func (r *ModelRegisterResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	opensearchConfig, ok := req.ProviderData.(opensearchapi.Config)
+	providerData, ok := req.ProviderData.(*ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected opensearchapi.Config, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.config = opensearchConfig
+	r.providerData = providerData
 }
 
-// Returns a configured OpenSearch client.
+// Returns the OpenSearch client shared by the provider.
 // https://github.com/opensearch-project/opensearch-go/blob/main/_samples/json.go
 func (r *ModelRegisterResource) client() (*opensearchapi.Client, error) {
-	return opensearchapi.NewClient(r.config)
+	return r.providerData.client()
 }
 
 // Create registers a new model in OpenSearch.
@@ -102,6 +605,15 @@ func (r *ModelRegisterResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultModelCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	client, err := r.client()
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -111,7 +623,75 @@ func (r *ModelRegisterResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	registerRequest, err := http.NewRequestWithContext(ctx, "POST", "/_plugins/_ml/models/_register?deploy=true", bytes.NewReader([]byte(data.Body.ValueString())))
+	registerBody, err := data.registerBody()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating model register request body",
+			fmt.Sprintf("Could not create model register request body: %s", err.Error()),
+		)
+		return
+	}
+
+	// Registering into a missing model group fails with a generic 400, so check the reference up front.
+	var reference skpropensearch.ModelRegisterReferences
+
+	if err := json.Unmarshal(registerBody, &reference); err == nil && reference.ModelGroupID != "" {
+		exists, err := modelGroupExists(ctx, client, reference.ModelGroupID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error checking model group",
+				fmt.Sprintf("Could not check model group %s exists: %s", reference.ModelGroupID, err.Error()),
+			)
+			return
+		}
+
+		if !exists {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("body"),
+				"Model group not found",
+				fmt.Sprintf("The model group %s referenced by model_group_id does not exist. If it is managed by an opensearch_model_group resource, check that resource was created successfully.", reference.ModelGroupID),
+			)
+			return
+		}
+	}
+
+	// A guardrail model which can't serve predictions would fail every prediction of this model.
+	if !data.GuardrailModelID.IsNull() {
+		guardrail, exists, err := getModel(ctx, client, data.GuardrailModelID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error checking guardrail model",
+				fmt.Sprintf("Could not check guardrail model %s exists: %s", data.GuardrailModelID.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		if !exists {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("guardrail_model_id"),
+				"Guardrail model not found",
+				fmt.Sprintf("The guardrail model %s does not exist.", data.GuardrailModelID.ValueString()),
+			)
+			return
+		}
+
+		if guardrail.ModelState != skpropensearch.ModelStateDeployed && guardrail.ModelState != skpropensearch.ModelStatePartiallyDeployed {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("guardrail_model_id"),
+				"Guardrail model not deployed",
+				fmt.Sprintf("The guardrail model %s is %s, it must be deployed before it can guard another model.", data.GuardrailModelID.ValueString(), guardrail.ModelState),
+			)
+			return
+		}
+	}
+
+	// Deploy parameters can only be supplied to an explicit _deploy call.
+	registerPath := "/_plugins/_ml/models/_register"
+	if data.Deploy.ValueBool() && !data.explicitDeploy() {
+		registerPath = "/_plugins/_ml/models/_register?deploy=true"
+	}
+
+	registerRequest, err := http.NewRequestWithContext(ctx, "POST", registerPath, bytes.NewReader(registerBody))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating model register request",
@@ -158,7 +738,7 @@ func (r *ModelRegisterResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	modelID, err := waitForMLTaskCompletion(ctx, client, registerResponse.TaskID)
+	modelID, err := waitForMLTaskCompletion(ctx, client, registerResponse.TaskID, data.pollInterval())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error waiting for model registration task",
@@ -174,144 +754,1042 @@ func (r *ModelRegisterResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	data.ModelID = types.StringValue(modelID)
-
-	tflog.Trace(ctx, "created Model Register resource", map[string]any{
-		"model_id": modelID,
-	})
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-}
-
-// Wait for the given ML task to complete, returning the model ID on success.
-func waitForMLTaskCompletion(ctx context.Context, client *opensearchapi.Client, taskID string) (string, error) {
-	const (
-		pollInterval = 2 * time.Second
-		timeout      = 15 * time.Minute
-	)
-
-	deadline := time.NewTimer(timeout)
-	defer deadline.Stop()
-
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return "", ctx.Err()
-		case <-deadline.C:
-			return "", fmt.Errorf("timed out after %s waiting for task %s", timeout.String(), taskID)
-		case <-ticker.C:
-			req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/_plugins/_ml/tasks/%s", taskID), nil)
-			if err != nil {
-				return "", err
-			}
+	if !data.ModelContentHash.IsNull() {
+		if err := verifyModelContentHash(ctx, client, modelID, data.ModelContentHash.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("model_content_hash"),
+				"Model content hash mismatch",
+				fmt.Sprintf("Model %s failed verification, so it is being deleted: %s", modelID, err.Error()),
+			)
+			discardModel(ctx, client, modelID, &resp.Diagnostics)
+			return
+		}
+	}
 
-			req.Header.Set("Content-Type", "application/json")
-			req.Header.Set("Accept", "application/json")
+	if data.Deploy.ValueBool() && data.explicitDeploy() {
+		var nodeIDs []string
 
-			httpResp, err := client.Client.Perform(req)
+		if !data.DeployNodeCount.IsNull() {
+			nodeIDs, err = mlNodeIDs(ctx, client, int(data.DeployNodeCount.ValueInt64()))
 			if err != nil {
-				return "", err
-			}
-
-			body, readErr := io.ReadAll(httpResp.Body)
-			_ = httpResp.Body.Close()
-
-			if readErr != nil {
-				return "", readErr
-			}
-
-			if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
-				return "", fmt.Errorf("OpenSearch returned %d while polling task: %s", httpResp.StatusCode, string(body))
-			}
-
-			var taskResp skpropensearch.TaskGetResponse
-
-			if err := json.Unmarshal(body, &taskResp); err != nil {
-				return "", err
+				resp.Diagnostics.AddError(
+					"Error choosing ML nodes",
+					fmt.Sprintf("Could not choose nodes to deploy model %s to: %s", modelID, err.Error()),
+				)
+				data.handleDeployFailure(ctx, client, modelID, &resp.Diagnostics)
+				return
 			}
+		}
 
-			if taskResp.State == skpropensearch.TaskStateCompleted {
-				if taskResp.ModelID != "" {
-					return taskResp.ModelID, nil
-				}
-
-				return "", fmt.Errorf("task completed but we could not find the model ID")
-			}
+		deployBody, err := data.deployBody(ctx, nodeIDs)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating model deploy request body",
+				fmt.Sprintf("Could not create model deploy request body: %s", err.Error()),
+			)
+			data.handleDeployFailure(ctx, client, modelID, &resp.Diagnostics)
+			return
+		}
 
-			if taskResp.State == skpropensearch.TaskStateFailed {
-				return "", fmt.Errorf("task %s failed: %s", taskID, string(body))
-			}
+		if err := deployModel(ctx, client, modelID, deployBody, data.pollInterval()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error deploying model",
+				fmt.Sprintf("Could not deploy model %s: %s", modelID, err.Error()),
+			)
+			data.handleDeployFailure(ctx, client, modelID, &resp.Diagnostics)
+			return
 		}
 	}
-}
-
-// Read the resource state from OpenSearch for our model.
-func (r *ModelRegisterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data ModelRegisterModel
 
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	var model skpropensearch.ModelGetResponse
 
-	// If we don’t have an ID, nothing to read.
-	if data.ModelID.IsNull() || data.ModelID.IsUnknown() || data.ModelID.ValueString() == "" {
-		resp.State.RemoveResource(ctx)
-		return
+	if data.Deploy.ValueBool() {
+		// The registration task can complete while the deployment it started is still running.
+		model, err = waitForModelDeployment(ctx, client, modelID, data.pollInterval())
+	} else {
+		model, _, err = getModel(ctx, client, modelID)
 	}
 
-	client, err := r.client()
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error creating OpenSearch client",
-			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+			"Error reading model",
+			fmt.Sprintf("Could not read model %s after registering it: %s", modelID, err.Error()),
 		)
+		data.handleDeployFailure(ctx, client, modelID, &resp.Diagnostics)
 		return
 	}
 
-	getReq, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/_plugins/_ml/models/%s", data.ModelID.ValueString()), nil)
-	if err != nil {
-		resp.Diagnostics.AddError("Error creating model get request", err.Error())
-		return
-	}
+	// A deployment which failed on every node still completes its task, but the model can't serve predictions.
+	if data.Deploy.ValueBool() && model.ModelState == skpropensearch.ModelStateDeployFailed {
+		detail := fmt.Sprintf("Model %s was registered but its deployment ended %s.", modelID, model.ModelState)
 
-	getReq.Header.Set("Content-Type", "application/json")
-	getReq.Header.Set("Accept", "application/json")
+		if failures := deployTaskError(ctx, client, modelID); failures != "" {
+			detail += fmt.Sprintf(" Node failures: %s", failures)
+		} else {
+			detail += " Check the ML nodes have enough memory for the model (GET /_plugins/_ml/stats)."
+		}
 
-	httpResp, err := client.Client.Perform(getReq)
-	if err != nil {
-		resp.Diagnostics.AddError("Error reading model", err.Error())
+		resp.Diagnostics.AddError("Error deploying model", detail)
+		data.handleDeployFailure(ctx, client, modelID, &resp.Diagnostics)
 		return
 	}
 
-	// If it’s gone, tell Terraform to drop it from state.
-	if httpResp.StatusCode == http.StatusNotFound {
-		resp.State.RemoveResource(ctx)
+	if !data.DeployNodeCount.IsNull() && model.CurrentWorkerNodeCount < data.DeployNodeCount.ValueInt64() {
+		resp.Diagnostics.AddError(
+			"Model was not deployed to every requested node",
+			fmt.Sprintf("Model %s is running on %d of the %d requested nodes (%s), check the ML nodes have enough memory for the model.",
+				modelID, model.CurrentWorkerNodeCount, data.DeployNodeCount.ValueInt64(), model.ModelState),
+		)
+		data.handleDeployFailure(ctx, client, modelID, &resp.Diagnostics)
 		return
 	}
 
-	body, err := io.ReadAll(httpResp.Body)
-	_ = httpResp.Body.Close()
-	if err != nil {
-		resp.Diagnostics.AddError("Error reading model get response", err.Error())
+	data.DeployedNodeCount = types.Int64Value(model.CurrentWorkerNodeCount)
+
+	if data.Deploy.ValueBool() {
+		data.verifyDeployment(ctx, client, modelID, model, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			data.handleDeployFailure(ctx, client, modelID, &resp.Diagnostics)
+			return
+		}
+	}
+
+	workerNodes, diags := types.ListValueFrom(ctx, types.StringType, model.PlanningWorkerNodes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.WorkerNodes = workerNodes
+	data.ModelState = types.StringValue(model.ModelState)
+
+	// Models are enabled when registered, so only disabling needs an extra call.
+	if !data.Enabled.ValueBool() {
+		if err := setModelEnabled(ctx, client, modelID, false); err != nil {
+			resp.Diagnostics.AddError(
+				"Error disabling model",
+				fmt.Sprintf("Could not disable model %s: %s", modelID, err.Error()),
+			)
+			data.handleDeployFailure(ctx, client, modelID, &resp.Diagnostics)
+			return
+		}
+	}
+
+	data.ModelID = types.StringValue(modelID)
+
+	tflog.Trace(ctx, "created Model Register resource", map[string]any{
+		"model_id": modelID,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Checks the model is deployed to each of its planned worker nodes with the profile API, which reports the nodes
+// actually running it, and sets deployed_node_count from it. A model which deployed to only some nodes, typically
+// because the others lack the memory for it, is an error unless require_full_deployment is turned off, when it is a warning.
+func (m *ModelRegisterModel) verifyDeployment(ctx context.Context, client *opensearchapi.Client, modelID string, model skpropensearch.ModelGetResponse, diags *diag.Diagnostics) {
+	report := diags.AddWarning
+	if m.RequireFullDeployment.ValueBool() {
+		report = diags.AddError
+	}
+
+	profile, err := getModelProfile(ctx, client, modelID)
+	if err != nil {
+		report(
+			"Error verifying model deployment",
+			fmt.Sprintf("Could not read the profile of model %s: %s", modelID, err.Error()),
+		)
+		return
+	}
+
+	deployedNodes := profile.DeployedNodes(modelID)
+	m.DeployedNodeCount = types.Int64Value(int64(len(deployedNodes)))
+
+	if model.ModelState != skpropensearch.ModelStatePartiallyDeployed && len(deployedNodes) >= len(model.PlanningWorkerNodes) {
+		return
+	}
+
+	var missingNodes []string
+	for _, nodeID := range model.PlanningWorkerNodes {
+		if !slices.Contains(deployedNodes, nodeID) {
+			missingNodes = append(missingNodes, nodeID)
+		}
+	}
+
+	detail := fmt.Sprintf("Model %s is deployed to %d of its %d worker nodes (%s).",
+		modelID, len(deployedNodes), len(model.PlanningWorkerNodes), model.ModelState)
+
+	if len(missingNodes) > 0 {
+		detail += fmt.Sprintf(" It is not running on %s.", strings.Join(missingNodes, ", "))
+	}
+
+	if failures := deployTaskError(ctx, client, modelID); failures != "" {
+		detail += fmt.Sprintf(" Node failures: %s", failures)
+	} else {
+		detail += " Check the ML nodes have enough memory for the model (GET /_plugins/_ml/stats)."
+	}
+
+	report("Model was not deployed to every worker node", detail)
+}
+
+// Returns the deployment profile of the model on each node.
+func getModelProfile(ctx context.Context, client *opensearchapi.Client, modelID string) (skpropensearch.ModelProfileResponse, error) {
+	var profile skpropensearch.ModelProfileResponse
+
+	status, body, err := performJSONRequest(ctx, client, "GET", fmt.Sprintf("/_plugins/_ml/profile/models/%s", modelID), nil)
+	if err != nil {
+		return profile, err
+	}
+
+	if status < 200 || status >= 300 {
+		return profile, fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	if err := json.Unmarshal(body, &profile); err != nil {
+		return profile, fmt.Errorf("could not parse model profile response: %w", err)
+	}
+
+	return profile, nil
+}
+
+// Deletes a model which registered but then failed to deploy, or failed a later step of creating it, when
+// rollback_on_deploy_failure is set. Otherwise points out the model which was left behind, as it is not in state
+// and the next apply registers another one.
+func (m ModelRegisterModel) handleDeployFailure(ctx context.Context, client *opensearchapi.Client, modelID string, diags *diag.Diagnostics) {
+	// The create timeout may be what failed the deployment, so rolling back isn't bound by it.
+	ctx = context.WithoutCancel(ctx)
+
+	if !m.RollbackOnDeployFailure.ValueBool() {
+		diags.AddWarning(
+			"Model left registered",
+			fmt.Sprintf("Model %s was registered but creating it failed, so it is not tracked in state. "+
+				"Delete it manually, or set rollback_on_deploy_failure to delete it automatically.", modelID),
+		)
+		return
+	}
+
+	// A partial deployment has to be undeployed before the model can be deleted.
+	if err := undeployModel(ctx, client, modelID); err != nil {
+		diags.AddError(
+			"Error rolling back model",
+			fmt.Sprintf("Could not undeploy model %s after creating it failed, it must be deleted manually: %s", modelID, err.Error()),
+		)
+		return
+	}
+
+	if err := deleteModel(ctx, client, modelID); err != nil {
+		diags.AddError(
+			"Error rolling back model",
+			fmt.Sprintf("Could not delete model %s after creating it failed, it must be deleted manually: %s", modelID, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "rolled back Model Register resource", map[string]any{
+		"model_id": modelID,
+	})
+}
+
+// Checks the content hash OpenSearch reports for a registered model matches the expected SHA-256 hash.
+func verifyModelContentHash(ctx context.Context, client *opensearchapi.Client, modelID, expected string) error {
+	model, exists, err := getModel(ctx, client, modelID)
+	if err != nil {
+		return fmt.Errorf("could not read model: %w", err)
+	}
+
+	if !exists {
+		return fmt.Errorf("the model no longer exists")
+	}
+
+	if model.ModelContentHashValue == "" {
+		return fmt.Errorf("OpenSearch did not report a content hash, only local models registered from a URL have one")
+	}
+
+	if !strings.EqualFold(model.ModelContentHashValue, expected) {
+		return fmt.Errorf("OpenSearch reports the content hash %s, expected %s, the model artifact may be corrupted or tampered with", model.ModelContentHashValue, expected)
+	}
+
+	return nil
+}
+
+// Undeploys and deletes a model which must not be kept, regardless of rollback_on_deploy_failure.
+func discardModel(ctx context.Context, client *opensearchapi.Client, modelID string, diags *diag.Diagnostics) {
+	ctx = context.WithoutCancel(ctx)
+
+	if err := undeployModel(ctx, client, modelID); err != nil {
+		diags.AddError(
+			"Error deleting model",
+			fmt.Sprintf("Could not undeploy model %s, it must be deleted manually: %s", modelID, err.Error()),
+		)
+		return
+	}
+
+	if err := deleteModel(ctx, client, modelID); err != nil {
+		diags.AddError(
+			"Error deleting model",
+			fmt.Sprintf("Could not delete model %s, it must be deleted manually: %s", modelID, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted unverified model", map[string]any{
+		"model_id": modelID,
+	})
+}
+
+// Wait for the given ML task to complete, returning the model ID on success. The wait ends with the context.
+func waitForMLTaskCompletion(ctx context.Context, client *opensearchapi.Client, taskID string, pollInterval time.Duration) (string, error) {
+	var (
+		start    = time.Now()
+		endpoint = fmt.Sprintf("/_plugins/_ml/tasks/%s", taskID)
+		// The last state reported by OpenSearch, used to explain where a slow task got stuck.
+		lastState = "unknown (no successful poll yet)"
+		// Learned from the task, used to check the model directly when the task API is unavailable.
+		modelID  string
+		taskType string
+	)
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return "", fmt.Errorf("timed out after %s waiting for task %s (GET %s), last known task state: %s. "+
+					"Increase the create timeout for large models, or check the ML nodes have enough capacity to run the task (GET /_plugins/_ml/stats)",
+					time.Since(start).Round(time.Second), taskID, endpoint, lastState)
+			}
+
+			return "", fmt.Errorf("%w after %s waiting for task %s (GET %s), last known task state: %s",
+				ctx.Err(), time.Since(start).Round(time.Second), taskID, endpoint, lastState)
+		case <-ticker.C:
+			task, err := getMLTask(ctx, client, endpoint)
+			if err != nil {
+				if modelID == "" {
+					return "", err
+				}
+
+				// The task API is flaky on some versions, so fall back to the state of the model it is working on.
+				done, err := modelReachedTaskState(ctx, client, modelID, taskType)
+				if err != nil {
+					return "", err
+				}
+
+				if done {
+					return modelID, nil
+				}
+
+				continue
+			}
+
+			lastState = task.State
+
+			if task.ModelID != "" {
+				modelID = task.ModelID
+			}
+
+			if task.TaskType != "" {
+				taskType = task.TaskType
+			}
+
+			if task.State == skpropensearch.TaskStateCompleted {
+				if modelID == "" {
+					return "", fmt.Errorf("task completed but we could not find the model ID")
+				}
+
+				// The task can complete before the model is visible on some versions, so confirm it exists.
+				_, exists, err := getModel(ctx, client, modelID)
+				if err != nil {
+					return "", err
+				}
+
+				if exists {
+					return modelID, nil
+				}
+
+				lastState = fmt.Sprintf("%s (waiting for model %s to become visible)", task.State, modelID)
+				continue
+			}
+
+			if task.State == skpropensearch.TaskStateFailed {
+				return "", fmt.Errorf("task %s failed: %s", taskID, task.Error)
+			}
+		}
+	}
+}
+
+// Returns the ML task at the given endpoint.
+func getMLTask(ctx context.Context, client *opensearchapi.Client, endpoint string) (skpropensearch.TaskGetResponse, error) {
+	var task skpropensearch.TaskGetResponse
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return task, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(req)
+	if err != nil {
+		return task, err
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		return task, err
+	}
+
+	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
+		return task, fmt.Errorf("OpenSearch returned %d while polling task: %s", httpResp.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, &task); err != nil {
+		return task, err
+	}
+
+	return task, nil
+}
+
+// Reports whether the model is in the state the given type of task leaves it in.
+func modelReachedTaskState(ctx context.Context, client *opensearchapi.Client, modelID, taskType string) (bool, error) {
+	model, exists, err := getModel(ctx, client, modelID)
+	if err != nil || !exists {
+		return false, err
+	}
+
+	if model.ModelState == skpropensearch.ModelStateDeployFailed {
+		return false, fmt.Errorf("model %s failed to deploy", modelID)
+	}
+
+	if taskType == skpropensearch.TaskTypeDeployModel {
+		return model.ModelState == skpropensearch.ModelStateDeployed || model.ModelState == skpropensearch.ModelStatePartiallyDeployed, nil
+	}
+
+	return model.ModelState != "" && model.ModelState != skpropensearch.ModelStateRegistering, nil
+}
+
+// Waits until the deployment of a model ends, returning the model in its final state. The wait ends with the context.
+func waitForModelDeployment(ctx context.Context, client *opensearchapi.Client, modelID string, pollInterval time.Duration) (skpropensearch.ModelGetResponse, error) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		model, exists, err := getModel(ctx, client, modelID)
+		if err != nil {
+			return model, err
+		}
+
+		if !exists {
+			return model, fmt.Errorf("model %s no longer exists", modelID)
+		}
+
+		switch model.ModelState {
+		case skpropensearch.ModelStateDeployed, skpropensearch.ModelStatePartiallyDeployed, skpropensearch.ModelStateDeployFailed:
+			return model, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return model, fmt.Errorf("%w waiting for model %s to deploy, last known model state: %s", ctx.Err(), modelID, model.ModelState)
+		case <-ticker.C:
+		}
+	}
+}
+
+// Returns the error of the latest deploy task of the model, which names the failure on each node,
+// or an empty string when it can't be found. Only used to explain a failure, so errors are ignored.
+func deployTaskError(ctx context.Context, client *opensearchapi.Client, modelID string) string {
+	query := map[string]any{
+		"size": 1,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"must": []any{
+					map[string]any{"term": map[string]any{"model_id": modelID}},
+					map[string]any{"term": map[string]any{"task_type": skpropensearch.TaskTypeDeployModel}},
+				},
+			},
+		},
+		"sort": []any{
+			map[string]any{"create_time": map[string]any{"order": "desc"}},
+		},
+	}
+
+	searchResp, err := searchML(ctx, client, "/_plugins/_ml/tasks/_search", query)
+	if err != nil || len(searchResp.Hits.Hits) == 0 {
+		return ""
+	}
+
+	var task skpropensearch.TaskGetResponse
+
+	if err := json.Unmarshal(searchResp.Hits.Hits[0].Source, &task); err != nil {
+		return ""
+	}
+
+	return task.Error
+}
+
+// Deploy a registered model and wait for the deploy task to complete.
+func deployModel(ctx context.Context, client *opensearchapi.Client, modelID string, body []byte, pollInterval time.Duration) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("/_plugins/_ml/models/%s/_deploy", modelID), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(req)
+	if err != nil {
+		return err
+	}
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", httpResp.StatusCode, string(respBody))
+	}
+
+	var deployResponse skpropensearch.ModelDeployResponse
+
+	if err := json.Unmarshal(respBody, &deployResponse); err != nil {
+		return err
+	}
+
+	_, err = waitForMLTaskCompletion(ctx, client, deployResponse.TaskID, pollInterval)
+
+	return err
+}
+
+// Undeploy a model from all nodes. A model which is already gone counts as undeployed.
+func undeployModel(ctx context.Context, client *opensearchapi.Client, modelID string) error {
+	return performModelRequest(ctx, client, "POST", fmt.Sprintf("/_plugins/_ml/models/%s/_undeploy", modelID))
+}
+
+// Delete a model. A model which is already gone counts as deleted.
+func deleteModel(ctx context.Context, client *opensearchapi.Client, modelID string) error {
+	return performModelRequest(ctx, client, "DELETE", fmt.Sprintf("/_plugins/_ml/models/%s", modelID))
+}
+
+// Performs a bodyless model request, treating 404 as success.
+func performModelRequest(ctx context.Context, client *opensearchapi.Client, method, path string) error {
+	req, err := http.NewRequestWithContext(ctx, method, path, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(req)
+	if err != nil {
+		return err
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// Enable or disable a model for inference without undeploying it.
+func setModelEnabled(ctx context.Context, client *opensearchapi.Client, modelID string, enabled bool) error {
+	return updateModel(ctx, client, modelID, skpropensearch.ModelUpdateRequest{
+		IsEnabled: &enabled,
+	})
+}
+
+// Updates the model in place, OpenSearch rejects invalid values (e.g. a rate limiter) with a 400 which is returned as is.
+func updateModel(ctx context.Context, client *opensearchapi.Client, modelID string, update skpropensearch.ModelUpdateRequest) error {
+	requestBody, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("/_plugins/_ml/models/%s", modelID), bytes.NewReader(requestBody))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(req)
+	if err != nil {
+		return err
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// Read the resource state from OpenSearch for our model.
+func (r *ModelRegisterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ModelRegisterModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// If we don’t have an ID, nothing to read.
+	if data.ModelID.IsNull() || data.ModelID.IsUnknown() || data.ModelID.ValueString() == "" {
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+	client, err := r.client()
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error reading model",
-			fmt.Sprintf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body)),
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	model, found, err := getModel(ctx, client, data.ModelID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading model", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	body, err := readBackModelBody(data.Body.ValueString(), model)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing model",
+			fmt.Sprintf("Could not compare model %s with state: %s", data.ModelID.ValueString(), err.Error()),
 		)
 		return
 	}
 
+	data.Body = NewJSONBodyValue(body)
+
+	// Only read back the typed attributes the user manages, the raw body stays authoritative otherwise.
+	if !data.ModelFormat.IsNull() && model.ModelFormat != "" {
+		data.ModelFormat = types.StringValue(model.ModelFormat)
+	}
+
+	if !data.ModelConfig.IsNull() && len(model.ModelConfig) > 0 {
+		modelConfig, err := readBackModelConfig(data.ModelConfig.ValueString(), model.ModelConfig)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error parsing model config",
+				fmt.Sprintf("Could not compare model config: %s", err.Error()),
+			)
+			return
+		}
+
+		data.ModelConfig = types.StringValue(modelConfig)
+	}
+
+	if !data.RateLimiter.IsNull() {
+		rateLimiter, diags := readBackRateLimiter(model.RateLimiter)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.RateLimiter = rateLimiter
+	}
+
+	// Older versions of OpenSearch don't report is_enabled, in which case the model is always enabled.
+	data.Enabled = types.BoolValue(model.IsEnabled == nil || *model.IsEnabled)
+
+	if !data.DeployParameters.IsNull() && len(model.PlanningWorkerNodes) > 0 {
+		deployParameters, diags := readBackDeployParameters(ctx, data.DeployParameters, model.PlanningWorkerNodes)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.DeployParameters = deployParameters
+	}
+
+	workerNodes, diags := types.ListValueFrom(ctx, types.StringType, model.PlanningWorkerNodes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.WorkerNodes = workerNodes
+	data.DeployedNodeCount = types.Int64Value(model.CurrentWorkerNodeCount)
+	data.ModelState = types.StringValue(model.ModelState)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-// Update is not supported; registering a new model is the only way to change anything.
+// Returns the rate limiter to send to OpenSearch, nil when there is none.
+func (m ModelRegisterModel) rateLimiter() *skpropensearch.RateLimiter {
+	if m.RateLimiter.IsNull() || m.RateLimiter.IsUnknown() {
+		return nil
+	}
+
+	attrs := m.RateLimiter.Attributes()
+
+	limit, _ := attrs["limit"].(types.Float64)
+	unit, _ := attrs["unit"].(types.String)
+
+	return &skpropensearch.RateLimiter{
+		Limit: strconv.FormatFloat(limit.ValueFloat64(), 'f', -1, 64),
+		Unit:  unit.ValueString(),
+	}
+}
+
+// Returns the rate limiter reported by OpenSearch, null when the model has none so the attribute shows drift.
+func readBackRateLimiter(rateLimiter *skpropensearch.RateLimiter) (types.Object, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if rateLimiter == nil {
+		return types.ObjectNull(modelRateLimiterAttrTypes), diags
+	}
+
+	limit, err := strconv.ParseFloat(rateLimiter.Limit, 64)
+	if err != nil {
+		diags.AddError(
+			"Error parsing model rate limiter",
+			fmt.Sprintf("Could not parse the rate limiter limit %q: %s", rateLimiter.Limit, err.Error()),
+		)
+		return types.ObjectNull(modelRateLimiterAttrTypes), diags
+	}
+
+	return types.ObjectValueMust(modelRateLimiterAttrTypes, map[string]attr.Value{
+		"limit": types.Float64Value(limit),
+		"unit":  types.StringValue(rateLimiter.Unit),
+	}), diags
+}
+
+// Returns the model and whether it exists.
+func getModel(ctx context.Context, client *opensearchapi.Client, modelID string) (skpropensearch.ModelGetResponse, bool, error) {
+	var model skpropensearch.ModelGetResponse
+
+	getReq, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/_plugins/_ml/models/%s", modelID), nil)
+	if err != nil {
+		return model, false, err
+	}
+
+	getReq.Header.Set("Content-Type", "application/json")
+	getReq.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(getReq)
+	if err != nil {
+		return model, false, err
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		return model, false, err
+	}
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return model, false, nil
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return model, false, fmt.Errorf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, &model); err != nil {
+		return model, false, fmt.Errorf("could not parse model get response: %w", err)
+	}
+
+	return model, true, nil
+}
+
+// Returns the IDs of the first count nodes with the ml role, sorted so the choice is stable.
+func mlNodeIDs(ctx context.Context, client *opensearchapi.Client, count int) ([]string, error) {
+	getReq, err := http.NewRequestWithContext(ctx, "GET", "/_nodes", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	getReq.Header.Set("Content-Type", "application/json")
+	getReq.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(getReq)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return nil, fmt.Errorf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	var nodes skpropensearch.NodesInfoResponse
+
+	if err := json.Unmarshal(body, &nodes); err != nil {
+		return nil, err
+	}
+
+	var ids []string
+
+	for id, node := range nodes.Nodes {
+		if slices.Contains(node.Roles, skpropensearch.NodeRoleML) {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) < count {
+		return nil, fmt.Errorf("requested deployment to %d ML nodes but the cluster only has %d nodes with the %q role", count, len(ids), skpropensearch.NodeRoleML)
+	}
+
+	sort.Strings(ids)
+
+	return ids[:count], nil
+}
+
+// Returns the deploy parameters with node_ids replaced by the nodes OpenSearch planned the deployment on,
+// when they differ. node_ids is the only deploy parameter OpenSearch reports back.
+func readBackDeployParameters(ctx context.Context, configured types.Map, plannedNodes []string) (types.Map, diag.Diagnostics) {
+	var deployParameters map[string]string
+
+	diags := configured.ElementsAs(ctx, &deployParameters, false)
+	if diags.HasError() {
+		return configured, diags
+	}
+
+	nodeIDs, ok := deployParameters["node_ids"]
+	if !ok {
+		return configured, diags
+	}
+
+	var ids []string
+
+	if err := json.Unmarshal([]byte(nodeIDs), &ids); err != nil {
+		return configured, diags
+	}
+
+	if sameElements(ids, plannedNodes) {
+		return configured, diags
+	}
+
+	planned, err := json.Marshal(plannedNodes)
+	if err != nil {
+		diags.AddError("Error reading deploy parameters", err.Error())
+		return configured, diags
+	}
+
+	deployParameters["node_ids"] = string(planned)
+
+	return types.MapValueFrom(ctx, types.StringType, deployParameters)
+}
+
+// Reports whether both slices contain the same elements, ignoring order.
+func sameElements(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int, len(a))
+
+	for _, v := range a {
+		counts[v]++
+	}
+
+	for _, v := range b {
+		counts[v]--
+		if counts[v] < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns the registration body to store in state, so out of band changes to the model surface in plan.
+// Only the drift tracked fields the body already has are compared, everything else is kept from state.
+// The state body is returned unchanged when nothing drifted, and an imported model (without a body) gets
+// every drift tracked field OpenSearch reports.
+func readBackModelBody(state string, model skpropensearch.ModelGetResponse) (string, error) {
+	stateBody := map[string]any{}
+
+	if state != "" {
+		if err := json.Unmarshal([]byte(state), &stateBody); err != nil {
+			return "", err
+		}
+	}
+
+	remote := map[string]any{}
+
+	for field, value := range map[string]string{
+		"name":           model.Name,
+		"description":    model.Description,
+		"function_name":  model.FunctionName,
+		"model_group_id": model.ModelGroupID,
+		"connector_id":   model.ConnectorID,
+	} {
+		if value != "" {
+			remote[field] = value
+		}
+	}
+
+	// OpenSearch reports the function name upper cased, e.g. REMOTE for a body with "remote".
+	if functionName, ok := stateBody["function_name"].(string); ok && strings.EqualFold(functionName, model.FunctionName) {
+		remote["function_name"] = functionName
+	}
+
+	if len(model.Connector) > 0 {
+		connector, err := normalizeConnectorBody(model.Connector)
+		if err != nil {
+			return "", err
+		}
+
+		// The connector is compared like a connector resource, keeping the credential OpenSearch never returns.
+		stateConnector := ""
+		if _, ok := stateBody["connector"]; ok {
+			stateConnectorBytes, err := json.Marshal(stateBody["connector"])
+			if err != nil {
+				return "", err
+			}
+
+			stateConnector = string(stateConnectorBytes)
+		}
+
+		connector, err = readBackConnectorBody(stateConnector, connector)
+		if err != nil {
+			return "", err
+		}
+
+		var value any
+		if err := json.Unmarshal([]byte(connector), &value); err != nil {
+			return "", err
+		}
+
+		remote["connector"] = value
+	}
+
+	if len(model.Interface) > 0 {
+		var modelInterface map[string]any
+		if err := json.Unmarshal(model.Interface, &modelInterface); err != nil {
+			return "", err
+		}
+
+		// OpenSearch returns the input and output schemas as JSON strings, while bodies usually give them as objects.
+		stateInterface, _ := stateBody["interface"].(map[string]any)
+
+		for key, value := range modelInterface {
+			schemaString, ok := value.(string)
+			if _, isObject := stateInterface[key].(map[string]any); !ok || !isObject {
+				continue
+			}
+
+			var schema any
+			if err := json.Unmarshal([]byte(schemaString), &schema); err == nil {
+				modelInterface[key] = schema
+			}
+		}
+
+		remote["interface"] = modelInterface
+	}
+
+	body := make(map[string]any, len(stateBody))
+	for key, value := range stateBody {
+		body[key] = value
+	}
+
+	for _, field := range skpropensearch.ModelBodyDriftFields {
+		value, ok := remote[field]
+		if !ok {
+			continue
+		}
+
+		if _, tracked := stateBody[field]; tracked || state == "" {
+			body[field] = value
+		}
+	}
+
+	if state != "" && reflect.DeepEqual(body, stateBody) {
+		return state, nil
+	}
+
+	result, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+
+	return string(result), nil
+}
+
+// Returns the configured model config unchanged when every configured field matches OpenSearch,
+// otherwise the configured fields as OpenSearch reports them so the drift surfaces in plan.
+func readBackModelConfig(configured string, remote json.RawMessage) (string, error) {
+	var (
+		want map[string]any
+		got  map[string]any
+	)
+
+	if err := json.Unmarshal([]byte(configured), &want); err != nil {
+		return "", err
+	}
+
+	if err := json.Unmarshal(remote, &got); err != nil {
+		return "", err
+	}
+
+	drifted := make(map[string]any, len(want))
+
+	for key := range want {
+		if value, ok := got[key]; ok {
+			drifted[key] = value
+		}
+	}
+
+	wantBytes, err := json.Marshal(want)
+	if err != nil {
+		return "", err
+	}
+
+	driftedBytes, err := json.Marshal(drifted)
+	if err != nil {
+		return "", err
+	}
+
+	if bytes.Equal(wantBytes, driftedBytes) {
+		return configured, nil
+	}
+
+	return string(driftedBytes), nil
+}
+
+// Update enables or disables the model, registering a new model is the only way to change anything else.
 func (r *ModelRegisterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data ModelRegisterModel
 
@@ -320,10 +1798,47 @@ func (r *ModelRegisterResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	// All updatable fields are RequiresReplace, so Update should not be called for changes.
-	// Still, if called (e.g. drift-only), just persist planned state.
-	tflog.Trace(ctx, "updated Model Register resource (no-op update)", map[string]any{
+	var state ModelRegisterModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Toggling enabled and changing the rate limiter are the only in-place updates, everything else is RequiresReplace.
+	var update skpropensearch.ModelUpdateRequest
+
+	if !data.Enabled.Equal(state.Enabled) {
+		enabled := data.Enabled.ValueBool()
+		update.IsEnabled = &enabled
+	}
+
+	if !data.RateLimiter.Equal(state.RateLimiter) {
+		update.RateLimiter = data.rateLimiter()
+	}
+
+	if update.IsEnabled != nil || update.RateLimiter != nil {
+		client, err := r.client()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating OpenSearch client",
+				fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+			)
+			return
+		}
+
+		if err := updateModel(ctx, client, data.ModelID.ValueString(), update); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating model",
+				fmt.Sprintf("Could not update model %s: %s", data.ModelID.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "updated Model Register resource", map[string]any{
 		"model_id": data.ModelID.ValueString(),
+		"enabled":  data.Enabled.ValueBool(),
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -338,6 +1853,15 @@ func (r *ModelRegisterResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultModelDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Nothing to delete if missing ID.
 	if data.ModelID.IsNull() || data.ModelID.IsUnknown() || data.ModelID.ValueString() == "" {
 		return
@@ -394,3 +1918,50 @@ func (r *ModelRegisterResource) Delete(ctx context.Context, req resource.DeleteR
 		"model_id": data.ModelID.ValueString(),
 	})
 }
+
+// ImportState imports the model by ID, or by name using a `name:<value>` import ID.
+func (r *ModelRegisterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	id, err := resolveImportID(ctx, client, "/_plugins/_ml/models/_search", req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing model",
+			fmt.Sprintf("Could not resolve model import ID: %s", err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("model_id"), id)...)
+}
+
+// UpgradeState carries model state written by earlier versions of the provider forward.
+func (r *ModelRegisterResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 only had the model ID and body, and always deployed the registered model, so enabled and
+		// deploy start as true. The other attributes added since are left null for the next read.
+		0: {
+			PriorSchema: &modelRegisterSchemaV0,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior modelRegisterModelV0
+
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("model_id"), prior.ModelID)...)
+				resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("body"), JSONBody{StringValue: prior.Body})...)
+				resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("enabled"), types.BoolValue(true))...)
+				resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deploy"), types.BoolValue(true))...)
+			},
+		},
+	}
+}
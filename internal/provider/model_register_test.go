@@ -0,0 +1,443 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/opensearch-project/opensearch-go/v4"
+	requestsigner "github.com/opensearch-project/opensearch-go/v4/signer/awsv2"
+)
+
+func TestWaitForMLTaskCompletionRefreshesExpiredCredentials(t *testing.T) {
+	var retrievals, polls atomic.Int32
+
+	// Each retrieval returns new keys, so the server can tell which credentials signed a request.
+	credentials := aws.NewCredentialsCache(aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+		return aws.Credentials{
+			AccessKeyID:     fmt.Sprintf("AKID%d", retrievals.Add(1)),
+			SecretAccessKey: "secret",
+		}, nil
+	}))
+
+	signer, err := requestsigner.NewSignerWithService(aws.Config{Region: "us-east-1", Credentials: credentials}, "es")
+	if err != nil {
+		t.Fatalf("could not create signer: %s", err)
+	}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// The first credentials expire after the first poll.
+		if polls.Load() > 0 && strings.Contains(r.Header.Get("Authorization"), "Credential=AKID1/") {
+			writeJSON(w, http.StatusForbidden, `{"message":"The security token included in the request is expired"}`)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/_plugins/_ml/tasks/task-1":
+			poll := polls.Add(1)
+
+			state := "RUNNING"
+			if poll > 1 {
+				state = "COMPLETED"
+			}
+
+			writeJSON(w, http.StatusOK, fmt.Sprintf(`{"task_type":"REGISTER_MODEL","state":%q,"model_id":"model-1"}`, state))
+		case "/_plugins/_ml/models/model-1":
+			writeJSON(w, http.StatusOK, `{"model_state":"REGISTERED"}`)
+		default:
+			writeJSON(w, http.StatusNotFound, `{}`)
+		}
+	}, func(config *opensearch.Config) {
+		config.Signer = signer
+		config.Transport = &sigV4RefreshTransport{
+			base:        http.DefaultTransport,
+			signer:      signer,
+			credentials: credentials,
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	modelID, err := waitForMLTaskCompletion(ctx, client, "task-1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("expected the poll to survive the expired credentials, got: %s", err)
+	}
+
+	if modelID != "model-1" {
+		t.Errorf("expected model-1, got %q", modelID)
+	}
+
+	if got := retrievals.Load(); got != 2 {
+		t.Errorf("expected the credentials to be retrieved twice, got %d", got)
+	}
+
+	if got := polls.Load(); got != 2 {
+		t.Errorf("expected 2 successful polls (running, completed), got %d", got)
+	}
+}
+
+func TestWaitForMLTaskCompletionWaitsForModel(t *testing.T) {
+	tests := []struct {
+		name        string
+		taskStatus  func(poll int32) (int, string)
+		modelStatus func(get int32) (int, string)
+		wantGets    int32
+	}{
+		{
+			name: "model not visible yet",
+			taskStatus: func(poll int32) (int, string) {
+				return http.StatusOK, `{"task_type":"REGISTER_MODEL","state":"COMPLETED","model_id":"model-1"}`
+			},
+			modelStatus: func(get int32) (int, string) {
+				if get <= 2 {
+					return http.StatusNotFound, `{"error":{"type":"status_exception","reason":"Failed to find model"},"status":404}`
+				}
+
+				return http.StatusOK, `{"model_state":"REGISTERED"}`
+			},
+			wantGets: 3,
+		},
+		{
+			name: "task API fails after the model is known",
+			taskStatus: func(poll int32) (int, string) {
+				if poll == 1 {
+					return http.StatusOK, `{"task_type":"REGISTER_MODEL","state":"RUNNING","model_id":"model-1"}`
+				}
+
+				return http.StatusInternalServerError, `{"error":"task index unavailable"}`
+			},
+			modelStatus: func(get int32) (int, string) {
+				if get == 1 {
+					return http.StatusOK, `{"model_state":"REGISTERING"}`
+				}
+
+				return http.StatusOK, `{"model_state":"REGISTERED"}`
+			},
+			wantGets: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var polls, gets atomic.Int32
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/_plugins/_ml/tasks/task-1":
+					status, body := tt.taskStatus(polls.Add(1))
+					writeJSON(w, status, body)
+				case "/_plugins/_ml/models/model-1":
+					status, body := tt.modelStatus(gets.Add(1))
+					writeJSON(w, status, body)
+				default:
+					writeJSON(w, http.StatusNotFound, `{}`)
+				}
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			modelID, err := waitForMLTaskCompletion(ctx, client, "task-1", time.Millisecond)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if modelID != "model-1" {
+				t.Errorf("expected model-1, got %q", modelID)
+			}
+
+			if got := gets.Load(); got != tt.wantGets {
+				t.Errorf("expected the model to be fetched %d times, got %d", tt.wantGets, got)
+			}
+		})
+	}
+}
+
+func TestModelRegisterUnknownDeployParameters(t *testing.T) {
+	ctx := context.Background()
+
+	r := &ModelRegisterResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	attributes["body"] = tftypes.NewValue(tftypes.String, `{"name":"embeddings"}`)
+	attributes["deploy_parameters"] = tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+		"node_ids":        tftypes.NewValue(tftypes.String, `["node-1"]`),
+		"future_option":   tftypes.NewValue(tftypes.String, `{"enabled":true}`),
+		"another_setting": tftypes.NewValue(tftypes.String, "fast"),
+	})
+
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)}
+
+	resp := &resource.ValidateConfigResponse{}
+	r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: config}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected unknown deploy parameters to be accepted, got %v", resp.Diagnostics)
+	}
+
+	if warnings := resp.Diagnostics.Warnings(); len(warnings) != 2 {
+		t.Errorf("expected a warning for each unknown deploy parameter, got %v", warnings)
+	}
+
+	var data ModelRegisterModel
+	if diags := config.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("could not read config: %v", diags)
+	}
+
+	body, err := data.deployBody(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := `{"another_setting":"fast","future_option":{"enabled":true},"node_ids":["node-1"]}`
+	if string(body) != want {
+		t.Errorf("expected deploy body %s, got %s", want, body)
+	}
+}
+
+func TestModelRegisterCreateRollsBack(t *testing.T) {
+	ctx := context.Background()
+
+	r := &ModelRegisterResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	timeoutsType := objectType.AttributeTypes["timeouts"]
+
+	tests := []struct {
+		name         string
+		deploy       bool
+		enabled      bool
+		rollback     bool
+		createTime   string
+		modelState   string
+		wantError    string
+		wantDeletes  int32
+		wantLeftOver bool
+	}{
+		{
+			name:        "disabling fails",
+			enabled:     false,
+			rollback:    true,
+			modelState:  "REGISTERED",
+			wantError:   "Error disabling model",
+			wantDeletes: 1,
+		},
+		{
+			name:        "deployment times out",
+			deploy:      true,
+			enabled:     true,
+			rollback:    true,
+			createTime:  "200ms",
+			modelState:  "DEPLOYING",
+			wantError:   "Error reading model",
+			wantDeletes: 1,
+		},
+		{
+			name:         "disabling fails without rollback",
+			enabled:      false,
+			modelState:   "REGISTERED",
+			wantError:    "Error disabling model",
+			wantLeftOver: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deletes atomic.Int32
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/_plugins/_ml/models/_register":
+					writeJSON(w, http.StatusOK, `{"task_id":"task-1","status":"CREATED"}`)
+				case r.URL.Path == "/_plugins/_ml/tasks/task-1":
+					writeJSON(w, http.StatusOK, `{"task_type":"REGISTER_MODEL","state":"COMPLETED","model_id":"model-1"}`)
+				case r.URL.Path == "/_plugins/_ml/models/model-1" && r.Method == http.MethodGet:
+					writeJSON(w, http.StatusOK, fmt.Sprintf(`{"model_state":%q}`, tt.modelState))
+				case r.URL.Path == "/_plugins/_ml/models/model-1" && r.Method == http.MethodPut:
+					writeJSON(w, http.StatusInternalServerError, `{"error":"update failed"}`)
+				case r.URL.Path == "/_plugins/_ml/models/model-1/_undeploy":
+					writeJSON(w, http.StatusOK, `{}`)
+				case r.URL.Path == "/_plugins/_ml/models/model-1" && r.Method == http.MethodDelete:
+					deletes.Add(1)
+					writeJSON(w, http.StatusOK, `{"result":"deleted"}`)
+				default:
+					writeJSON(w, http.StatusNotFound, `{}`)
+				}
+			})
+
+			attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+			for name, attributeType := range objectType.AttributeTypes {
+				attributes[name] = tftypes.NewValue(attributeType, nil)
+			}
+
+			attributes["body"] = tftypes.NewValue(tftypes.String, `{"name":"embeddings","function_name":"remote","connector_id":"connector-1"}`)
+			attributes["deploy"] = tftypes.NewValue(tftypes.Bool, tt.deploy)
+			attributes["enabled"] = tftypes.NewValue(tftypes.Bool, tt.enabled)
+			attributes["rollback_on_deploy_failure"] = tftypes.NewValue(tftypes.Bool, tt.rollback)
+			attributes["poll_interval"] = tftypes.NewValue(tftypes.String, "10ms")
+
+			if tt.createTime != "" {
+				attributes["timeouts"] = tftypes.NewValue(timeoutsType, map[string]tftypes.Value{
+					"create": tftypes.NewValue(tftypes.String, tt.createTime),
+					"delete": tftypes.NewValue(tftypes.String, nil),
+				})
+			}
+
+			req := resource.CreateRequest{
+				Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)},
+			}
+			resp := &resource.CreateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+			}
+
+			(&ModelRegisterResource{providerData: &ProviderData{Client: client}}).Create(ctx, req, resp)
+
+			errs := resp.Diagnostics.Errors()
+			if len(errs) != 1 || errs[0].Summary() != tt.wantError {
+				t.Fatalf("expected the error %q, got %v", tt.wantError, resp.Diagnostics)
+			}
+
+			if !resp.State.Raw.IsNull() {
+				t.Error("expected no state to be saved")
+			}
+
+			if got := deletes.Load(); got != tt.wantDeletes {
+				t.Errorf("expected the model to be deleted %d times, got %d", tt.wantDeletes, got)
+			}
+
+			warnings := resp.Diagnostics.Warnings()
+			if leftOver := len(warnings) == 1 && warnings[0].Summary() == "Model left registered"; leftOver != tt.wantLeftOver {
+				t.Errorf("expected a warning about the model left registered %t, got %v", tt.wantLeftOver, warnings)
+			}
+		})
+	}
+}
+
+func TestModelRegisterPartialDeployment(t *testing.T) {
+	ctx := context.Background()
+
+	r := &ModelRegisterResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	tests := []struct {
+		name                  string
+		requireFullDeployment bool
+		wantError             bool
+	}{
+		{
+			name:                  "full deployment required",
+			requireFullDeployment: true,
+			wantError:             true,
+		},
+		{
+			name:                  "partial deployment allowed",
+			requireFullDeployment: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/_plugins/_ml/models/_register":
+					writeJSON(w, http.StatusOK, `{"task_id":"task-1","status":"CREATED"}`)
+				case "/_plugins/_ml/tasks/task-1":
+					writeJSON(w, http.StatusOK, `{"task_type":"REGISTER_MODEL","state":"COMPLETED","model_id":"model-1"}`)
+				case "/_plugins/_ml/models/model-1":
+					writeJSON(w, http.StatusOK, `{"model_state":"PARTIALLY_DEPLOYED","planning_worker_nodes":["node-1","node-2"],"current_worker_node_count":1}`)
+				case "/_plugins/_ml/profile/models/model-1":
+					writeJSON(w, http.StatusOK, `{"nodes":{"node-1":{"models":{"model-1":{"model_state":"DEPLOYED"}}}}}`)
+				case "/_plugins/_ml/tasks/_search":
+					writeJSON(w, http.StatusOK, `{"hits":{"hits":[]}}`)
+				default:
+					writeJSON(w, http.StatusNotFound, `{}`)
+				}
+			})
+
+			attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+			for name, attributeType := range objectType.AttributeTypes {
+				attributes[name] = tftypes.NewValue(attributeType, nil)
+			}
+
+			attributes["body"] = tftypes.NewValue(tftypes.String, `{"name":"embeddings","function_name":"remote","connector_id":"connector-1"}`)
+			attributes["deploy"] = tftypes.NewValue(tftypes.Bool, true)
+			attributes["enabled"] = tftypes.NewValue(tftypes.Bool, true)
+			attributes["rollback_on_deploy_failure"] = tftypes.NewValue(tftypes.Bool, false)
+			attributes["require_full_deployment"] = tftypes.NewValue(tftypes.Bool, tt.requireFullDeployment)
+			attributes["poll_interval"] = tftypes.NewValue(tftypes.String, "10ms")
+
+			req := resource.CreateRequest{
+				Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)},
+			}
+			resp := &resource.CreateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)},
+			}
+
+			(&ModelRegisterResource{providerData: &ProviderData{Client: client}}).Create(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Fatalf("expected an error %t, got %v", tt.wantError, resp.Diagnostics)
+			}
+
+			if tt.wantError {
+				if errs := resp.Diagnostics.Errors(); errs[0].Summary() != "Model was not deployed to every worker node" {
+					t.Errorf("expected a partial deployment error, got %v", errs)
+				}
+				return
+			}
+
+			warnings := resp.Diagnostics.Warnings()
+			if len(warnings) != 1 || warnings[0].Summary() != "Model was not deployed to every worker node" {
+				t.Errorf("expected a partial deployment warning, got %v", warnings)
+			}
+
+			var data ModelRegisterModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+
+			if data.ModelID.ValueString() != "model-1" || data.DeployedNodeCount.ValueInt64() != 1 {
+				t.Errorf("expected the partially deployed model to be saved, got model %s on %s nodes", data.ModelID, data.DeployedNodeCount)
+			}
+		})
+	}
+}
+
+func TestModelRegisterRequireFullDeploymentDefault(t *testing.T) {
+	var schemaResp resource.SchemaResponse
+	(&ModelRegisterResource{}).Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	attribute := schemaResp.Schema.Attributes["require_full_deployment"].(schema.BoolAttribute)
+
+	var defaultResp defaults.BoolResponse
+	attribute.Default.DefaultBool(context.Background(), defaults.BoolRequest{}, &defaultResp)
+
+	if !defaultResp.PlanValue.ValueBool() {
+		t.Errorf("expected a partial deployment to fail by default, got require_full_deployment %s", defaultResp.PlanValue)
+	}
+}
@@ -0,0 +1,436 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// Services an OpenAI connector can call.
+const (
+	openAIServiceOpenAI = "openai"
+	openAIServiceAzure  = "azure"
+)
+
+// Tasks an OpenAI connector can perform.
+const (
+	openAITaskChat      = "chat"
+	openAITaskEmbedding = "embedding"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &OpenAIConnectorResource{}
+	_ resource.ResourceWithValidateConfig = &OpenAIConnectorResource{}
+)
+
+// NewOpenAIConnectorResource is a helper function to simplify the provider implementation.
+func NewOpenAIConnectorResource() resource.Resource {
+	return &OpenAIConnectorResource{}
+}
+
+// OpenAIConnectorResource is the resource implementation.
+type OpenAIConnectorResource struct {
+	providerData *ProviderData
+}
+
+// OpenAIConnectorModel describes the OpenAI Connector resource data model.
+type OpenAIConnectorModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	Service        types.String `tfsdk:"service"`
+	Task           types.String `tfsdk:"task"`
+	Model          types.String `tfsdk:"model"`
+	Endpoint       types.String `tfsdk:"endpoint"`
+	Organization   types.String `tfsdk:"organization"`
+	DeploymentName types.String `tfsdk:"deployment_name"`
+	APIVersion     types.String `tfsdk:"api_version"`
+	APIKey         types.String `tfsdk:"api_key"`
+	APIKeyVersion  types.Int64  `tfsdk:"api_key_version"`
+}
+
+// Metadata returns the data source type name.
+func (r *OpenAIConnectorResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_openai_connector", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the OpenAI Connector resource.
+func (r *OpenAIConnectorResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Connectors can't be updated, so every argument replaces the connector.
+	requiresReplace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Connector to an OpenAI or Azure OpenAI model, assembled from typed attributes. " +
+			"Use `opensearch_connector` for other remote model providers.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier for the connector.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the connector.",
+				Required:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the connector.",
+				Optional:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"service": schema.StringAttribute{
+				MarkdownDescription: "Service hosting the model, `openai` or `azure`. Defaults to `openai`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(openAIServiceOpenAI),
+				PlanModifiers:       requiresReplace,
+				Validators: []validator.String{
+					stringvalidator.OneOf(openAIServiceOpenAI, openAIServiceAzure),
+				},
+			},
+			"task": schema.StringAttribute{
+				MarkdownDescription: "What the model is used for, `chat` (chat completions) or `embedding`. Defaults to `chat`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(openAITaskChat),
+				PlanModifiers:       requiresReplace,
+				Validators: []validator.String{
+					stringvalidator.OneOf(openAITaskChat, openAITaskEmbedding),
+				},
+			},
+			"model": schema.StringAttribute{
+				MarkdownDescription: "OpenAI model, e.g. `gpt-4o-mini` or `text-embedding-3-small`. Required for the `openai` service.",
+				Optional:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "Host of the API. Defaults to `api.openai.com` for the `openai` service, " +
+					"required for the `azure` service, e.g. `my-resource.openai.azure.com`.",
+				Optional:      true,
+				PlanModifiers: requiresReplace,
+			},
+			"organization": schema.StringAttribute{
+				MarkdownDescription: "OpenAI organization ID requests are billed to. Only used by the `openai` service.",
+				Optional:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"deployment_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the Azure OpenAI deployment. Required for the `azure` service.",
+				Optional:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"api_version": schema.StringAttribute{
+				MarkdownDescription: "Azure OpenAI API version. Only used by the `azure` service, defaults to `2024-02-01`.",
+				Optional:            true,
+				PlanModifiers:       requiresReplace,
+			},
+			"api_key": schema.StringAttribute{
+				MarkdownDescription: "API key used to call the model. Write-only, so it is never stored in state; " +
+					"change `api_key_version` to recreate the connector with a new key.",
+				Required:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"api_key_version": schema.Int64Attribute{
+				MarkdownDescription: "Changing this recreates the connector with the current `api_key`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks the attributes each service requires.
+func (r *OpenAIConnectorResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data OpenAIConnectorModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Service.IsUnknown() {
+		return
+	}
+
+	required := []string{"model"}
+	if data.Service.ValueString() == openAIServiceAzure {
+		required = []string{"endpoint", "deployment_name"}
+	}
+
+	for _, attribute := range required {
+		var value types.String
+
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &value)...)
+
+		if value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"Missing required attribute",
+				fmt.Sprintf("%s must be set for the %s service.", attribute, data.Service.ValueString()),
+			)
+		}
+	}
+}
+
+// Returns the connector create body for the OpenAI or Azure OpenAI blueprint.
+func (m OpenAIConnectorModel) createBody(apiKey string) ([]byte, error) {
+	var (
+		parameters = map[string]string{}
+		headers    = map[string]string{}
+		url        string
+	)
+
+	switch m.Service.ValueString() {
+	case openAIServiceAzure:
+		parameters["endpoint"] = m.Endpoint.ValueString()
+		parameters["deployment_name"] = m.DeploymentName.ValueString()
+		parameters["api_version"] = "2024-02-01"
+
+		if !m.APIVersion.IsNull() {
+			parameters["api_version"] = m.APIVersion.ValueString()
+		}
+
+		headers["api-key"] = "${credential.openAI_key}"
+
+		url = "https://${parameters.endpoint}/openai/deployments/${parameters.deployment_name}/chat/completions?api-version=${parameters.api_version}"
+		if m.Task.ValueString() == openAITaskEmbedding {
+			url = "https://${parameters.endpoint}/openai/deployments/${parameters.deployment_name}/embeddings?api-version=${parameters.api_version}"
+		}
+	default:
+		parameters["endpoint"] = "api.openai.com"
+		parameters["model"] = m.Model.ValueString()
+
+		if !m.Endpoint.IsNull() {
+			parameters["endpoint"] = m.Endpoint.ValueString()
+		}
+
+		headers["Authorization"] = "Bearer ${credential.openAI_key}"
+
+		if !m.Organization.IsNull() {
+			headers["OpenAI-Organization"] = m.Organization.ValueString()
+		}
+
+		url = "https://${parameters.endpoint}/v1/chat/completions"
+		if m.Task.ValueString() == openAITaskEmbedding {
+			url = "https://${parameters.endpoint}/v1/embeddings"
+		}
+	}
+
+	headers["Content-Type"] = "application/json"
+
+	action := map[string]any{
+		"action_type": "predict",
+		"method":      "POST",
+		"url":         url,
+		"headers":     headers,
+	}
+
+	// Azure selects the model with the deployment, so only OpenAI requests name it.
+	_, namesModel := parameters["model"]
+
+	switch {
+	case m.Task.ValueString() == openAITaskEmbedding && namesModel:
+		action["request_body"] = `{"input": ${parameters.input}, "model": "${parameters.model}"}`
+	case m.Task.ValueString() == openAITaskEmbedding:
+		action["request_body"] = `{"input": ${parameters.input}}`
+	case namesModel:
+		action["request_body"] = `{"model": "${parameters.model}", "messages": ${parameters.messages}}`
+	default:
+		action["request_body"] = `{"messages": ${parameters.messages}}`
+	}
+
+	if m.Task.ValueString() == openAITaskEmbedding {
+		action["pre_process_function"] = "connector.pre_process.openai.embedding"
+		action["post_process_function"] = "connector.post_process.openai.embedding"
+	}
+
+	body := map[string]any{
+		"name":       m.Name.ValueString(),
+		"version":    1,
+		"protocol":   "http",
+		"parameters": parameters,
+		"credential": map[string]string{"openAI_key": apiKey},
+		"actions":    []any{action},
+	}
+
+	if !m.Description.IsNull() {
+		body["description"] = m.Description.ValueString()
+	}
+
+	return json.Marshal(body)
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *OpenAIConnectorResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *OpenAIConnectorResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// Create a new OpenAI connector in OpenSearch.
+func (r *OpenAIConnectorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var (
+		data   OpenAIConnectorModel
+		apiKey types.String
+	)
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write-only attributes are only available from the configuration.
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("api_key"), &apiKey)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	createBody, err := data.createBody(apiKey.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating connector request body",
+			fmt.Sprintf("Could not create connector create request body: %s", err.Error()),
+		)
+		return
+	}
+
+	connectorID, _, err := createConnector(ctx, client, createBody)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating connector",
+			fmt.Sprintf("Could not create connector: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(connectorID)
+
+	tflog.Trace(ctx, "created OpenAI Connector resource", map[string]any{
+		"connector_id": connectorID,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read the resource state from OpenSearch for our connector.
+func (r *OpenAIConnectorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data OpenAIConnectorModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	_, exists, err := getConnector(ctx, client, data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading connector", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if !exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update is not supported; every argument replaces the connector.
+func (r *OpenAIConnectorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data OpenAIConnectorModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete the connector from OpenSearch.
+func (r *OpenAIConnectorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data OpenAIConnectorModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := deleteConnector(ctx, client, data.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting connector",
+			fmt.Sprintf("Could not delete connector %s: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted OpenAI Connector resource", map[string]any{
+		"connector_id": data.ID.ValueString(),
+	})
+}
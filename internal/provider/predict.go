@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// Runs a prediction against a model, returning the HTTP status and response body.
+// When an algorithm is given the algorithm specific endpoint is used, otherwise the model endpoint which
+// works for remote and deployed local models.
+func predict(ctx context.Context, client *opensearchapi.Client, modelID, algorithm string, input []byte) (int, []byte, error) {
+	predictPath := fmt.Sprintf("/_plugins/_ml/models/%s/_predict", modelID)
+	if algorithm != "" {
+		predictPath = fmt.Sprintf("/_plugins/_ml/_predict/%s/%s", algorithm, modelID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", predictPath, bytes.NewReader(input))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(req)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		return httpResp.StatusCode, nil, err
+	}
+
+	return httpResp.StatusCode, body, nil
+}
+
+// PredictModel describes the Predict data source and ephemeral resource data model.
+type PredictModel struct {
+	ModelID   types.String `tfsdk:"model_id"`
+	Algorithm types.String `tfsdk:"algorithm"`
+	Input     JSONBody     `tfsdk:"input"`
+	Response  types.String `tfsdk:"response"`
+}
+
+// Runs the prediction described by the model and stores the response, failing on any non 2xx status.
+func (m *PredictModel) run(ctx context.Context, client *opensearchapi.Client) error {
+	status, body, err := predict(ctx, client, m.ModelID.ValueString(), m.Algorithm.ValueString(), []byte(m.Input.ValueString()))
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	m.Response = types.StringValue(string(body))
+
+	tflog.Trace(ctx, "ran prediction", map[string]any{
+		"model_id": m.ModelID.ValueString(),
+	})
+
+	return nil
+}
+
+// Describes the cost of running predictions, shared by the data source and ephemeral resource.
+const predictCostDescription = "The prediction runs every time Terraform reads it, i.e. on every plan and apply. " +
+	"For remote models each run is a call to the external service, which is billed and adds its latency to the run."
@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PredictBatchDataSource{}
+
+// NewPredictBatchDataSource is a helper function to simplify the provider implementation.
+func NewPredictBatchDataSource() datasource.DataSource {
+	return &PredictBatchDataSource{}
+}
+
+// PredictBatchDataSource is the data source implementation.
+type PredictBatchDataSource struct {
+	providerData *ProviderData
+}
+
+// PredictBatchModel describes the Predict Batch data source data model.
+type PredictBatchModel struct {
+	Requests []PredictBatchRequestModel `tfsdk:"requests"`
+	Results  []PredictBatchResultModel  `tfsdk:"results"`
+}
+
+// PredictBatchRequestModel describes a single prediction in the batch.
+type PredictBatchRequestModel struct {
+	ModelID   types.String `tfsdk:"model_id"`
+	Algorithm types.String `tfsdk:"algorithm"`
+	Input     types.String `tfsdk:"input"`
+}
+
+// PredictBatchResultModel describes the outcome of a single prediction in the batch.
+type PredictBatchResultModel struct {
+	ModelID    types.String `tfsdk:"model_id"`
+	Success    types.Bool   `tfsdk:"success"`
+	StatusCode types.Int64  `tfsdk:"status_code"`
+	Response   types.String `tfsdk:"response"`
+	Error      types.String `tfsdk:"error"`
+}
+
+// Metadata returns the data source type name.
+func (d *PredictBatchDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_predict_batch", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Predict Batch data source.
+func (d *PredictBatchDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs a batch of predictions to validate deployed models. " +
+			"Failed predictions are reported per model in `results` rather than failing the data source.",
+
+		Attributes: map[string]schema.Attribute{
+			"requests": schema.ListNestedAttribute{
+				MarkdownDescription: "Predictions to run, in order.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"model_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the model to predict with.",
+							Required:            true,
+						},
+						"algorithm": schema.StringAttribute{
+							MarkdownDescription: "Algorithm for the `/_plugins/_ml/_predict/<algorithm>/<model_id>` endpoint, e.g. `text_embedding`. " +
+								"When unset `/_plugins/_ml/models/<model_id>/_predict` is used.",
+							Optional: true,
+						},
+						"input": schema.StringAttribute{
+							MarkdownDescription: "A JSON payload with the sample input sent to the model.",
+							Required:            true,
+						},
+					},
+				},
+			},
+			"results": schema.ListNestedAttribute{
+				MarkdownDescription: "Outcome of each prediction, in the same order as `requests`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"model_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the model.",
+							Computed:            true,
+						},
+						"success": schema.BoolAttribute{
+							MarkdownDescription: "Whether the prediction succeeded.",
+							Computed:            true,
+						},
+						"status_code": schema.Int64Attribute{
+							MarkdownDescription: "HTTP status returned by OpenSearch, 0 if the request could not be sent.",
+							Computed:            true,
+						},
+						"response": schema.StringAttribute{
+							MarkdownDescription: "The JSON response returned by OpenSearch.",
+							Computed:            true,
+						},
+						"error": schema.StringAttribute{
+							MarkdownDescription: "Why the prediction failed, empty on success.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (d *PredictBatchDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (d *PredictBatchDataSource) client() (*opensearchapi.Client, error) {
+	return d.providerData.client()
+}
+
+// Read runs each prediction and records its outcome.
+func (d *PredictBatchDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PredictBatchModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	data.Results = make([]PredictBatchResultModel, 0, len(data.Requests))
+
+	for _, request := range data.Requests {
+		result := PredictBatchResultModel{
+			ModelID:  request.ModelID,
+			Success:  types.BoolValue(false),
+			Response: types.StringValue(""),
+			Error:    types.StringValue(""),
+		}
+
+		status, body, err := predict(ctx, client, request.ModelID.ValueString(), request.Algorithm.ValueString(), []byte(request.Input.ValueString()))
+
+		result.StatusCode = types.Int64Value(int64(status))
+
+		switch {
+		case err != nil:
+			result.Error = types.StringValue(err.Error())
+		case status < 200 || status >= 300:
+			result.Response = types.StringValue(string(body))
+			result.Error = types.StringValue(fmt.Sprintf("OpenSearch returned %d", status))
+		default:
+			result.Success = types.BoolValue(true)
+			result.Response = types.StringValue(string(body))
+		}
+
+		tflog.Trace(ctx, "ran batch prediction", map[string]any{
+			"model_id": request.ModelID.ValueString(),
+			"success":  result.Success.ValueBool(),
+		})
+
+		data.Results = append(data.Results, result)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
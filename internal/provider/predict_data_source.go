@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &PredictDataSource{}
+
+// NewPredictDataSource is a helper function to simplify the provider implementation.
+func NewPredictDataSource() datasource.DataSource {
+	return &PredictDataSource{}
+}
+
+// PredictDataSource is the data source implementation.
+type PredictDataSource struct {
+	providerData *ProviderData
+}
+
+// Metadata returns the data source type name.
+func (d *PredictDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_predict", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Predict data source.
+func (d *PredictDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs a prediction with a model, e.g. to fetch embeddings or a completion. " + predictCostDescription + " " +
+			"The response is stored in state, use the `opensearch_predict` ephemeral resource instead to keep it out of state.",
+
+		Attributes: map[string]schema.Attribute{
+			"model_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the model to predict with.",
+				Required:            true,
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Algorithm for the `/_plugins/_ml/_predict/<algorithm>/<model_id>` endpoint, e.g. `text_embedding`. " +
+					"When unset `/_plugins/_ml/models/<model_id>/_predict` is used.",
+				Optional: true,
+			},
+			"input": schema.StringAttribute{
+				MarkdownDescription: "A JSON payload sent to the model, e.g. `{\"parameters\": {\"inputs\": \"hello\"}}`.",
+				Required:            true,
+				CustomType:          JSONBodyType{},
+			},
+			"response": schema.StringAttribute{
+				MarkdownDescription: "The JSON response returned by OpenSearch.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (d *PredictDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (d *PredictDataSource) client() (*opensearchapi.Client, error) {
+	return d.providerData.client()
+}
+
+// Read runs the prediction.
+func (d *PredictDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PredictModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := data.run(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error running prediction",
+			fmt.Sprintf("Could not run prediction with model %s: %s", data.ModelID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &PredictEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &PredictEphemeralResource{}
+)
+
+// NewPredictEphemeralResource is a helper function to simplify the provider implementation.
+func NewPredictEphemeralResource() ephemeral.EphemeralResource {
+	return &PredictEphemeralResource{}
+}
+
+// PredictEphemeralResource is the ephemeral resource implementation.
+type PredictEphemeralResource struct {
+	providerData *ProviderData
+}
+
+// Metadata returns the ephemeral resource type name.
+func (e *PredictEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_predict", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Predict ephemeral resource.
+func (e *PredictEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs a prediction with a model without storing the response in state or plan, " +
+			"e.g. to pass a completion to a write-only attribute. " + predictCostDescription,
+
+		Attributes: map[string]schema.Attribute{
+			"model_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the model to predict with.",
+				Required:            true,
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "Algorithm for the `/_plugins/_ml/_predict/<algorithm>/<model_id>` endpoint, e.g. `text_embedding`. " +
+					"When unset `/_plugins/_ml/models/<model_id>/_predict` is used.",
+				Optional: true,
+			},
+			"input": schema.StringAttribute{
+				MarkdownDescription: "A JSON payload sent to the model, e.g. `{\"parameters\": {\"inputs\": \"hello\"}}`.",
+				Required:            true,
+				CustomType:          JSONBodyType{},
+			},
+			"response": schema.StringAttribute{
+				MarkdownDescription: "The JSON response returned by OpenSearch.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for the ephemeral resource.
+func (e *PredictEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	e.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (e *PredictEphemeralResource) client() (*opensearchapi.Client, error) {
+	return e.providerData.client()
+}
+
+// Open runs the prediction.
+func (e *PredictEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data PredictModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := e.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := data.run(ctx, client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error running prediction",
+			fmt.Sprintf("Could not run prediction with model %s: %s", data.ModelID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &PredictFunction{}
+
+// Limits how long the predict function waits for OpenSearch, as it runs synchronously while Terraform plans.
+const predictFunctionTimeout = 30 * time.Second
+
+// NewPredictFunction is a helper function to simplify the provider implementation.
+func NewPredictFunction(p *OpenSearchProvider) func() function.Function {
+	return func() function.Function {
+		return &PredictFunction{provider: p}
+	}
+}
+
+// PredictFunction is the function implementation. Functions aren't configured like resources, so the
+// provider is kept to read its data once it has been configured.
+type PredictFunction struct {
+	provider *OpenSearchProvider
+}
+
+// Metadata returns the function name.
+func (f *PredictFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "predict"
+}
+
+// Definition defines the parameters and return type of the predict function.
+func (f *PredictFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Runs a prediction with a text embedding model and returns the response.",
+		MarkdownDescription: "Runs a prediction with a model through `/_plugins/_ml/models/<model_id>/_predict` and returns the JSON response, " +
+			"e.g. to compute an embedding of a short text while planning. Use `jsondecode` to read values from it. " +
+			"Terraform fails the run when a function returns a different result at apply than it did while planning, " +
+			"so only text embedding models (`function_name` `TEXT_EMBEDDING`), which return the same embedding for the same input, are accepted. " +
+			"Use the `opensearch_predict` data source or ephemeral resource for other models. " +
+			fmt.Sprintf("The request fails after %s. ", predictFunctionTimeout) + predictCostDescription + " " +
+			"Terraform may call functions before configuring the provider, e.g. during `terraform validate`, in which case the call fails.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "model_id",
+				MarkdownDescription: "ID of the model to predict with.",
+			},
+			function.StringParameter{
+				Name:                "input_json",
+				MarkdownDescription: "A JSON payload sent to the model, e.g. `jsonencode({parameters = {inputs = \"hello\"}})`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run sends the input to the model and returns the response, failing on any non 2xx status and for models other than
+// text embedding models, whose results could change between plan and apply.
+func (f *PredictFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var modelID, input string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &modelID, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	if !json.Valid([]byte(input)) {
+		resp.Error = function.NewArgumentFuncError(1, "input_json must be valid JSON")
+		return
+	}
+
+	if f.provider == nil || f.provider.providerData == nil {
+		resp.Error = function.NewFuncError("The provider has not been configured yet, so predict can't reach OpenSearch. " +
+			"Terraform calls functions before configuring the provider, e.g. during terraform validate, only plans and applies can run a prediction.")
+		return
+	}
+
+	client, err := f.provider.providerData.client()
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, predictFunctionTimeout)
+	defer cancel()
+
+	model, exists, err := getModel(ctx, client, modelID)
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Could not read model %s: %s", modelID, err.Error()))
+		return
+	}
+
+	if !exists {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Model %s does not exist", modelID))
+		return
+	}
+
+	if !strings.EqualFold(model.FunctionName, skpropensearch.FunctionNameTextEmbedding) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf(
+			"Model %s is a %s model, predict only runs %s models as others can return a different result at apply than while planning. "+
+				"Use the opensearch_predict data source or ephemeral resource instead.",
+			modelID, model.FunctionName, skpropensearch.FunctionNameTextEmbedding))
+		return
+	}
+
+	status, body, err := predict(ctx, client, modelID, "", []byte(input))
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Could not run prediction with model %s: %s", modelID, err.Error()))
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Could not run prediction with model %s: OpenSearch returned %d: %s", modelID, status, string(body)))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, string(body)))
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPredictFunction(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/_plugins/_ml/models/embedding":
+			writeJSON(w, http.StatusOK, `{"function_name":"TEXT_EMBEDDING","model_state":"DEPLOYED"}`)
+		case "/_plugins/_ml/models/chat":
+			writeJSON(w, http.StatusOK, `{"function_name":"REMOTE","model_state":"DEPLOYED"}`)
+		case "/_plugins/_ml/models/embedding/_predict":
+			writeJSON(w, http.StatusOK, `{"inference_results":[]}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			writeJSON(w, http.StatusNotFound, `{}`)
+		}
+	})
+
+	tests := []struct {
+		name       string
+		provider   *OpenSearchProvider
+		modelID    string
+		wantError  string
+		wantResult string
+	}{
+		{
+			name:      "provider missing",
+			modelID:   "embedding",
+			wantError: "The provider has not been configured yet",
+		},
+		{
+			name:      "provider not configured",
+			provider:  &OpenSearchProvider{},
+			modelID:   "embedding",
+			wantError: "The provider has not been configured yet",
+		},
+		{
+			name:      "not a text embedding model",
+			provider:  &OpenSearchProvider{providerData: &ProviderData{Client: client}},
+			modelID:   "chat",
+			wantError: "Model chat is a REMOTE model",
+		},
+		{
+			name:       "text embedding model",
+			provider:   &OpenSearchProvider{providerData: &ProviderData{Client: client}},
+			modelID:    "embedding",
+			wantResult: `{"inference_results":[]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(tt.modelID),
+					types.StringValue(`{"text_docs":["hello world"]}`),
+				}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.StringUnknown()),
+			}
+
+			NewPredictFunction(tt.provider)().Run(ctx, req, resp)
+
+			if tt.wantError != "" {
+				if resp.Error == nil || !strings.Contains(resp.Error.Error(), tt.wantError) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantError, resp.Error)
+				}
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+
+			if result, ok := resp.Result.Value().(types.String); !ok || result.ValueString() != tt.wantResult {
+				t.Errorf("expected the result %s, got %v", tt.wantResult, resp.Result.Value())
+			}
+		})
+	}
+}
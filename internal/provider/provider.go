@@ -4,38 +4,224 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/opensearch-project/opensearch-go/v4"
 	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
 	requestsigner "github.com/opensearch-project/opensearch-go/v4/signer/awsv2"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
 )
 
 var _ provider.Provider = &OpenSearchProvider{}
 
 type OpenSearchProvider struct {
 	version string
+	// Set once the provider is configured, for functions which don't receive provider data.
+	providerData *ProviderData
 }
 
 // OpenSearchProviderModel describes the provider data model.
 type OpenSearchProviderModel struct {
-	Address    types.String `tfsdk:"address"`
-	Username   types.String `tfsdk:"username"`
-	Password   types.String `tfsdk:"password"`
-	Insecure   types.Bool   `tfsdk:"insecure"`
-	UseSigV4   types.Bool   `tfsdk:"use_sig_v4"`
-	Profile    types.String `tfsdk:"profile"`
-	Region     types.String `tfsdk:"region"`
-	AwsService types.String `tfsdk:"aws_service"`
+	Address          types.String `tfsdk:"address"`
+	Addresses        types.List   `tfsdk:"addresses"`
+	Username         types.String `tfsdk:"username"`
+	Password         types.String `tfsdk:"password"`
+	Token            types.String `tfsdk:"token"`
+	Insecure         types.Bool   `tfsdk:"insecure"`
+	CACertFile       types.String `tfsdk:"cacert_file"`
+	CACertPEM        types.String `tfsdk:"cacert_pem"`
+	TLSRenegotiation types.String `tfsdk:"tls_renegotiation"`
+	DisableHTTP2     types.Bool   `tfsdk:"disable_http2"`
+	UseSigV4         types.Bool   `tfsdk:"use_sig_v4"`
+	Profile          types.String `tfsdk:"profile"`
+	Region           types.String `tfsdk:"region"`
+	AwsService       types.String `tfsdk:"aws_service"`
+	MaxRetries       types.Int64  `tfsdk:"max_retries"`
+	RetryOnStatus    types.List   `tfsdk:"retry_on_status"`
+	RetryBaseDelay   types.String `tfsdk:"retry_base_delay"`
+	RetryWait        types.String `tfsdk:"retry_wait"`
+	ConnectTimeout   types.String `tfsdk:"connection_timeout"`
+	RequestTimeout   types.String `tfsdk:"request_timeout"`
+	Headers          types.Map    `tfsdk:"headers"`
+}
+
+// Defaults for retrying requests which OpenSearch could not serve.
+var (
+	defaultMaxRetries     = 3
+	defaultRetryOnStatus  = []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+	defaultRetryBaseDelay = time.Second
+	// Upper bound for a single backoff so exponential growth stays reasonable.
+	maxRetryDelay = 30 * time.Second
+)
+
+// Environment variables used when the matching provider attribute is not set, configuration always takes precedence.
+const (
+	envAddress    = "OPENSEARCH_ADDRESS"
+	envUsername   = "OPENSEARCH_USERNAME"
+	envPassword   = "OPENSEARCH_PASSWORD"
+	envToken      = "OPENSEARCH_TOKEN"
+	envInsecure   = "OPENSEARCH_INSECURE"
+	envCACertFile = "OPENSEARCH_CACERT_FILE"
+	envUseSigV4   = "OPENSEARCH_USE_SIG_V4"
+	envProfile    = "OPENSEARCH_PROFILE"
+	envRegion     = "OPENSEARCH_REGION"
+	envAwsService = "OPENSEARCH_AWS_SERVICE"
+)
+
+// Fills in attributes which are not set in configuration from the environment.
+func (m *OpenSearchProviderModel) applyEnvironment() error {
+	for _, v := range []struct {
+		value *types.String
+		env   string
+	}{
+		{&m.Address, envAddress},
+		{&m.Username, envUsername},
+		{&m.Password, envPassword},
+		{&m.Token, envToken},
+		{&m.CACertFile, envCACertFile},
+		{&m.Profile, envProfile},
+		{&m.Region, envRegion},
+		{&m.AwsService, envAwsService},
+	} {
+		if env, ok := os.LookupEnv(v.env); ok && v.value.IsNull() {
+			*v.value = types.StringValue(env)
+		}
+	}
+
+	for _, v := range []struct {
+		value *types.Bool
+		env   string
+	}{
+		{&m.Insecure, envInsecure},
+		{&m.UseSigV4, envUseSigV4},
+	} {
+		env, ok := os.LookupEnv(v.env)
+		if !ok || !v.value.IsNull() {
+			continue
+		}
+
+		parsed, err := strconv.ParseBool(env)
+		if err != nil {
+			return fmt.Errorf("invalid boolean value %q for %s", env, v.env)
+		}
+
+		*v.value = types.BoolValue(parsed)
+	}
+
+	return nil
+}
+
+// Returns the retry configuration, falling back to the defaults for anything not set.
+func (m OpenSearchProviderModel) retryConfig(ctx context.Context) (int, []int, time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	maxRetries := defaultMaxRetries
+	if !m.MaxRetries.IsNull() {
+		maxRetries = int(m.MaxRetries.ValueInt64())
+	}
+
+	retryOnStatus := defaultRetryOnStatus
+	if !m.RetryOnStatus.IsNull() {
+		diags.Append(m.RetryOnStatus.ElementsAs(ctx, &retryOnStatus, false)...)
+	}
+
+	// retry_wait is another name for retry_base_delay, only one of them can be set.
+	delayAttribute, delayValue := "retry_base_delay", m.RetryBaseDelay
+	if !m.RetryWait.IsNull() {
+		delayAttribute, delayValue = "retry_wait", m.RetryWait
+	}
+
+	retryBaseDelay := defaultRetryBaseDelay
+	if !delayValue.IsNull() {
+		delay, err := time.ParseDuration(delayValue.ValueString())
+		if err != nil || delay <= 0 {
+			diags.AddAttributeError(
+				path.Root(delayAttribute),
+				"Invalid retry base delay",
+				fmt.Sprintf("The %s %q must be a positive duration, e.g. \"1s\".", delayAttribute, delayValue.ValueString()),
+			)
+		} else {
+			retryBaseDelay = delay
+		}
+	}
+
+	return maxRetries, retryOnStatus, retryBaseDelay, diags
+}
+
+// Returns the connection and request timeouts, zero when they are not set.
+func (m OpenSearchProviderModel) timeoutConfig() (time.Duration, time.Duration, diag.Diagnostics) {
+	var (
+		diags                          diag.Diagnostics
+		connectTimeout, requestTimeout time.Duration
+	)
+
+	if !m.ConnectTimeout.IsNull() {
+		timeout, err := time.ParseDuration(m.ConnectTimeout.ValueString())
+		if err != nil || timeout <= 0 {
+			diags.AddAttributeError(
+				path.Root("connection_timeout"),
+				"Invalid connection timeout",
+				fmt.Sprintf("The connection_timeout %q must be a positive duration, e.g. \"10s\".", m.ConnectTimeout.ValueString()),
+			)
+		}
+
+		connectTimeout = timeout
+	}
+
+	if !m.RequestTimeout.IsNull() {
+		timeout, err := time.ParseDuration(m.RequestTimeout.ValueString())
+		if err != nil || timeout <= 0 {
+			diags.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid request timeout",
+				fmt.Sprintf("The request_timeout %q must be a positive duration, e.g. \"5m\".", m.RequestTimeout.ValueString()),
+			)
+		}
+
+		requestTimeout = timeout
+	}
+
+	if !diags.HasError() && connectTimeout > 0 && requestTimeout > 0 && connectTimeout > requestTimeout {
+		diags.AddAttributeError(
+			path.Root("connection_timeout"),
+			"Invalid connection timeout",
+			fmt.Sprintf("The connection_timeout %s can't be longer than the request_timeout %s, which includes connecting.", connectTimeout, requestTimeout),
+		)
+	}
+
+	return connectTimeout, requestTimeout, diags
+}
+
+// Returns the exponential backoff for the given retry attempt, starting at 1.
+func retryBackoff(base time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryDelay)
 }
 
 func (p *OpenSearchProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -47,38 +233,135 @@ func (p *OpenSearchProvider) Schema(ctx context.Context, req provider.SchemaRequ
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"address": schema.StringAttribute{
-				MarkdownDescription: "The OpenSearch address",
-				Required:            true,
+				MarkdownDescription: "The OpenSearch address. Can also be set with the `OPENSEARCH_ADDRESS` environment variable.",
+				Optional:            true,
+			},
+			"addresses": schema.ListAttribute{
+				MarkdownDescription: "Several OpenSearch addresses, e.g. the coordinating nodes of a cluster, which requests are spread across round-robin. " +
+					"Conflicts with `address`, and takes precedence over the `OPENSEARCH_ADDRESS` environment variable.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+					listvalidator.ConflictsWith(path.MatchRoot("address")),
+				},
 			},
 			"username": schema.StringAttribute{
-				MarkdownDescription: "The OpenSearch username",
+				MarkdownDescription: "The OpenSearch username. Can also be set with the `OPENSEARCH_USERNAME` environment variable.",
 				Optional:            true,
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "The OpenSearch password",
+				MarkdownDescription: "The OpenSearch password. Can also be set with the `OPENSEARCH_PASSWORD` environment variable.",
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Bearer token sent as the `Authorization` header of every request, e.g. for a gateway in front of OpenSearch. " +
+					"Conflicts with `username`, `password` and `use_sig_v4`. Can also be set with the `OPENSEARCH_TOKEN` environment variable.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("username"), path.MatchRoot("password")),
+				},
+			},
 			"insecure": schema.BoolAttribute{
-				MarkdownDescription: "Whether to skip TLS verification",
+				MarkdownDescription: "Whether to skip TLS verification. Can also be set with the `OPENSEARCH_INSECURE` environment variable.",
 				Optional:            true,
 			},
+			"cacert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM file with the CA certificates to verify OpenSearch with, e.g. for a private CA. " +
+					"Only these certificates are trusted. Can also be set with the `OPENSEARCH_CACERT_FILE` environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("cacert_pem"), path.MatchRoot("insecure")),
+				},
+			},
+			"cacert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates to verify OpenSearch with, as an alternative to `cacert_file`. Only these certificates are trusted.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("insecure")),
+				},
+			},
+			"tls_renegotiation": schema.StringAttribute{
+				MarkdownDescription: "Whether OpenSearch may renegotiate TLS: `never` (the default), `once` or `freely`. " +
+					"Only needed behind servers or load balancers which ask for a client certificate after the TLS 1.2 handshake, " +
+					"which fails with `tls: no renegotiation` otherwise. TLS 1.3 has no renegotiation.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("never", "once", "freely"),
+				},
+			},
+			"disable_http2": schema.BoolAttribute{
+				MarkdownDescription: "Whether to use HTTP/1.1 even when OpenSearch offers HTTP/2. " +
+					"Only needed behind load balancers or proxies which offer HTTP/2 but mishandle it, e.g. by resetting streams or closing connections mid request.",
+				Optional: true,
+			},
 			"use_sig_v4": schema.BoolAttribute{
-				MarkdownDescription: "Whether to use AWS SigV4 signing for requests",
+				MarkdownDescription: "Whether to use AWS SigV4 signing for requests. Can also be set with the `OPENSEARCH_USE_SIG_V4` environment variable.",
 				Optional:            true,
 			},
 			"region": schema.StringAttribute{
-				MarkdownDescription: "The AWS region for SigV4 signing",
+				MarkdownDescription: "The AWS region for SigV4 signing. Can also be set with the `OPENSEARCH_REGION` environment variable.",
 				Optional:            true,
 			},
 			"profile": schema.StringAttribute{
-				MarkdownDescription: "The AWS profile to use from the shared credentials file",
+				MarkdownDescription: "The AWS profile to use from the shared credentials file. Can also be set with the `OPENSEARCH_PROFILE` environment variable.",
 				Optional:            true,
 			},
 			"aws_service": schema.StringAttribute{
-				MarkdownDescription: "The AWS service name for SigV4 signing (e.g., 'es' for OpenSearch Service, 'aoss' for OpenSearch Serverless)",
+				MarkdownDescription: "The AWS service name for SigV4 signing (e.g., 'es' for OpenSearch Service, 'aoss' for OpenSearch Serverless). Can also be set with the `OPENSEARCH_AWS_SERVICE` environment variable.",
 				Optional:            true,
 			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of times a request is retried after a retryable status or network error. Defaults to `3`, `0` disables retries. " +
+					"Terraform itself does not retry failed provider operations, so this is the only retry applied to requests.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 10),
+				},
+			},
+			"retry_on_status": schema.ListAttribute{
+				MarkdownDescription: "HTTP status codes which are retried. Defaults to `[429, 502, 503, 504]`. " +
+					"Requests which are not idempotent, such as POST, are only retried on `429` and `503`, " +
+					"as after other statuses OpenSearch may have processed them already.",
+				Optional:    true,
+				ElementType: types.Int64Type,
+				Validators: []validator.List{
+					listvalidator.ValueInt64sAre(int64validator.Between(400, 599)),
+				},
+			},
+			"retry_base_delay": schema.StringAttribute{
+				MarkdownDescription: "Delay before the first retry as a duration (e.g. `500ms`, `2s`), doubled for each further retry up to 30s. " +
+					"A `Retry-After` header on the response takes precedence. Defaults to `1s`.",
+				Optional: true,
+			},
+			"retry_wait": schema.StringAttribute{
+				MarkdownDescription: "Another name for `retry_base_delay`, i.e. the wait before the first retry, which doubles for each further retry. " +
+					"Only one of the two can be set.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("retry_base_delay")),
+				},
+			},
+			"connection_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for a connection to OpenSearch to be established as a duration (e.g. `5s`), so an unreachable endpoint fails fast. Defaults to `30s`.",
+				Optional:            true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long a single request may take, including connecting and reading the response, as a duration (e.g. `5m`). " +
+					"Each retry gets its own timeout. Polling for ML tasks is a series of requests, so this doesn't limit how long a model takes to register. " +
+					"Defaults to no timeout.",
+				Optional: true,
+			},
+			"headers": schema.MapAttribute{
+				MarkdownDescription: "Headers added to every request, e.g. `X-Tenant-ID` for a proxy. " +
+					"They are added before SigV4 signing, so they are covered by the signature like every other header (AWS never signs `User-Agent`). " +
+					"Headers the provider or signing sets itself (`Authorization`, `Host`, `Content-Type`, `Accept`, `Content-Length`, `User-Agent` and `X-Amz-*`) can't be set.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 		},
 	}
 }
@@ -91,17 +374,158 @@ func (p *OpenSearchProvider) Configure(ctx context.Context, req provider.Configu
 		return
 	}
 
+	// An address taken from a resource which is not created yet is unknown until apply, and can't be connected to.
+	if data.Address.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("address"),
+			"Unknown OpenSearch address",
+			"The provider can't connect to OpenSearch as the address is unknown until apply. Apply the resource it comes from first with -target, "+
+				fmt.Sprintf("set the address statically, or use the %s environment variable.", envAddress),
+		)
+	}
+
+	if data.Addresses.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("addresses"),
+			"Unknown OpenSearch addresses",
+			"The provider can't connect to OpenSearch as the addresses are unknown until apply. Apply the resources they come from first with -target, "+
+				"or set the addresses statically.",
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.applyEnvironment(); err != nil {
+		resp.Diagnostics.AddError("Invalid provider environment", err.Error())
+		return
+	}
+
+	addresses := []string{data.Address.ValueString()}
+	if !data.Addresses.IsNull() {
+		resp.Diagnostics.Append(data.Addresses.ElementsAs(ctx, &addresses, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if data.Addresses.IsNull() && data.Address.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("address"),
+			"Missing OpenSearch address",
+			fmt.Sprintf("Set the address or addresses attribute or the %s environment variable.", envAddress),
+		)
+		return
+	}
+
+	// Checked again here as the environment can set credentials too.
+	if data.Token.ValueString() != "" && (data.Username.ValueString() != "" || data.Password.ValueString() != "" || data.UseSigV4.ValueBool()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token"),
+			"Conflicting authentication",
+			"A token can't be used together with a username and password or SigV4 signing, as each sets the Authorization header.",
+		)
+		return
+	}
+
+	// The client spreads requests across every address, retrying failed ones against the next.
 	config := opensearch.Config{
-		Addresses: []string{data.Address.ValueString()},
+		Addresses: addresses,
+	}
+
+	// Global headers are set on each request before it is signed, so SigV4 signatures cover them.
+	if !data.Headers.IsNull() {
+		var headers map[string]string
+
+		resp.Diagnostics.Append(data.Headers.ElementsAs(ctx, &headers, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		config.Header = http.Header{}
+
+		for name, value := range headers {
+			if isReservedRequestHeader(name) {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("headers"),
+					"Reserved header",
+					fmt.Sprintf("The %s header is set by the provider and can't be overridden.", name),
+				)
+				continue
+			}
+
+			config.Header.Set(name, value)
+		}
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tlsConfig, err := data.tlsConfig()
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid CA certificate", err.Error())
+		return
+	}
+
+	connectTimeout, requestTimeout, diags := data.timeoutConfig()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if tlsConfig != nil || connectTimeout > 0 || data.DisableHTTP2.ValueBool() {
+		// Cloned so proxy settings and the other defaults still apply.
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+
+		if tlsConfig != nil {
+			transport.TLSClientConfig = tlsConfig
+		}
+
+		// An empty, non-nil TLSNextProto stops HTTP/2 being negotiated, even if the default transport already set it up.
+		if data.DisableHTTP2.ValueBool() {
+			transport.ForceAttemptHTTP2 = false
+			transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		}
+
+		if connectTimeout > 0 {
+			dialer := &net.Dialer{
+				Timeout:   connectTimeout,
+				KeepAlive: 30 * time.Second,
+			}
+
+			transport.DialContext = dialer.DialContext
+			transport.TLSHandshakeTimeout = connectTimeout
+		}
+
+		config.Transport = transport
 	}
 
-	if data.Insecure.ValueBool() {
-		config.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, // For testing only. Use certificate for validation.
+	// Innermost, so every attempt (and every SigV4 credential refresh) gets its own timeout.
+	if requestTimeout > 0 {
+		base := config.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+
+		config.Transport = &requestTimeoutTransport{
+			base:    base,
+			timeout: requestTimeout,
 		}
 	}
 
-	if !data.UseSigV4.ValueBool() {
+	if data.Token.ValueString() != "" {
+		base := config.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+
+		config.Transport = &bearerTokenTransport{
+			base:  base,
+			token: data.Token.ValueString(),
+		}
+	} else if !data.UseSigV4.ValueBool() {
 		config.Username = data.Username.ValueString()
 		config.Password = data.Password.ValueString()
 	} else {
@@ -110,12 +534,23 @@ func (p *OpenSearchProvider) Configure(ctx context.Context, req provider.Configu
 			err       error
 		)
 
-		if data.Profile.IsNull() {
-			awsConfig, err = awsconfig.LoadDefaultConfig(ctx)
-		} else {
-			awsConfig, err = awsconfig.LoadDefaultConfig(ctx, awsconfig.WithSharedConfigProfile(data.Profile.ValueString()))
+		// Refresh temporary credentials well before they expire so long running polls keep signing with valid ones.
+		awsOptions := []func(*awsconfig.LoadOptions) error{
+			awsconfig.WithCredentialsCacheOptions(func(o *aws.CredentialsCacheOptions) {
+				o.ExpiryWindow = 5 * time.Minute
+			}),
+		}
+
+		if !data.Profile.IsNull() {
+			awsOptions = append(awsOptions, awsconfig.WithSharedConfigProfile(data.Profile.ValueString()))
 		}
 
+		if !data.Region.IsNull() {
+			awsOptions = append(awsOptions, awsconfig.WithRegion(data.Region.ValueString()))
+		}
+
+		awsConfig, err = awsconfig.LoadDefaultConfig(ctx, awsOptions...)
+
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get AWS config, got error: %s", err))
 			return
@@ -136,34 +571,184 @@ func (p *OpenSearchProvider) Configure(ctx context.Context, req provider.Configu
 		}
 
 		config.Signer = signer
+
+		// Credentials can still expire between retrieval and use, so retry once with fresh ones when they do.
+		if credentials, ok := awsConfig.Credentials.(*aws.CredentialsCache); ok {
+			base := config.Transport
+			if base == nil {
+				base = http.DefaultTransport
+			}
+
+			config.Transport = &sigV4RefreshTransport{
+				base:        base,
+				signer:      signer,
+				credentials: credentials,
+			}
+		}
+	}
+
+	maxRetries, retryOnStatus, retryBaseDelay, diags := data.retryConfig(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// opensearch-go retries network errors, statuses are retried by statusRetryTransport so Retry-After is honoured.
+	config.MaxRetries = maxRetries
+	config.RetryOnStatus = []int{}
+	config.DisableRetry = maxRetries == 0
+	config.RetryBackoff = func(attempt int) time.Duration {
+		return retryBackoff(retryBaseDelay, attempt)
+	}
+
+	if maxRetries > 0 && len(retryOnStatus) > 0 {
+		base := config.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+
+		config.Transport = &statusRetryTransport{
+			base:          base,
+			maxRetries:    maxRetries,
+			retryOnStatus: retryOnStatus,
+			baseDelay:     retryBaseDelay,
+			signer:        config.Signer,
+		}
 	}
 
 	apiconfig := opensearchapi.Config{
 		Client: config,
 	}
 
-	resp.DataSourceData = apiconfig
-	resp.ResourceData = apiconfig
+	// Built once so every resource reuses the same transport and TLS setup.
+	client, err := opensearchapi.NewClient(apiconfig)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	providerData := &ProviderData{
+		Config:     apiconfig,
+		Client:     client,
+		Serverless: data.AwsService.ValueString() == "aoss" || isServerless(apiconfig),
+	}
+
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+	resp.EphemeralResourceData = providerData
+	p.providerData = providerData
+}
+
+// ProviderData is shared with data sources and resources when the provider is configured.
+type ProviderData struct {
+	Config opensearchapi.Config
+	Client *opensearchapi.Client
+	// Whether the provider is pointed at an OpenSearch Serverless (aoss) collection, by aws_service or address.
+	Serverless bool
+}
+
+// Returns the OpenSearch client built when the provider was configured.
+func (d *ProviderData) client() (*opensearchapi.Client, error) {
+	if d == nil || d.Client == nil {
+		return nil, fmt.Errorf("the provider has not been configured")
+	}
+
+	return d.Client, nil
 }
 
 func (p *OpenSearchProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewModelGroupResource,
 		NewConnectorResource,
+		NewBedrockConnectorResource,
+		NewOpenAIConnectorResource,
 		NewModelRegisterResource,
+		NewModelDeployResource,
+		NewAgentResource,
+		NewScriptStoredSearchTemplateResource,
+		NewSnapshotResource,
+		NewSnapshotRepositoryResource,
+		NewSnapshotPolicyResource,
+		NewAliasResource,
+		NewIndexResource,
+		NewIndexTemplateResource,
+		NewComponentTemplateResource,
+		NewDataStreamResource,
+		NewIndexForceMergeResource,
+		NewISMPolicyResource,
+		NewIngestPipelineResource,
+		NewSearchPipelineResource,
+		NewAlertingMonitorResource,
+		NewRoleResource,
+		NewRoleMappingResource,
+		NewInternalUserResource,
+		NewTenantResource,
+		NewClusterSettingsResource,
 	}
 }
 
 func (p *OpenSearchProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
-	return []func() ephemeral.EphemeralResource{}
+	return []func() ephemeral.EphemeralResource{
+		NewPredictEphemeralResource,
+		NewAgentExecuteEphemeralResource,
+	}
 }
 
 func (p *OpenSearchProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewHealthDataSource,
+		NewPredictDataSource,
+		NewPredictBatchDataSource,
+		NewMLStatsDataSource,
+		NewModelDataSource,
+		NewConnectorDataSource,
+		NewModelGroupDataSource,
+		NewIndexDataSource,
+	}
 }
 
 func (p *OpenSearchProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewPredictFunction(p),
+	}
+}
+
+// Returns an error when the given resource or data source type can't be used with the OpenSearch Serverless
+// collection the provider is configured for, see skpropensearch.ServerlessUnsupportedReason.
+func (d *ProviderData) serverlessDiagnostics(typeName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if d == nil || !d.Serverless {
+		return diags
+	}
+
+	if reason := skpropensearch.ServerlessUnsupportedReason(typeName); reason != "" {
+		diags.AddError(
+			fmt.Sprintf("%s is not supported by OpenSearch Serverless", typeName),
+			fmt.Sprintf("%s See %s for the APIs Serverless collections support.", reason, skpropensearch.ServerlessLimitationsURL),
+		)
+	}
+
+	return diags
+}
+
+// Reports whether the client is pointed at an OpenSearch Serverless (aoss) collection endpoint.
+func isServerless(config opensearchapi.Config) bool {
+	for _, address := range config.Client.Addresses {
+		u, err := url.Parse(address)
+		if err != nil {
+			continue
+		}
+
+		if strings.HasSuffix(u.Hostname(), ".aoss.amazonaws.com") {
+			return true
+		}
+	}
+
+	return false
 }
 
 func NewOpenSearchProvider(version string) func() provider.Provider {
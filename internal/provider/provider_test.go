@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// Returns a client for a stand-in OpenSearch serving the handler, after applying the configure functions to its config.
+func newTestClient(t *testing.T, handler http.HandlerFunc, configure ...func(*opensearch.Config)) *opensearchapi.Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config := opensearch.Config{
+		Addresses:    []string{server.URL},
+		DisableRetry: true,
+	}
+
+	for _, fn := range configure {
+		fn(&config)
+	}
+
+	client, err := opensearchapi.NewClient(opensearchapi.Config{Client: config})
+	if err != nil {
+		t.Fatalf("could not create client: %s", err)
+	}
+
+	return client
+}
+
+// Writes the JSON body with the given status.
+func writeJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(body))
+}
+
+func TestRetryConfigBaseDelay(t *testing.T) {
+	tests := []struct {
+		name           string
+		retryBaseDelay types.String
+		retryWait      types.String
+		want           time.Duration
+		wantErr        bool
+	}{
+		{
+			name: "default",
+			want: defaultRetryBaseDelay,
+		},
+		{
+			name:           "retry_base_delay",
+			retryBaseDelay: types.StringValue("500ms"),
+			want:           500 * time.Millisecond,
+		},
+		{
+			name:      "retry_wait",
+			retryWait: types.StringValue("2s"),
+			want:      2 * time.Second,
+		},
+		{
+			name:           "zero",
+			retryBaseDelay: types.StringValue("0s"),
+			want:           defaultRetryBaseDelay,
+			wantErr:        true,
+		},
+		{
+			name:      "invalid",
+			retryWait: types.StringValue("soon"),
+			want:      defaultRetryBaseDelay,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := OpenSearchProviderModel{RetryBaseDelay: tt.retryBaseDelay, RetryWait: tt.retryWait}
+
+			_, _, got, diags := data.retryConfig(context.Background())
+			if diags.HasError() != tt.wantErr {
+				t.Errorf("expected an error %t, got %v", tt.wantErr, diags)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected a base delay of %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConfigureUnknownAddress(t *testing.T) {
+	ctx := context.Background()
+
+	p := &OpenSearchProvider{}
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	tests := []struct {
+		name      string
+		attribute string
+	}{
+		{
+			name:      "address",
+			attribute: "address",
+		},
+		{
+			name:      "addresses",
+			attribute: "addresses",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+			for name, attributeType := range objectType.AttributeTypes {
+				attributes[name] = tftypes.NewValue(attributeType, nil)
+			}
+
+			attributes[tt.attribute] = tftypes.NewValue(objectType.AttributeTypes[tt.attribute], tftypes.UnknownValue)
+
+			req := provider.ConfigureRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)},
+			}
+			resp := &provider.ConfigureResponse{}
+
+			p.Configure(ctx, req, resp)
+
+			errs := resp.Diagnostics.Errors()
+			if len(errs) != 1 || !strings.HasPrefix(errs[0].Summary(), "Unknown OpenSearch address") {
+				t.Fatalf("expected an unknown address error, got %v", resp.Diagnostics)
+			}
+
+			if resp.ResourceData != nil || resp.DataSourceData != nil {
+				t.Error("expected the provider to be left unconfigured")
+			}
+		})
+	}
+}
+
+func TestProviderDataSharedClient(t *testing.T) {
+	ctx := context.Background()
+
+	var connections atomic.Int32
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{}`)
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+
+	p := &OpenSearchProvider{}
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	attributes["address"] = tftypes.NewValue(tftypes.String, server.URL)
+
+	configureResp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attributes)},
+	}, configureResp)
+
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", configureResp.Diagnostics)
+	}
+
+	providerData := configureResp.ResourceData.(*ProviderData)
+
+	var clients []*opensearchapi.Client
+
+	for _, newResource := range p.Resources(ctx) {
+		r := newResource()
+
+		var metadataResp resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "opensearch"}, &metadataResp)
+
+		withConfigure, ok := r.(resource.ResourceWithConfigure)
+		if !ok {
+			continue
+		}
+
+		resp := &resource.ConfigureResponse{}
+		withConfigure.Configure(ctx, resource.ConfigureRequest{ProviderData: configureResp.ResourceData}, resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("%s: unexpected error: %v", metadataResp.TypeName, resp.Diagnostics)
+		}
+
+		client, err := r.(interface {
+			client() (*opensearchapi.Client, error)
+		}).client()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", metadataResp.TypeName, err)
+		}
+
+		if client != providerData.Client {
+			t.Errorf("%s: expected the client built by the provider, got another one", metadataResp.TypeName)
+		}
+
+		clients = append(clients, client)
+	}
+
+	if len(clients) < 2 {
+		t.Fatalf("expected several resources to be configured, got %d", len(clients))
+	}
+
+	// Requests from each resource go through the one transport, so they reuse its connection.
+	for _, client := range clients {
+		if _, _, err := performJSONRequest(ctx, client, "GET", "/", nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if got := connections.Load(); got != 1 {
+		t.Errorf("expected the resources to share one connection, got %d", got)
+	}
+}
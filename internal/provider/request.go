@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// Sends a request with an optional JSON body, returning the response status and body.
+// Any status is returned as is, callers decide which ones are errors.
+func performJSONRequest(ctx context.Context, client *opensearchapi.Client, method, path string, body []byte) (int, []byte, error) {
+	return performRequest(ctx, client, method, path, "application/json", body)
+}
+
+// Sends a request with an optional body of the given content type, expecting a JSON response.
+func performRequest(ctx context.Context, client *opensearchapi.Client, method, path, contentType string, body []byte) (int, []byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, path, reader)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(req)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		return httpResp.StatusCode, nil, err
+	}
+
+	return httpResp.StatusCode, respBody, nil
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v4/signer"
+)
+
+// Longest Retry-After the provider waits for, responses asking for longer fail instead of holding up the run.
+const maxRetryAfter = 5 * time.Minute
+
+// Statuses which mean OpenSearch refused the request without processing it. Only these are retried for requests which
+// are not idempotent, e.g. POST _register, as after a 502 or 504 the first attempt may have created the object already.
+var unprocessedStatuses = []int{http.StatusTooManyRequests, http.StatusServiceUnavailable}
+
+// statusRetryTransport retries responses with a retryable status, waiting exactly as long as their Retry-After
+// header asks (as Amazon OpenSearch Service sends when throttling) and otherwise backing off exponentially.
+// Status retries happen here rather than in opensearch-go, as its backoff function can't see the response.
+type statusRetryTransport struct {
+	base          http.RoundTripper
+	maxRetries    int
+	retryOnStatus []int
+	baseDelay     time.Duration
+	// Signs retries again when SigV4 is used, so their signature date stays current.
+	signer signer.Signer
+}
+
+// RoundTrip executes the request, retrying it while OpenSearch responds with a retryable status.
+func (t *statusRetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+
+	for attempt := 1; attempt <= t.maxRetries; attempt++ {
+		if err != nil || !t.retryable(req, resp.StatusCode) {
+			return resp, err
+		}
+
+		// The request can't be sent again without a way to replay its body.
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, nil
+		}
+
+		delay, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if !ok {
+			delay = retryBackoff(t.baseDelay, attempt)
+		}
+
+		if delay > maxRetryAfter {
+			return resp, nil
+		}
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		retry := req.Clone(req.Context())
+
+		if req.GetBody != nil {
+			retry.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if t.signer != nil {
+			if err := t.signer.SignRequest(retry); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err = t.base.RoundTrip(retry)
+	}
+
+	return resp, err
+}
+
+// Reports whether a response with the status is retried, which for requests which are not idempotent is only when
+// OpenSearch did not process them.
+func (t *statusRetryTransport) retryable(req *http.Request, status int) bool {
+	if !slices.Contains(t.retryOnStatus, status) {
+		return false
+	}
+
+	return req.Method == http.MethodGet || req.Method == http.MethodHead || req.Method == http.MethodOptions ||
+		req.Method == http.MethodPut || req.Method == http.MethodDelete || slices.Contains(unprocessedStatuses, status)
+}
+
+// Returns how long a Retry-After header value asks to wait, given either as seconds or as an HTTP date.
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{
+			name:   "seconds",
+			value:  "120",
+			want:   2 * time.Minute,
+			wantOK: true,
+		},
+		{
+			name:   "zero seconds",
+			value:  "0",
+			wantOK: true,
+		},
+		{
+			name:   "seconds with whitespace",
+			value:  " 3 ",
+			want:   3 * time.Second,
+			wantOK: true,
+		},
+		{
+			name:   "HTTP date",
+			value:  "Fri, 01 Mar 2024 12:00:30 GMT",
+			want:   30 * time.Second,
+			wantOK: true,
+		},
+		{
+			name:   "obsolete HTTP date",
+			value:  "Friday, 01-Mar-24 12:01:00 GMT",
+			want:   time.Minute,
+			wantOK: true,
+		},
+		{
+			name:   "HTTP date in the past",
+			value:  "Fri, 01 Mar 2024 11:59:00 GMT",
+			wantOK: true,
+		},
+		{
+			name:  "negative seconds",
+			value: "-5",
+		},
+		{
+			name:  "empty",
+			value: "",
+		},
+		{
+			name:  "invalid",
+			value: "soon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := retryAfter(tt.value, now)
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok %t, got %t", tt.wantOK, ok)
+			}
+
+			if got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestStatusRetryTransport(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		status       int
+		retryAfter   string
+		wantRequests int32
+		wantStatus   int
+	}{
+		{
+			name:         "retry after seconds",
+			retryAfter:   "0",
+			wantRequests: 2,
+			wantStatus:   http.StatusOK,
+		},
+		{
+			name:         "retry after HTTP date",
+			retryAfter:   time.Now().Add(-time.Second).UTC().Format(http.TimeFormat),
+			wantRequests: 2,
+			wantStatus:   http.StatusOK,
+		},
+		{
+			name:         "retry after backoff",
+			wantRequests: 2,
+			wantStatus:   http.StatusOK,
+		},
+		{
+			name:         "retry after too long",
+			retryAfter:   "3600",
+			wantRequests: 1,
+			wantStatus:   http.StatusTooManyRequests,
+		},
+		{
+			name:         "POST not retried on bad gateway",
+			status:       http.StatusBadGateway,
+			wantRequests: 1,
+			wantStatus:   http.StatusBadGateway,
+		},
+		{
+			name:         "POST not retried on gateway timeout",
+			status:       http.StatusGatewayTimeout,
+			wantRequests: 1,
+			wantStatus:   http.StatusGatewayTimeout,
+		},
+		{
+			name:         "POST retried on service unavailable",
+			status:       http.StatusServiceUnavailable,
+			wantRequests: 2,
+			wantStatus:   http.StatusOK,
+		},
+		{
+			name:         "PUT retried on bad gateway",
+			method:       http.MethodPut,
+			status:       http.StatusBadGateway,
+			wantRequests: 2,
+			wantStatus:   http.StatusOK,
+		},
+		{
+			name:         "GET retried on gateway timeout",
+			method:       http.MethodGet,
+			status:       http.StatusGatewayTimeout,
+			wantRequests: 2,
+			wantStatus:   http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+
+			status := tt.status
+			if status == 0 {
+				status = http.StatusTooManyRequests
+			}
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests.Add(1) == 1 {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+
+					writeJSON(w, status, `{"message":"`+http.StatusText(status)+`"}`)
+					return
+				}
+
+				writeJSON(w, http.StatusOK, `{}`)
+			}))
+			t.Cleanup(server.Close)
+
+			transport := &statusRetryTransport{
+				base:          http.DefaultTransport,
+				maxRetries:    3,
+				retryOnStatus: defaultRetryOnStatus,
+				baseDelay:     time.Millisecond,
+			}
+
+			req, err := http.NewRequest(method, server.URL, strings.NewReader(`{}`))
+			if err != nil {
+				t.Fatalf("could not create request: %s", err)
+			}
+
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, resp.StatusCode)
+			}
+
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("expected %d requests, got %d", tt.wantRequests, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,510 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &RoleResource{}
+	_ resource.ResourceWithImportState = &RoleResource{}
+)
+
+// NewRoleResource is a helper function to simplify the provider implementation.
+func NewRoleResource() resource.Resource {
+	return &RoleResource{}
+}
+
+// RoleResource is the resource implementation.
+type RoleResource struct {
+	providerData *ProviderData
+}
+
+// RoleModel describes the Role resource data model.
+type RoleModel struct {
+	ID                 types.String                `tfsdk:"id"`
+	Name               types.String                `tfsdk:"name"`
+	Description        types.String                `tfsdk:"description"`
+	ClusterPermissions []types.String              `tfsdk:"cluster_permissions"`
+	IndexPermissions   []RoleIndexPermissionModel  `tfsdk:"index_permissions"`
+	TenantPermissions  []RoleTenantPermissionModel `tfsdk:"tenant_permissions"`
+}
+
+// RoleIndexPermissionModel describes the permissions a role has on the indices matching its patterns.
+type RoleIndexPermissionModel struct {
+	IndexPatterns  []types.String `tfsdk:"index_patterns"`
+	AllowedActions []types.String `tfsdk:"allowed_actions"`
+	DLS            types.String   `tfsdk:"dls"`
+	FLS            []types.String `tfsdk:"fls"`
+	MaskedFields   []types.String `tfsdk:"masked_fields"`
+}
+
+// RoleTenantPermissionModel describes the permissions a role has on the tenants matching its patterns.
+type RoleTenantPermissionModel struct {
+	TenantPatterns []types.String `tfsdk:"tenant_patterns"`
+	AllowedActions []types.String `tfsdk:"allowed_actions"`
+}
+
+// Metadata returns the resource type name.
+func (r *RoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_role", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Role resource.
+func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a role of the security plugin. Reserved, static and hidden roles, e.g. `all_access`, can't be managed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the role.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the role.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the role.",
+				Optional:            true,
+			},
+			"cluster_permissions": schema.ListAttribute{
+				MarkdownDescription: "Cluster wide permissions and action groups, e.g. `cluster_monitor` or `cluster:admin/opensearch/ml/predict`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"index_permissions": schema.ListNestedAttribute{
+				MarkdownDescription: "Permissions on the indices matching each set of patterns.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"index_patterns": schema.ListAttribute{
+							MarkdownDescription: "Index names or patterns, e.g. `logs-*`.",
+							Required:            true,
+							ElementType:         types.StringType,
+						},
+						"allowed_actions": schema.ListAttribute{
+							MarkdownDescription: "Permissions and action groups allowed on the indices, e.g. `read` or `indices:data/write/index`.",
+							Optional:            true,
+							ElementType:         types.StringType,
+						},
+						"dls": schema.StringAttribute{
+							MarkdownDescription: "Document level security, a JSON query limiting the documents the role can see.",
+							Optional:            true,
+						},
+						"fls": schema.ListAttribute{
+							MarkdownDescription: "Field level security, the fields the role can see, or can't see when prefixed with `~`.",
+							Optional:            true,
+							ElementType:         types.StringType,
+						},
+						"masked_fields": schema.ListAttribute{
+							MarkdownDescription: "Fields whose values are replaced with a hash for the role.",
+							Optional:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+			"tenant_permissions": schema.ListNestedAttribute{
+				MarkdownDescription: "Permissions on the Dashboards tenants matching each set of patterns.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"tenant_patterns": schema.ListAttribute{
+							MarkdownDescription: "Tenant names or patterns.",
+							Required:            true,
+							ElementType:         types.StringType,
+						},
+						"allowed_actions": schema.ListAttribute{
+							MarkdownDescription: "Access to the tenants, e.g. `kibana_all_read` or `kibana_all_write`.",
+							Optional:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *RoleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *RoleResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// Create creates the role, unless a reserved, static or hidden role already has its name.
+func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoleModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := putRole(ctx, client, data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating role",
+			fmt.Sprintf("Could not create role %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+
+	tflog.Trace(ctx, "created Role resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read refreshes the role from OpenSearch.
+func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoleModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	role, found, err := getRole(ctx, client, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading role", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = data.Name
+	data.readBack(role.Role)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update replaces the role's permissions in place.
+func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoleModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := putRole(ctx, client, data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating role",
+			fmt.Sprintf("Could not update role %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "updated Role resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the role, its role mappings are kept.
+func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoleModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	existing, found, err := getRole(ctx, client, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting role", err.Error())
+		return
+	}
+
+	// Nothing to delete.
+	if !found {
+		return
+	}
+
+	if err := securityObjectReadOnlyError("role", existing.SecurityObjectFlags); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting role",
+			fmt.Sprintf("Could not delete role %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "DELETE", securityAPIPath("roles", data.Name.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting role", err.Error())
+		return
+	}
+
+	// Treat 404 as already deleted.
+	if status == http.StatusNotFound {
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error deleting role",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Role resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+}
+
+// ImportState imports a role by its name, reading all of its permissions back from OpenSearch.
+func (r *RoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
+
+// Returns the role and whether it exists.
+func getRole(ctx context.Context, client *opensearchapi.Client, name string) (skpropensearch.RoleGetItem, bool, error) {
+	var role skpropensearch.RoleGetItem
+
+	status, body, err := performJSONRequest(ctx, client, "GET", securityAPIPath("roles", name), nil)
+	if err != nil {
+		return role, false, err
+	}
+
+	if status == http.StatusNotFound {
+		return role, false, nil
+	}
+
+	if status < 200 || status >= 300 {
+		return role, false, fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	var getResponse skpropensearch.RoleGetResponse
+
+	if err := json.Unmarshal(body, &getResponse); err != nil {
+		return role, false, fmt.Errorf("could not parse role response: %w", err)
+	}
+
+	role, found := getResponse[name]
+
+	return role, found, nil
+}
+
+// Creates or replaces the role. A reserved, static or hidden role is never changed, see securityObjectReadOnlyError.
+func putRole(ctx context.Context, client *opensearchapi.Client, data RoleModel) error {
+	name := data.Name.ValueString()
+
+	existing, found, err := getRole(ctx, client, name)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		if err := securityObjectReadOnlyError("role", existing.SecurityObjectFlags); err != nil {
+			return err
+		}
+	}
+
+	requestBody, err := json.Marshal(data.role())
+	if err != nil {
+		return err
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "PUT", securityAPIPath("roles", name), requestBody)
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	return nil
+}
+
+// Returns the role as sent to OpenSearch.
+func (m RoleModel) role() skpropensearch.Role {
+	role := skpropensearch.Role{
+		Description:        m.Description.ValueString(),
+		ClusterPermissions: stringValues(m.ClusterPermissions),
+		IndexPermissions:   make([]skpropensearch.RoleIndexPermission, 0, len(m.IndexPermissions)),
+		TenantPermissions:  make([]skpropensearch.RoleTenantPermission, 0, len(m.TenantPermissions)),
+	}
+
+	for _, permission := range m.IndexPermissions {
+		role.IndexPermissions = append(role.IndexPermissions, skpropensearch.RoleIndexPermission{
+			IndexPatterns:  stringValues(permission.IndexPatterns),
+			AllowedActions: stringValues(permission.AllowedActions),
+			DLS:            permission.DLS.ValueString(),
+			FLS:            stringValues(permission.FLS),
+			MaskedFields:   stringValues(permission.MaskedFields),
+		})
+	}
+
+	for _, permission := range m.TenantPermissions {
+		role.TenantPermissions = append(role.TenantPermissions, skpropensearch.RoleTenantPermission{
+			TenantPatterns: stringValues(permission.TenantPatterns),
+			AllowedActions: stringValues(permission.AllowedActions),
+		})
+	}
+
+	return role
+}
+
+// Replaces the model's permissions with the role in OpenSearch, keeping unset attributes null when OpenSearch reports them empty.
+func (m *RoleModel) readBack(role skpropensearch.Role) {
+	m.Description = readBackOptionalString(m.Description, role.Description)
+	m.ClusterPermissions = readBackStrings(m.ClusterPermissions, role.ClusterPermissions)
+
+	indexPermissions := make([]RoleIndexPermissionModel, 0, len(role.IndexPermissions))
+
+	for i, permission := range role.IndexPermissions {
+		var prior RoleIndexPermissionModel
+		if i < len(m.IndexPermissions) {
+			prior = m.IndexPermissions[i]
+		}
+
+		indexPermissions = append(indexPermissions, RoleIndexPermissionModel{
+			IndexPatterns:  readBackStrings(prior.IndexPatterns, permission.IndexPatterns),
+			AllowedActions: readBackStrings(prior.AllowedActions, permission.AllowedActions),
+			DLS:            readBackOptionalString(prior.DLS, permission.DLS),
+			FLS:            readBackStrings(prior.FLS, permission.FLS),
+			MaskedFields:   readBackStrings(prior.MaskedFields, permission.MaskedFields),
+		})
+	}
+
+	tenantPermissions := make([]RoleTenantPermissionModel, 0, len(role.TenantPermissions))
+
+	for i, permission := range role.TenantPermissions {
+		var prior RoleTenantPermissionModel
+		if i < len(m.TenantPermissions) {
+			prior = m.TenantPermissions[i]
+		}
+
+		tenantPermissions = append(tenantPermissions, RoleTenantPermissionModel{
+			TenantPatterns: readBackStrings(prior.TenantPatterns, permission.TenantPatterns),
+			AllowedActions: readBackStrings(prior.AllowedActions, permission.AllowedActions),
+		})
+	}
+
+	// As with lists of strings, no permissions are null unless the prior value was an empty list.
+	if len(indexPermissions) > 0 || m.IndexPermissions != nil {
+		m.IndexPermissions = indexPermissions
+	}
+
+	if len(tenantPermissions) > 0 || m.TenantPermissions != nil {
+		m.TenantPermissions = tenantPermissions
+	}
+}
+
+// Returns the values of a list attribute, never nil as OpenSearch expects lists rather than null.
+func stringValues(values []types.String) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, value.ValueString())
+	}
+
+	return result
+}
+
+// Returns the values OpenSearch reports for a list attribute. No values are null, unless the prior value was an empty list.
+func readBackStrings(prior []types.String, actual []string) []types.String {
+	if len(actual) == 0 && prior == nil {
+		return nil
+	}
+
+	result := make([]types.String, 0, len(actual))
+	for _, value := range actual {
+		result = append(result, types.StringValue(value))
+	}
+
+	return result
+}
+
+// Returns the value OpenSearch reports for an optional string attribute. An empty value is null, unless the prior value was empty.
+func readBackOptionalString(prior types.String, actual string) types.String {
+	if actual == "" && prior.IsNull() {
+		return types.StringNull()
+	}
+
+	return types.StringValue(actual)
+}
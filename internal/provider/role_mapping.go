@@ -0,0 +1,356 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &RoleMappingResource{}
+	_ resource.ResourceWithImportState = &RoleMappingResource{}
+)
+
+// NewRoleMappingResource is a helper function to simplify the provider implementation.
+func NewRoleMappingResource() resource.Resource {
+	return &RoleMappingResource{}
+}
+
+// RoleMappingResource is the resource implementation.
+type RoleMappingResource struct {
+	providerData *ProviderData
+}
+
+// RoleMappingModel describes the Role Mapping resource data model.
+type RoleMappingModel struct {
+	ID           types.String   `tfsdk:"id"`
+	Role         types.String   `tfsdk:"role"`
+	Description  types.String   `tfsdk:"description"`
+	Users        []types.String `tfsdk:"users"`
+	BackendRoles []types.String `tfsdk:"backend_roles"`
+	Hosts        []types.String `tfsdk:"hosts"`
+}
+
+// Metadata returns the resource type name.
+func (r *RoleMappingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_role_mapping", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Role Mapping resource.
+func (r *RoleMappingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Maps users, backend roles (e.g. SAML or LDAP groups) and hosts to a role of the security plugin. " +
+			"Reserved, static and hidden mappings can't be managed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the role.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "Name of the role which is mapped, e.g. `opensearch_role.example.name`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the role mapping.",
+				Optional:            true,
+			},
+			"users": schema.ListAttribute{
+				MarkdownDescription: "Users which have the role.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"backend_roles": schema.ListAttribute{
+				MarkdownDescription: "Backend roles which have the role, e.g. groups from a SAML assertion.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"hosts": schema.ListAttribute{
+				MarkdownDescription: "Hosts whose requests have the role.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *RoleMappingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *RoleMappingResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// Create maps the role, unless its mapping is reserved, static or hidden.
+func (r *RoleMappingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoleMappingModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := putRoleMapping(ctx, client, data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating role mapping",
+			fmt.Sprintf("Could not create the mapping of role %s: %s", data.Role.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Role
+
+	tflog.Trace(ctx, "created Role Mapping resource", map[string]any{
+		"role": data.Role.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read refreshes the role mapping from OpenSearch.
+func (r *RoleMappingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoleMappingModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	mapping, found, err := getRoleMapping(ctx, client, data.Role.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading role mapping", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = data.Role
+	data.Description = readBackOptionalString(data.Description, mapping.Description)
+	data.Users = readBackStrings(data.Users, mapping.Users)
+	data.BackendRoles = readBackStrings(data.BackendRoles, mapping.BackendRoles)
+	data.Hosts = readBackStrings(data.Hosts, mapping.Hosts)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update replaces the users, backend roles and hosts of the role mapping in place.
+func (r *RoleMappingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoleMappingModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := putRoleMapping(ctx, client, data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating role mapping",
+			fmt.Sprintf("Could not update the mapping of role %s: %s", data.Role.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "updated Role Mapping resource", map[string]any{
+		"role": data.Role.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the role mapping, the role itself is kept.
+func (r *RoleMappingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoleMappingModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	existing, found, err := getRoleMapping(ctx, client, data.Role.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting role mapping", err.Error())
+		return
+	}
+
+	// Nothing to delete.
+	if !found {
+		return
+	}
+
+	if err := securityObjectReadOnlyError("role mapping", existing.SecurityObjectFlags); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting role mapping",
+			fmt.Sprintf("Could not delete the mapping of role %s: %s", data.Role.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "DELETE", securityAPIPath("rolesmapping", data.Role.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting role mapping", err.Error())
+		return
+	}
+
+	// Treat 404 as already deleted.
+	if status == http.StatusNotFound {
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error deleting role mapping",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Role Mapping resource", map[string]any{
+		"role": data.Role.ValueString(),
+	})
+}
+
+// ImportState imports a role mapping by the name of its role.
+func (r *RoleMappingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role"), req.ID)...)
+}
+
+// Returns the mapping of the role and whether it exists.
+func getRoleMapping(ctx context.Context, client *opensearchapi.Client, role string) (skpropensearch.RoleMappingGetItem, bool, error) {
+	var mapping skpropensearch.RoleMappingGetItem
+
+	status, body, err := performJSONRequest(ctx, client, "GET", securityAPIPath("rolesmapping", role), nil)
+	if err != nil {
+		return mapping, false, err
+	}
+
+	if status == http.StatusNotFound {
+		return mapping, false, nil
+	}
+
+	if status < 200 || status >= 300 {
+		return mapping, false, fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	var getResponse skpropensearch.RoleMappingGetResponse
+
+	if err := json.Unmarshal(body, &getResponse); err != nil {
+		return mapping, false, fmt.Errorf("could not parse role mapping response: %w", err)
+	}
+
+	mapping, found := getResponse[role]
+
+	return mapping, found, nil
+}
+
+// Creates or replaces the mapping of the role. A reserved, static or hidden mapping is never changed, see securityObjectReadOnlyError.
+func putRoleMapping(ctx context.Context, client *opensearchapi.Client, data RoleMappingModel) error {
+	role := data.Role.ValueString()
+
+	existing, found, err := getRoleMapping(ctx, client, role)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		if err := securityObjectReadOnlyError("role mapping", existing.SecurityObjectFlags); err != nil {
+			return err
+		}
+	}
+
+	requestBody, err := json.Marshal(skpropensearch.RoleMapping{
+		Description:  data.Description.ValueString(),
+		Users:        stringValues(data.Users),
+		BackendRoles: stringValues(data.BackendRoles),
+		Hosts:        stringValues(data.Hosts),
+	})
+	if err != nil {
+		return err
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "PUT", securityAPIPath("rolesmapping", role), requestBody)
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestResourceModelsMatchSchemas(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource resource.Resource
+		model    any
+	}{
+		{
+			name:     "connector",
+			resource: NewConnectorResource(),
+			model:    &ConnectorModel{},
+		},
+		{
+			name:     "model register",
+			resource: NewModelRegisterResource(),
+			model:    &ModelRegisterModel{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			schemaResp := &resource.SchemaResponse{}
+			tt.resource.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+			if schemaResp.Diagnostics.HasError() {
+				t.Fatalf("unexpected schema diagnostics: %v", schemaResp.Diagnostics)
+			}
+
+			objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+			attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+			for name, attributeType := range objectType.AttributeTypes {
+				attributes[name] = tftypes.NewValue(attributeType, nil)
+			}
+
+			state := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(objectType, attributes),
+			}
+
+			if diags := state.Get(ctx, tt.model); diags.HasError() {
+				t.Errorf("model does not match the schema: %v", diags)
+			}
+		})
+	}
+}
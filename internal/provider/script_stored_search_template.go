@@ -0,0 +1,475 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                   = &ScriptStoredSearchTemplateResource{}
+	_ resource.ResourceWithValidateConfig = &ScriptStoredSearchTemplateResource{}
+)
+
+// Matches a Mustache tag with its sigil and name, e.g. {{query}}, {{{query}}}, {{#filters}}, {{^filters}} or {{/filters}}.
+var mustacheTagPattern = regexp.MustCompile(`\{\{(\{?)\s*([#^/&!>=]?)\s*(.*?)\s*\}?\}\}`)
+
+// Matches a Mustache variable name, e.g. query or range.gte.
+var mustacheNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// Mustache lambdas provided by OpenSearch which are not template parameters.
+var mustacheLambdas = map[string]bool{
+	"toJson": true,
+	"join":   true,
+	"url":    true,
+}
+
+// NewScriptStoredSearchTemplateResource is a helper function to simplify the provider implementation.
+func NewScriptStoredSearchTemplateResource() resource.Resource {
+	return &ScriptStoredSearchTemplateResource{}
+}
+
+// ScriptStoredSearchTemplateResource is the resource implementation.
+type ScriptStoredSearchTemplateResource struct {
+	providerData *ProviderData
+}
+
+// ScriptStoredSearchTemplateModel describes the stored search template resource data model.
+type ScriptStoredSearchTemplateModel struct {
+	ID     types.String `tfsdk:"id"`
+	Source types.String `tfsdk:"source"`
+	Params types.List   `tfsdk:"params"`
+}
+
+// Metadata returns the resource type name.
+func (r *ScriptStoredSearchTemplateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_script_stored_search_template", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the stored search template resource.
+func (r *ScriptStoredSearchTemplateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Stored Mustache search template resource",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the stored script.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "The Mustache search template.",
+				Required:            true,
+			},
+			"params": schema.ListAttribute{
+				MarkdownDescription: "Parameters the template is documented to accept. " +
+					"When set, a warning is raised for any parameter referenced by the template which is not in this list. " +
+					"This is only used for validation and is not sent to OpenSearch.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// ValidateConfig warns when the template references parameters which have not been declared.
+func (r *ScriptStoredSearchTemplateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ScriptStoredSearchTemplateModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Source.IsUnknown() || data.Params.IsNull() || data.Params.IsUnknown() {
+		return
+	}
+
+	var params []string
+
+	resp.Diagnostics.Append(data.Params.ElementsAs(ctx, &params, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	declared := make(map[string]bool, len(params))
+	for _, param := range params {
+		declared[param] = true
+	}
+
+	var undeclared []string
+
+	for _, param := range mustacheParams(data.Source.ValueString()) {
+		if !declared[param] {
+			undeclared = append(undeclared, param)
+		}
+	}
+
+	if len(undeclared) > 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("params"),
+			"Undeclared template parameters",
+			fmt.Sprintf("The template references parameters which are not declared in params: %s", strings.Join(undeclared, ", ")),
+		)
+	}
+}
+
+// Returns the sorted top level parameter names referenced by a Mustache template. Names inside a {{#section}} are
+// looked up on the section's items first, so they are skipped, as is the current item {{.}}. Inverted sections and the
+// toJson, join and url lambdas don't change the context, so names inside them are parameters, e.g. filters in
+// {{#toJson}}filters{{/toJson}}.
+func mustacheParams(source string) []string {
+	type section struct {
+		name string
+		// Whether names inside the section are looked up on its items.
+		scoped bool
+		// Where the section's content starts.
+		start int
+	}
+
+	var (
+		sections []section
+		scoped   int
+	)
+
+	found := make(map[string]bool)
+
+	add := func(name string) {
+		if scoped > 0 || !mustacheNamePattern.MatchString(name) {
+			return
+		}
+
+		// Only the root of a dotted name is a parameter, e.g. "range" for {{range.gte}}.
+		name, _, _ = strings.Cut(name, ".")
+
+		if !mustacheLambdas[name] {
+			found[name] = true
+		}
+	}
+
+	for _, match := range mustacheTagPattern.FindAllStringSubmatchIndex(source, -1) {
+		sigil := source[match[4]:match[5]]
+		name := source[match[6]:match[7]]
+
+		switch sigil {
+		case "!", ">", "=":
+			// Comments, partials and delimiter changes don't reference parameters.
+		case "#":
+			if mustacheLambdas[name] {
+				sections = append(sections, section{name: name, start: match[1]})
+				continue
+			}
+
+			add(name)
+
+			sections = append(sections, section{name: name, scoped: true, start: match[1]})
+			scoped++
+		case "^":
+			add(name)
+
+			sections = append(sections, section{name: name, start: match[1]})
+		case "/":
+			for len(sections) > 0 {
+				closed := sections[len(sections)-1]
+				sections = sections[:len(sections)-1]
+
+				if closed.scoped {
+					scoped--
+				}
+
+				if closed.name != name {
+					continue
+				}
+
+				// The toJson and join lambdas are given a parameter name as their content.
+				if name == "toJson" || name == "join" {
+					add(strings.TrimSpace(source[closed.start:match[0]]))
+				}
+
+				break
+			}
+		default:
+			add(name)
+		}
+	}
+
+	params := make([]string, 0, len(found))
+	for name := range found {
+		params = append(params, name)
+	}
+
+	sort.Strings(params)
+
+	return params
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *ScriptStoredSearchTemplateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *ScriptStoredSearchTemplateResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// Create stores the search template in OpenSearch.
+func (r *ScriptStoredSearchTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ScriptStoredSearchTemplateModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.put(ctx, data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating stored search template",
+			fmt.Sprintf("Could not create stored search template %s: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "created Script Stored Search Template resource", map[string]any{
+		"script_id": data.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Stores the search template, used for both create and update.
+func (r *ScriptStoredSearchTemplateResource) put(ctx context.Context, data ScriptStoredSearchTemplateModel) error {
+	client, err := r.client()
+	if err != nil {
+		return err
+	}
+
+	request := skpropensearch.StoredScriptRequest{
+		Script: skpropensearch.StoredScript{
+			Lang:   skpropensearch.ScriptLangMustache,
+			Source: data.Source.ValueString(),
+		},
+	}
+
+	requestBodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	putReq, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("/_scripts/%s", data.ID.ValueString()), bytes.NewReader(requestBodyBytes))
+	if err != nil {
+		return err
+	}
+
+	putReq.Header.Set("Content-Type", "application/json")
+	putReq.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(putReq)
+	if err != nil {
+		return err
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// Read the stored search template from OpenSearch.
+func (r *ScriptStoredSearchTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ScriptStoredSearchTemplateModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	getReq, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/_scripts/%s", data.ID.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating stored search template get request", err.Error())
+		return
+	}
+
+	getReq.Header.Set("Content-Type", "application/json")
+	getReq.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(getReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading stored search template", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if httpResp.StatusCode == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading stored search template get response", err.Error())
+		return
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		resp.Diagnostics.AddError(
+			"Error reading stored search template",
+			fmt.Sprintf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body)),
+		)
+		return
+	}
+
+	var script skpropensearch.StoredScriptGetResponse
+
+	if err := json.Unmarshal(body, &script); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing stored search template get response",
+			fmt.Sprintf("Could not parse stored search template get response: %s", err.Error()),
+		)
+		return
+	}
+
+	if !script.Found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Source = types.StringValue(script.Script.Source)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update stores the changed search template in place.
+func (r *ScriptStoredSearchTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ScriptStoredSearchTemplateModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.put(ctx, data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating stored search template",
+			fmt.Sprintf("Could not update stored search template %s: %s", data.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "updated Script Stored Search Template resource", map[string]any{
+		"script_id": data.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete the stored search template from OpenSearch.
+func (r *ScriptStoredSearchTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ScriptStoredSearchTemplateModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	delReq, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("/_scripts/%s", data.ID.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating stored search template delete request", err.Error())
+		return
+	}
+
+	delReq.Header.Set("Content-Type", "application/json")
+	delReq.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(delReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting stored search template", err.Error())
+		return
+	}
+
+	body, readErr := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if readErr != nil {
+		resp.Diagnostics.AddError("Error reading stored search template delete response", readErr.Error())
+		return
+	}
+
+	// Treat 404 as already deleted.
+	if httpResp.StatusCode == http.StatusNotFound {
+		tflog.Trace(ctx, "stored search template already deleted", map[string]any{
+			"script_id": data.ID.ValueString(),
+		})
+		return
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		resp.Diagnostics.AddError(
+			"Error deleting stored search template",
+			fmt.Sprintf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body)),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Script Stored Search Template resource", map[string]any{
+		"script_id": data.ID.ValueString(),
+	})
+}
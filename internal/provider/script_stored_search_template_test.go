@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMustacheParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   []string
+	}{
+		{
+			name:   "plain parameters",
+			source: `{"query":{"match":{"{{field}}":"{{{query}}}"}},"size":{{&size}},"from":{{ from }}}`,
+			want:   []string{"field", "from", "query", "size"},
+		},
+		{
+			name:   "dotted parameter",
+			source: `{"range":{"@timestamp":{"gte":"{{range.gte}}","lte":"{{range.lte}}"}}}`,
+			want:   []string{"range"},
+		},
+		{
+			name:   "section",
+			source: `{"bool":{"filter":[{{#filters}}{"term":{"{{field}}":"{{value}}"}},{{/filters}}{"match_all":{}}]}}`,
+			want:   []string{"filters"},
+		},
+		{
+			name:   "current item of a section",
+			source: `{"terms":{"tags":[{{#tags}}"{{.}}",{{/tags}}""]}}`,
+			want:   []string{"tags"},
+		},
+		{
+			name:   "nested sections",
+			source: `{{#groups}}{{#members}}{{name}}{{/members}}{{label}}{{/groups}}{{size}}`,
+			want:   []string{"groups", "size"},
+		},
+		{
+			name:   "inverted section",
+			source: `{"size":{{^size}}{{default_size}}{{/size}}{{size}}}`,
+			want:   []string{"default_size", "size"},
+		},
+		{
+			name:   "inverted section inside a section",
+			source: `{{#filters}}{{^last}},{{/last}}{{/filters}}`,
+			want:   []string{"filters"},
+		},
+		{
+			name:   "lambdas",
+			source: `{"filter":{{#toJson}}filters{{/toJson}},"tags":"{{#join}}tags{{/join}}","url":"{{#url}}{{address}}{{/url}}"}`,
+			want:   []string{"address", "filters", "tags"},
+		},
+		{
+			name:   "comments and partials",
+			source: `{{! the {{query}} parameter is optional }}{{> header}}{"size":{{size}}}`,
+			want:   []string{"size"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mustacheParams(tt.source); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected parameters %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// searchPermissionError is returned when the credentials are not allowed to use an ML Commons _search endpoint,
+// which least privilege roles often leave out even when they can read objects by ID.
+type searchPermissionError struct {
+	path   string
+	status int
+	body   string
+}
+
+// Error describes the denied search and the permission it needs.
+func (e *searchPermissionError) Error() string {
+	return fmt.Sprintf("permission denied (OpenSearch returned %d: %s). Grant the %s permission, e.g. with the ml_read_access role",
+		e.status, e.body, e.permission())
+}
+
+// Returns the action permission of the search endpoint, e.g. cluster:admin/opensearch/ml/models/search.
+func (e *searchPermissionError) permission() string {
+	objects := strings.TrimSuffix(strings.TrimPrefix(e.path, "/_plugins/_ml/"), "/_search")
+
+	return fmt.Sprintf("cluster:admin/opensearch/ml/%s/search", objects)
+}
+
+// Adds the diagnostic for a failed search for the named object, telling a denied search apart from other errors
+// so it isn't mistaken for the object not existing.
+func addSearchError(diags *diag.Diagnostics, kind, name string, err error) {
+	var permissionErr *searchPermissionError
+
+	if errors.As(err, &permissionErr) {
+		diags.AddError(
+			fmt.Sprintf("Permission denied searching for %s", kind),
+			fmt.Sprintf("The provider's credentials are not allowed to search for %s %q: %s.", kind, name, err.Error()),
+		)
+		return
+	}
+
+	diags.AddError(
+		fmt.Sprintf("Error searching for %s", kind),
+		fmt.Sprintf("Could not search for %s %q: %s", kind, name, err.Error()),
+	)
+}
+
+// Runs a search against one of the ML Commons _search endpoints, e.g. /_plugins/_ml/models/_search.
+func searchML(ctx context.Context, client *opensearchapi.Client, path string, query map[string]any) (skpropensearch.SearchResponse, error) {
+	var searchResp skpropensearch.SearchResponse
+
+	requestBody, err := json.Marshal(query)
+	if err != nil {
+		return searchResp, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", path, bytes.NewReader(requestBody))
+	if err != nil {
+		return searchResp, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(req)
+	if err != nil {
+		return searchResp, err
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		return searchResp, err
+	}
+
+	if httpResp.StatusCode == http.StatusUnauthorized || httpResp.StatusCode == http.StatusForbidden {
+		return searchResp, &searchPermissionError{path: path, status: httpResp.StatusCode, body: string(body)}
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return searchResp, fmt.Errorf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, &searchResp); err != nil {
+		return searchResp, err
+	}
+
+	return searchResp, nil
+}
+
+// Page size of searchModelIDs, well within the default index.max_result_window of 10000.
+const modelSearchPageSize = 1000
+
+// Returns the IDs of models which belong to the given field value, e.g. all models in a model group.
+// Model chunks (used for local models) are excluded so each model is only returned once. Results are
+// fetched a page at a time in index order, so groups larger than a page are returned whole.
+func searchModelIDs(ctx context.Context, client *opensearchapi.Client, field, value string) ([]string, error) {
+	ids := []string{}
+	seen := make(map[string]bool)
+
+	for from := 0; ; from += modelSearchPageSize {
+		query := map[string]any{
+			"from":    from,
+			"size":    modelSearchPageSize,
+			"_source": false,
+			"sort":    []any{"_doc"},
+			"query": map[string]any{
+				"bool": map[string]any{
+					"must": []any{
+						map[string]any{"term": map[string]any{field: value}},
+					},
+					"must_not": []any{
+						map[string]any{"exists": map[string]any{"field": "chunk_number"}},
+					},
+				},
+			},
+		}
+
+		searchResp, err := searchML(ctx, client, "/_plugins/_ml/models/_search", query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hit := range searchResp.Hits.Hits {
+			if !seen[hit.ID] {
+				seen[hit.ID] = true
+				ids = append(ids, hit.ID)
+			}
+		}
+
+		if len(searchResp.Hits.Hits) < modelSearchPageSize {
+			return ids, nil
+		}
+	}
+}
+
+// Returns the IDs of ML Commons objects (connectors, models, model groups) with the given exact name.
+func searchMLIDsByName(ctx context.Context, client *opensearchapi.Client, path, name string) ([]string, error) {
+	hits, err := searchMLByName(ctx, client, path, name, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(hits))
+
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
+
+	return ids, nil
+}
+
+// Returns the ML Commons objects with the given exact name whose other fields match the filters, e.g. a model group ID.
+// Model chunks share the name of their model so they are excluded. When newestFirst is set the hits are sorted
+// by created_time, newest first.
+func searchMLByName(ctx context.Context, client *opensearchapi.Client, path, name string, filters map[string]string, newestFirst bool) ([]skpropensearch.SearchHit, error) {
+	must := []any{
+		map[string]any{"term": map[string]any{"name.keyword": name}},
+	}
+
+	for field, value := range filters {
+		must = append(must, map[string]any{"term": map[string]any{field: value}})
+	}
+
+	query := map[string]any{
+		"size": 100,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"must": must,
+				"must_not": []any{
+					map[string]any{"exists": map[string]any{"field": "chunk_number"}},
+				},
+			},
+		},
+	}
+
+	if newestFirst {
+		query["sort"] = []any{
+			map[string]any{"created_time": map[string]any{"order": "desc"}},
+		}
+	}
+
+	searchResp, err := searchML(ctx, client, path, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return searchResp.Hits.Hits, nil
+}
@@ -0,0 +1,310 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &SearchPipelineResource{}
+	_ resource.ResourceWithImportState = &SearchPipelineResource{}
+)
+
+// NewSearchPipelineResource is a helper function to simplify the provider implementation.
+func NewSearchPipelineResource() resource.Resource {
+	return &SearchPipelineResource{}
+}
+
+// SearchPipelineResource is the resource implementation.
+type SearchPipelineResource struct {
+	providerData *ProviderData
+}
+
+// SearchPipelineModel describes the Search Pipeline resource data model.
+type SearchPipelineModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Body JSONBody     `tfsdk:"body"`
+}
+
+// Metadata returns the resource type name.
+func (r *SearchPipelineResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_search_pipeline", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Search Pipeline resource.
+func (r *SearchPipelineResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a search pipeline, which runs processors on search requests and responses, e.g. to rerank results with a model.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name (ID) of the search pipeline.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name (ID) of the search pipeline.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "A JSON payload which defines the pipeline, e.g. its `request_processors`, `response_processors` and `phase_results_processors`. " +
+					"Model IDs (e.g. `opensearch_model_register.reranker.model_id`) can be interpolated with `jsonencode`. " +
+					"Only the fields given here are checked for drift.",
+				Required:   true,
+				CustomType: JSONBodyType{},
+				PlanModifiers: []planmodifier.String{
+					UseStateForSemanticallyEqualJSON(),
+				},
+				Validators: []validator.String{
+					JSONBodyFields("search pipeline", skpropensearch.SearchPipelineBodyFields),
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *SearchPipelineResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *SearchPipelineResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// Create creates the search pipeline.
+func (r *SearchPipelineResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SearchPipelineModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := putSearchPipeline(ctx, client, data.Name.ValueString(), data.Body.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating search pipeline",
+			fmt.Sprintf("Could not create search pipeline %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+
+	tflog.Trace(ctx, "created Search Pipeline resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read compares the search pipeline in OpenSearch with the state.
+func (r *SearchPipelineResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SearchPipelineModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	status, body, err := performJSONRequest(ctx, client, "GET", fmt.Sprintf("/_search/pipeline/%s", name), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading search pipeline", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if status == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error reading search pipeline",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	// The response is keyed by pipeline name, as GET accepts wildcards.
+	var getResponse map[string]any
+
+	if err := json.Unmarshal(body, &getResponse); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing search pipeline response",
+			fmt.Sprintf("Could not parse search pipeline response: %s", err.Error()),
+		)
+		return
+	}
+
+	pipeline, ok := getResponse[name]
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	pipelineBody, err := readBackJSONSubset(data.Body.ValueString(), pipeline)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading search pipeline",
+			fmt.Sprintf("Could not compare search pipeline %s: %s", name, err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+	data.Body = NewJSONBodyValue(pipelineBody)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update replaces the search pipeline in place, pipelines are mutable.
+func (r *SearchPipelineResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SearchPipelineModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := putSearchPipeline(ctx, client, data.Name.ValueString(), data.Body.ValueString()); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating search pipeline",
+			fmt.Sprintf("Could not update search pipeline %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "updated Search Pipeline resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the search pipeline. Searching an index which uses it as its default pipeline fails until it is removed from the index settings.
+func (r *SearchPipelineResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SearchPipelineModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "DELETE", fmt.Sprintf("/_search/pipeline/%s", data.Name.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting search pipeline", err.Error())
+		return
+	}
+
+	// Treat 404 as already deleted.
+	if status == http.StatusNotFound {
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error deleting search pipeline",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Search Pipeline resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+}
+
+// ImportState imports a search pipeline by its name, reading its whole body back from OpenSearch.
+func (r *SearchPipelineResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
+
+// Creates or replaces the named search pipeline.
+func putSearchPipeline(ctx context.Context, client *opensearchapi.Client, name, body string) error {
+	status, respBody, err := performJSONRequest(ctx, client, "PUT", fmt.Sprintf("/_search/pipeline/%s", name), []byte(body))
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", status, string(respBody))
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestSearchMLByNameErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		body           string
+		wantPermission bool
+		wantSummary    string
+		wantDetail     string
+	}{
+		{
+			name:           "forbidden",
+			status:         http.StatusForbidden,
+			body:           `{"error":{"type":"security_exception","reason":"no permissions for [cluster:admin/opensearch/ml/models/search]"},"status":403}`,
+			wantPermission: true,
+			wantSummary:    "Permission denied searching for model",
+			wantDetail:     "Grant the cluster:admin/opensearch/ml/models/search permission",
+		},
+		{
+			name:           "unauthorized",
+			status:         http.StatusUnauthorized,
+			body:           `Unauthorized`,
+			wantPermission: true,
+			wantSummary:    "Permission denied searching for model",
+			wantDetail:     "OpenSearch returned 401: Unauthorized",
+		},
+		{
+			name:        "server error",
+			status:      http.StatusInternalServerError,
+			body:        `{"error":"search failed"}`,
+			wantSummary: "Error searching for model",
+			wantDetail:  `Could not search for model "embeddings": OpenSearch returned 500`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/_plugins/_ml/models/_search" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+
+				writeJSON(w, tt.status, tt.body)
+			})
+
+			_, err := searchMLByName(context.Background(), client, "/_plugins/_ml/models/_search", "embeddings", nil, false)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+
+			var permissionErr *searchPermissionError
+			if errors.As(err, &permissionErr) != tt.wantPermission {
+				t.Errorf("expected a permission error %t, got %v", tt.wantPermission, err)
+			}
+
+			var diags diag.Diagnostics
+			addSearchError(&diags, "model", "embeddings", err)
+
+			if len(diags) != 1 {
+				t.Fatalf("expected one diagnostic, got %v", diags)
+			}
+
+			if got := diags[0].Summary(); got != tt.wantSummary {
+				t.Errorf("expected summary %q, got %q", tt.wantSummary, got)
+			}
+
+			if got := diags[0].Detail(); !strings.Contains(got, tt.wantDetail) {
+				t.Errorf("expected detail containing %q, got %q", tt.wantDetail, got)
+			}
+		})
+	}
+}
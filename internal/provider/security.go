@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Returns an error explaining why a security plugin object can't be changed or deleted, or nil when it can.
+// OpenSearch rejects such changes with a 400 or 403 which doesn't say why.
+func securityObjectReadOnlyError(kind string, flags skpropensearch.SecurityObjectFlags) error {
+	reason := flags.ReadOnlyReason()
+	if reason == "" {
+		return nil
+	}
+
+	return fmt.Errorf("the %s can't be changed or deleted as %s. Manage it outside Terraform, or run terraform state rm to stop managing it", kind, reason)
+}
+
+// Returns the path of a security plugin REST API object, e.g. /_plugins/_security/api/internalusers/<name>.
+// Names can have characters which are special in paths (e.g. a user named after an email address or a
+// backend role with a slash), so they are escaped.
+func securityAPIPath(objects, name string) string {
+	return fmt.Sprintf("/_plugins/_security/api/%s/%s", objects, url.PathEscape(name))
+}
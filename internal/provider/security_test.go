@@ -0,0 +1,264 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+func TestSecurityObjectReadOnlyError(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   skpropensearch.SecurityObjectFlags
+		wantErr string
+	}{
+		{
+			name: "changeable",
+		},
+		{
+			name:    "reserved",
+			flags:   skpropensearch.SecurityObjectFlags{Reserved: true},
+			wantErr: "the role can't be changed or deleted as it is reserved, i.e. only an admin certificate can change it",
+		},
+		{
+			name:    "static",
+			flags:   skpropensearch.SecurityObjectFlags{Static: true},
+			wantErr: "the role can't be changed or deleted as it is static, i.e. defined by the security plugin itself",
+		},
+		{
+			name:    "hidden",
+			flags:   skpropensearch.SecurityObjectFlags{Hidden: true},
+			wantErr: "the role can't be changed or deleted as it is hidden, i.e. for the security plugin's internal use",
+		},
+		{
+			name:    "static and reserved",
+			flags:   skpropensearch.SecurityObjectFlags{Reserved: true, Static: true},
+			wantErr: "as it is static",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := securityObjectReadOnlyError("role", tt.flags)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPutSecurityObjectRejectsReadOnly(t *testing.T) {
+	tests := []struct {
+		name    string
+		objects string
+		object  string
+		put     func(ctx context.Context, client *opensearchapi.Client) error
+	}{
+		{
+			name:    "user",
+			objects: "internalusers",
+			object:  "ops@example.com/admin",
+			put: func(ctx context.Context, client *opensearchapi.Client) error {
+				return putInternalUser(ctx, client, InternalUserModel{Name: types.StringValue("ops@example.com/admin"), Password: types.StringValue("secret")})
+			},
+		},
+		{
+			name:    "role",
+			objects: "roles",
+			object:  "all_access",
+			put: func(ctx context.Context, client *opensearchapi.Client) error {
+				return putRole(ctx, client, RoleModel{Name: types.StringValue("all_access")})
+			},
+		},
+		{
+			name:    "role mapping",
+			objects: "rolesmapping",
+			object:  "ml_full_access",
+			put: func(ctx context.Context, client *opensearchapi.Client) error {
+				return putRoleMapping(ctx, client, RoleMappingModel{Role: types.StringValue("ml_full_access")})
+			},
+		},
+		{
+			name:    "tenant",
+			objects: "tenants",
+			object:  "global tenant",
+			put: func(ctx context.Context, client *opensearchapi.Client) error {
+				return putTenant(ctx, client, TenantModel{Name: types.StringValue("global tenant")})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if want := securityAPIPath(tt.objects, tt.object); r.URL.EscapedPath() != want {
+					t.Errorf("expected a request for %s, got %s", want, r.URL.EscapedPath())
+				}
+
+				if r.Method != "GET" {
+					t.Errorf("expected the reserved %s not to be changed, got %s %s", tt.name, r.Method, r.URL)
+				}
+
+				writeJSON(w, http.StatusOK, fmt.Sprintf(`{%q:{"reserved":true,"hidden":false,"static":false}}`, tt.object))
+			})
+
+			err := tt.put(context.Background(), client)
+			if err == nil || !strings.Contains(err.Error(), "as it is reserved") {
+				t.Errorf("expected a reserved object error, got %v", err)
+			}
+
+			if err != nil && strings.Contains(err.Error(), "secret") {
+				t.Errorf("expected the error not to include the password, got %s", err)
+			}
+		})
+	}
+}
+
+func TestSecurityAPIPath(t *testing.T) {
+	tests := map[string]string{
+		"ml_full_access":        "/_plugins/_security/api/roles/ml_full_access",
+		"ops@example.com/admin": "/_plugins/_security/api/roles/ops@example.com%2Fadmin",
+		"global tenant":         "/_plugins/_security/api/roles/global%20tenant",
+		"team?a#b":              "/_plugins/_security/api/roles/team%3Fa%23b",
+	}
+
+	for name, want := range tests {
+		if got := securityAPIPath("roles", name); got != want {
+			t.Errorf("expected securityAPIPath(%q) to be %q, got %q", name, want, got)
+		}
+	}
+}
+
+func TestInternalUserPassword(t *testing.T) {
+	ctx := context.Background()
+
+	r := &InternalUserResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	// Returns the user with the given password and password version, and the rest null.
+	user := func(password any, version any) tftypes.Value {
+		attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for name, attributeType := range objectType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+
+		attributes["id"] = tftypes.NewValue(tftypes.String, "analyst")
+		attributes["name"] = tftypes.NewValue(tftypes.String, "analyst")
+		attributes["password"] = tftypes.NewValue(tftypes.String, password)
+		attributes["password_version"] = tftypes.NewValue(tftypes.Number, version)
+
+		return tftypes.NewValue(objectType, attributes)
+	}
+
+	tests := []struct {
+		name         string
+		prior        tftypes.Value
+		config       tftypes.Value
+		wantPassword string
+	}{
+		{
+			name:         "create",
+			prior:        tftypes.NewValue(objectType, nil),
+			config:       user("secret", nil),
+			wantPassword: "secret",
+		},
+		{
+			name:   "update keeping the password version",
+			prior:  user(nil, 1),
+			config: user("rotated", 1),
+		},
+		{
+			name:         "update changing the password version",
+			prior:        user(nil, 1),
+			config:       user("rotated", 2),
+			wantPassword: "rotated",
+		},
+		{
+			name:         "update setting the password version after import",
+			prior:        user(nil, nil),
+			config:       user("secret", 1),
+			wantPassword: "secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var puts []skpropensearch.InternalUser
+
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					writeJSON(w, http.StatusOK, `{"analyst":{"reserved":false,"hidden":false,"static":false}}`)
+				case http.MethodPut:
+					var put skpropensearch.InternalUser
+					if err := json.NewDecoder(r.Body).Decode(&put); err != nil {
+						t.Errorf("could not decode the user: %s", err)
+					}
+
+					puts = append(puts, put)
+					writeJSON(w, http.StatusOK, `{"status":"OK"}`)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+				}
+			})
+
+			r := &InternalUserResource{providerData: &ProviderData{Client: client}}
+
+			// Write-only attributes are always null in the plan.
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tt.config}
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tt.config.Copy()}
+			plan.SetAttribute(ctx, path.Root("password"), types.StringNull())
+
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+
+			var diags diag.Diagnostics
+			if tt.prior.IsNull() {
+				resp := &resource.CreateResponse{State: state}
+				r.Create(ctx, resource.CreateRequest{Config: config, Plan: plan}, resp)
+				diags, state = resp.Diagnostics, resp.State
+			} else {
+				resp := &resource.UpdateResponse{State: state}
+				r.Update(ctx, resource.UpdateRequest{Config: config, Plan: plan, State: tfsdk.State{Schema: schemaResp.Schema, Raw: tt.prior}}, resp)
+				diags, state = resp.Diagnostics, resp.State
+			}
+
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if len(puts) != 1 || puts[0].Password != tt.wantPassword {
+				t.Fatalf("expected one request setting the password to %q, got %+v", tt.wantPassword, puts)
+			}
+
+			var password types.String
+			state.GetAttribute(ctx, path.Root("password"), &password)
+			if !password.IsNull() {
+				t.Errorf("expected the password not to be stored in state, got %s", password)
+			}
+		})
+	}
+}
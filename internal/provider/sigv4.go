@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/opensearch-project/opensearch-go/v4/signer"
+)
+
+// Messages returned by AWS when a request was signed with temporary credentials which have since expired.
+var expiredCredentialsMessages = []string{
+	"ExpiredToken",
+	"security token included in the request is expired",
+}
+
+// sigV4RefreshTransport refreshes AWS credentials and retries the request once when OpenSearch rejects
+// it because the temporary credentials used to sign it expired. This matters for long running operations
+// (e.g. polling a model registration for 15 minutes) where the credentials can expire mid-operation.
+type sigV4RefreshTransport struct {
+	base        http.RoundTripper
+	signer      signer.Signer
+	credentials *aws.CredentialsCache
+}
+
+// RoundTrip executes the request, re-signing it with fresh credentials if they expired.
+func (t *sigV4RefreshTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusForbidden {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if !isExpiredCredentials(body) || (req.Body != nil && req.GetBody == nil) {
+		return resp, nil
+	}
+
+	// Drop the cached credentials so the signer retrieves new ones.
+	t.credentials.Invalidate()
+
+	retry := req.Clone(req.Context())
+
+	if req.GetBody != nil {
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := t.signer.SignRequest(retry); err != nil {
+		return nil, err
+	}
+
+	return t.base.RoundTrip(retry)
+}
+
+// Reports whether a 403 response body indicates the request was signed with expired credentials.
+func isExpiredCredentials(body []byte) bool {
+	for _, message := range expiredCredentialsMessages {
+		if strings.Contains(string(body), message) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/opensearch-project/opensearch-go/v4"
+	requestsigner "github.com/opensearch-project/opensearch-go/v4/signer/awsv2"
+)
+
+func TestSigV4RetriesAreSignedAgain(t *testing.T) {
+	var retrievals, requests atomic.Int32
+
+	// Each retrieval returns new keys, so the server can tell which credentials signed a request.
+	credentials := aws.NewCredentialsCache(aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+		return aws.Credentials{
+			AccessKeyID:     fmt.Sprintf("AKID%d", retrievals.Add(1)),
+			SecretAccessKey: "secret",
+		}, nil
+	}))
+
+	signer, err := requestsigner.NewSignerWithService(aws.Config{Region: "us-east-1", Credentials: credentials}, "es")
+	if err != nil {
+		t.Fatalf("could not create signer: %s", err)
+	}
+
+	var signedWith []string
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		authorization := r.Header.Get("Authorization")
+		if !strings.HasPrefix(authorization, "AWS4-HMAC-SHA256 ") || r.Header.Get("X-Amz-Date") == "" {
+			t.Errorf("expected a SigV4 signed request, got Authorization %q", authorization)
+		}
+
+		if r.Header.Get("X-Tenant-ID") != "tenant-a" || !strings.Contains(authorization, "x-tenant-id") {
+			t.Errorf("expected the X-Tenant-ID header to be sent and signed, got %q in %q", r.Header.Get("X-Tenant-ID"), authorization)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		sum := sha256.Sum256(body)
+		if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) {
+			t.Errorf("expected the signed payload hash to match the body %q", body)
+		}
+
+		credential := authorization[strings.Index(authorization, "Credential=")+len("Credential=") : strings.Index(authorization, "/")]
+		signedWith = append(signedWith, credential)
+
+		switch requests.Load() {
+		case 1:
+			writeJSON(w, http.StatusForbidden, `{"message":"The security token included in the request is expired"}`)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			writeJSON(w, http.StatusTooManyRequests, `{"message":"Too many requests"}`)
+		default:
+			writeJSON(w, http.StatusOK, `{"acknowledged":true}`)
+		}
+	}, func(config *opensearch.Config) {
+		config.Header = http.Header{"X-Tenant-ID": []string{"tenant-a"}}
+		config.Signer = signer
+		config.Transport = &statusRetryTransport{
+			base: &sigV4RefreshTransport{
+				base:        http.DefaultTransport,
+				signer:      signer,
+				credentials: credentials,
+			},
+			maxRetries:    1,
+			retryOnStatus: []int{http.StatusTooManyRequests},
+			signer:        signer,
+		}
+	})
+
+	if err := putIndexSettings(context.Background(), client, "logs-1", map[string]any{"index.number_of_replicas": 1}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := []string{"AKID1", "AKID2", "AKID2"}
+	if strings.Join(signedWith, ",") != strings.Join(want, ",") {
+		t.Errorf("expected the requests to be signed with %v, got %v", want, signedWith)
+	}
+}
@@ -0,0 +1,440 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource               = &SnapshotResource{}
+	_ resource.ResourceWithModifyPlan = &SnapshotResource{}
+)
+
+// NewSnapshotResource is a helper function to simplify the provider implementation.
+func NewSnapshotResource() resource.Resource {
+	return &SnapshotResource{}
+}
+
+// SnapshotResource is the resource implementation.
+type SnapshotResource struct {
+	providerData *ProviderData
+}
+
+// SnapshotModel describes the Snapshot resource data model.
+type SnapshotModel struct {
+	ID                 types.String `tfsdk:"id"`
+	Repository         types.String `tfsdk:"repository"`
+	Name               types.String `tfsdk:"name"`
+	Indices            types.List   `tfsdk:"indices"`
+	IncludeGlobalState types.Bool   `tfsdk:"include_global_state"`
+	WaitForCompletion  types.Bool   `tfsdk:"wait_for_completion"`
+	State              types.String `tfsdk:"state"`
+	Failures           types.List   `tfsdk:"failures"`
+}
+
+// Metadata returns the resource type name.
+func (r *SnapshotResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_snapshot", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Snapshot resource.
+func (r *SnapshotResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "On-demand snapshot resource. The snapshot is taken on create and deleted on destroy.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the snapshot in the form `<repository>/<name>`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"repository": schema.StringAttribute{
+				MarkdownDescription: "Name of the snapshot repository.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the snapshot.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"indices": schema.ListAttribute{
+				MarkdownDescription: "Index names or patterns to include in the snapshot. Defaults to all indices.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"include_global_state": schema.BoolAttribute{
+				MarkdownDescription: "Whether to include the cluster state in the snapshot.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				MarkdownDescription: "Whether create waits for the snapshot to finish. " +
+					"When `false` the snapshot is left `IN_PROGRESS` and `state` is refreshed on subsequent reads.",
+				Optional: true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "State of the snapshot, e.g. `IN_PROGRESS`, `SUCCESS`, `PARTIAL` or `FAILED`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"failures": schema.ListAttribute{
+				MarkdownDescription: "Shard level failures reported for the snapshot.",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *SnapshotResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *SnapshotResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// ModifyPlan rejects the resource when the provider is configured for OpenSearch Serverless.
+func (r *SnapshotResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.providerData.serverlessDiagnostics("opensearch_snapshot")...)
+}
+
+// Create takes the snapshot in OpenSearch.
+func (r *SnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SnapshotModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	request := skpropensearch.SnapshotCreateRequest{
+		IncludeGlobalState: data.IncludeGlobalState.ValueBool(),
+	}
+
+	resp.Diagnostics.Append(data.Indices.ElementsAs(ctx, &request.Indices, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requestBodyBytes, err := json.Marshal(request)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating snapshot request body",
+			fmt.Sprintf("Could not create snapshot create request body: %s", err.Error()),
+		)
+		return
+	}
+
+	createPath := fmt.Sprintf("/_snapshot/%s/%s?wait_for_completion=%t", data.Repository.ValueString(), data.Name.ValueString(), data.WaitForCompletion.ValueBool())
+
+	createReq, err := http.NewRequestWithContext(ctx, "PUT", createPath, bytes.NewReader(requestBodyBytes))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating snapshot request",
+			fmt.Sprintf("Could not create snapshot create request: %s", err.Error()),
+		)
+		return
+	}
+
+	createReq.Header.Set("Content-Type", "application/json")
+	createReq.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(createReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating snapshot",
+			fmt.Sprintf("Could not create snapshot: %s", err.Error()),
+		)
+		return
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading snapshot create response", err.Error())
+		return
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		resp.Diagnostics.AddError(
+			"Error creating snapshot",
+			fmt.Sprintf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body)),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", data.Repository.ValueString(), data.Name.ValueString()))
+
+	// Without waiting OpenSearch only acknowledges the request, so read the snapshot for its state.
+	snapshot, found, err := getSnapshot(ctx, client, data.Repository.ValueString(), data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading snapshot",
+			fmt.Sprintf("Could not read snapshot after creating it: %s", err.Error()),
+		)
+		return
+	}
+
+	if !found {
+		snapshot.State = skpropensearch.SnapshotStateInProgress
+	}
+
+	resp.Diagnostics.Append(data.setSnapshot(ctx, snapshot)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created Snapshot resource", map[string]any{
+		"snapshot": data.ID.ValueString(),
+		"state":    snapshot.State,
+	})
+
+	// Persist the snapshot before reporting a failure so it is deleted on destroy.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if snapshot.State == skpropensearch.SnapshotStateFailed || snapshot.State == skpropensearch.SnapshotStatePartial {
+		resp.Diagnostics.AddError(
+			"Snapshot did not complete successfully",
+			fmt.Sprintf("Snapshot %s finished in state %s: %s", data.ID.ValueString(), snapshot.State, snapshot.FailureSummary()),
+		)
+	}
+}
+
+// Sets the computed state and failures from the snapshot.
+func (m *SnapshotModel) setSnapshot(ctx context.Context, snapshot skpropensearch.Snapshot) diag.Diagnostics {
+	failures := make([]string, 0, len(snapshot.Failures))
+	for _, failure := range snapshot.Failures {
+		failures = append(failures, failure.String())
+	}
+
+	failuresValue, diags := types.ListValueFrom(ctx, types.StringType, failures)
+
+	m.State = types.StringValue(snapshot.State)
+	m.Failures = failuresValue
+
+	return diags
+}
+
+// Returns the snapshot and whether it exists.
+func getSnapshot(ctx context.Context, client *opensearchapi.Client, repository, name string) (skpropensearch.Snapshot, bool, error) {
+	var snapshot skpropensearch.Snapshot
+
+	getReq, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/_snapshot/%s/%s", repository, name), nil)
+	if err != nil {
+		return snapshot, false, err
+	}
+
+	getReq.Header.Set("Content-Type", "application/json")
+	getReq.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(getReq)
+	if err != nil {
+		return snapshot, false, err
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if err != nil {
+		return snapshot, false, err
+	}
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return snapshot, false, nil
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return snapshot, false, fmt.Errorf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body))
+	}
+
+	var getResponse skpropensearch.SnapshotGetResponse
+
+	if err := json.Unmarshal(body, &getResponse); err != nil {
+		return snapshot, false, err
+	}
+
+	if len(getResponse.Snapshots) == 0 {
+		return snapshot, false, nil
+	}
+
+	return getResponse.Snapshots[0], true, nil
+}
+
+// Read the snapshot state from OpenSearch.
+func (r *SnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SnapshotModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	snapshot, found, err := getSnapshot(ctx, client, data.Repository.ValueString(), data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading snapshot", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(data.setSnapshot(ctx, snapshot)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only persists wait_for_completion, everything else requires a new snapshot.
+func (r *SnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SnapshotModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated Snapshot resource (no-op update)", map[string]any{
+		"snapshot": data.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete the snapshot from the repository, aborting it if it is still in progress.
+func (r *SnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SnapshotModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	delReq, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("/_snapshot/%s/%s", data.Repository.ValueString(), data.Name.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating snapshot delete request", err.Error())
+		return
+	}
+
+	delReq.Header.Set("Content-Type", "application/json")
+	delReq.Header.Set("Accept", "application/json")
+
+	httpResp, err := client.Client.Perform(delReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting snapshot", err.Error())
+		return
+	}
+
+	body, readErr := io.ReadAll(httpResp.Body)
+	_ = httpResp.Body.Close()
+	if readErr != nil {
+		resp.Diagnostics.AddError("Error reading snapshot delete response", readErr.Error())
+		return
+	}
+
+	// Treat 404 as already deleted.
+	if httpResp.StatusCode == http.StatusNotFound {
+		tflog.Trace(ctx, "snapshot already deleted", map[string]any{
+			"snapshot": data.ID.ValueString(),
+		})
+		return
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		resp.Diagnostics.AddError(
+			"Error deleting snapshot",
+			fmt.Sprintf("OpenSearch returned %d: %s", httpResp.StatusCode, string(body)),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Snapshot resource", map[string]any{
+		"snapshot": data.ID.ValueString(),
+	})
+}
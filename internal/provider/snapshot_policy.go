@@ -0,0 +1,364 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &SnapshotPolicyResource{}
+	_ resource.ResourceWithImportState = &SnapshotPolicyResource{}
+	_ resource.ResourceWithModifyPlan  = &SnapshotPolicyResource{}
+)
+
+// NewSnapshotPolicyResource is a helper function to simplify the provider implementation.
+func NewSnapshotPolicyResource() resource.Resource {
+	return &SnapshotPolicyResource{}
+}
+
+// SnapshotPolicyResource is the resource implementation.
+type SnapshotPolicyResource struct {
+	providerData *ProviderData
+}
+
+// SnapshotPolicyModel describes the Snapshot Policy resource data model.
+type SnapshotPolicyModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Body        JSONBody     `tfsdk:"body"`
+	SeqNo       types.Int64  `tfsdk:"seq_no"`
+	PrimaryTerm types.Int64  `tfsdk:"primary_term"`
+}
+
+// Metadata returns the resource type name.
+func (r *SnapshotPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_snapshot_policy", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Snapshot Policy resource.
+func (r *SnapshotPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Snapshot Management (SM) policy, which takes snapshots on a schedule and deletes them once they expire.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the policy.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the policy.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"body": schema.StringAttribute{
+				MarkdownDescription: "A JSON payload which defines the policy, e.g. its `creation` schedule, `deletion` conditions, `snapshot_config` with the `repository` and `enabled`. " +
+					"Only the fields given here are checked for drift.",
+				Required:   true,
+				CustomType: JSONBodyType{},
+				PlanModifiers: []planmodifier.String{
+					UseStateForSemanticallyEqualJSON(),
+				},
+				Validators: []validator.String{
+					JSONBodyFields("snapshot policy", skpropensearch.SnapshotPolicyBodyFields),
+				},
+			},
+			"seq_no": schema.Int64Attribute{
+				MarkdownDescription: "Sequence number of the policy, updates only apply when it is unchanged since the policy was last read.",
+				Computed:            true,
+			},
+			"primary_term": schema.Int64Attribute{
+				MarkdownDescription: "Primary term of the policy, updates only apply when it is unchanged since the policy was last read.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *SnapshotPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *SnapshotPolicyResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// ModifyPlan rejects the resource when the provider is configured for OpenSearch Serverless.
+func (r *SnapshotPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.providerData.serverlessDiagnostics("opensearch_snapshot_policy")...)
+}
+
+// Create creates the snapshot policy.
+func (r *SnapshotPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SnapshotPolicyModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	// Policies are created with POST and updated with PUT.
+	policy, err := putSnapshotPolicy(ctx, client, "POST", fmt.Sprintf("/_plugins/_sm/policies/%s", data.Name.ValueString()), data.Body.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating snapshot policy",
+			fmt.Sprintf("Could not create snapshot policy %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+	data.SeqNo = types.Int64Value(policy.SeqNo)
+	data.PrimaryTerm = types.Int64Value(policy.PrimaryTerm)
+
+	tflog.Trace(ctx, "created Snapshot Policy resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read compares the snapshot policy in OpenSearch with the state and refreshes its sequence number and primary term.
+func (r *SnapshotPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SnapshotPolicyModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "GET", fmt.Sprintf("/_plugins/_sm/policies/%s", data.Name.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading snapshot policy", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if status == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error reading snapshot policy",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	var policy skpropensearch.SnapshotPolicyResponse
+
+	if err := json.Unmarshal(body, &policy); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing snapshot policy response",
+			fmt.Sprintf("Could not parse snapshot policy response: %s", err.Error()),
+		)
+		return
+	}
+
+	policyBody, err := readBackSnapshotPolicyBody(data.Body.ValueString(), policy.SMPolicy)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading snapshot policy",
+			fmt.Sprintf("Could not compare snapshot policy %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+	data.Body = NewJSONBodyValue(policyBody)
+	data.SeqNo = types.Int64Value(policy.SeqNo)
+	data.PrimaryTerm = types.Int64Value(policy.PrimaryTerm)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update replaces the snapshot policy, only when it hasn't changed since it was last read.
+func (r *SnapshotPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state SnapshotPolicyModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	updatePath := fmt.Sprintf("/_plugins/_sm/policies/%s?if_seq_no=%d&if_primary_term=%d",
+		data.Name.ValueString(), state.SeqNo.ValueInt64(), state.PrimaryTerm.ValueInt64())
+
+	policy, err := putSnapshotPolicy(ctx, client, "PUT", updatePath, data.Body.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating snapshot policy",
+			fmt.Sprintf("Could not update snapshot policy %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+	data.SeqNo = types.Int64Value(policy.SeqNo)
+	data.PrimaryTerm = types.Int64Value(policy.PrimaryTerm)
+
+	tflog.Trace(ctx, "updated Snapshot Policy resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the snapshot policy. Snapshots it took are kept.
+func (r *SnapshotPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SnapshotPolicyModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "DELETE", fmt.Sprintf("/_plugins/_sm/policies/%s", data.Name.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting snapshot policy", err.Error())
+		return
+	}
+
+	// Treat 404 as already deleted.
+	if status == http.StatusNotFound {
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error deleting snapshot policy",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Snapshot Policy resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+}
+
+// ImportState imports a snapshot policy by its name, reading its whole body back from OpenSearch.
+func (r *SnapshotPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
+
+// Creates or updates a snapshot policy, returning its new sequence number and primary term.
+// A 409 means the policy changed since the sequence number in the path was read.
+func putSnapshotPolicy(ctx context.Context, client *opensearchapi.Client, method, policyPath, body string) (skpropensearch.SnapshotPolicyResponse, error) {
+	var policy skpropensearch.SnapshotPolicyResponse
+
+	status, respBody, err := performJSONRequest(ctx, client, method, policyPath, []byte(body))
+	if err != nil {
+		return policy, err
+	}
+
+	if status == http.StatusConflict {
+		return policy, fmt.Errorf("the policy was changed outside Terraform since it was last read, run terraform refresh and apply again: %s", string(respBody))
+	}
+
+	if status < 200 || status >= 300 {
+		return policy, fmt.Errorf("OpenSearch returned %d: %s", status, string(respBody))
+	}
+
+	if err := json.Unmarshal(respBody, &policy); err != nil {
+		return policy, fmt.Errorf("could not parse snapshot policy response: %w", err)
+	}
+
+	return policy, nil
+}
+
+// Returns the policy body from state when OpenSearch still has it, ignoring fields the state doesn't set
+// (e.g. defaults OpenSearch adds to the snapshot config). An empty state (i.e. an import) gets the whole policy
+// without its server managed fields.
+func readBackSnapshotPolicyBody(state string, remote json.RawMessage) (string, error) {
+	var policy map[string]any
+
+	if err := json.Unmarshal(remote, &policy); err != nil {
+		return "", err
+	}
+
+	for _, field := range skpropensearch.SnapshotPolicyServerManagedFields {
+		delete(policy, field)
+	}
+
+	return readBackJSONSubset(state, policy)
+}
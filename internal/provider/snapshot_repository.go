@@ -0,0 +1,357 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &SnapshotRepositoryResource{}
+	_ resource.ResourceWithModifyPlan  = &SnapshotRepositoryResource{}
+	_ resource.ResourceWithImportState = &SnapshotRepositoryResource{}
+)
+
+// NewSnapshotRepositoryResource is a helper function to simplify the provider implementation.
+func NewSnapshotRepositoryResource() resource.Resource {
+	return &SnapshotRepositoryResource{}
+}
+
+// SnapshotRepositoryResource is the resource implementation.
+type SnapshotRepositoryResource struct {
+	providerData *ProviderData
+}
+
+// SnapshotRepositoryModel describes the Snapshot Repository resource data model.
+type SnapshotRepositoryModel struct {
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Settings JSONBody     `tfsdk:"settings"`
+}
+
+// Metadata returns the resource type name.
+func (r *SnapshotRepositoryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_snapshot_repository", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Snapshot Repository resource.
+func (r *SnapshotRepositoryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Registers a snapshot repository, e.g. an S3 bucket, which snapshots are stored in. " +
+			"Deleting the repository only unregisters it, the snapshots it holds are kept.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the snapshot repository.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the snapshot repository.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the snapshot repository, e.g. `s3` or `fs`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"settings": schema.StringAttribute{
+				MarkdownDescription: "A JSON object of the repository settings, e.g. `bucket` and `base_path` for `s3` or `location` for `fs`. " +
+					"Changes register the repository again in place, which suits settings such as `max_restore_bytes_per_sec`. " +
+					"Only the settings given here are checked for drift.",
+				Required:   true,
+				CustomType: JSONBodyType{},
+				PlanModifiers: []planmodifier.String{
+					UseStateForSemanticallyEqualJSON(),
+				},
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *SnapshotRepositoryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *SnapshotRepositoryResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// ModifyPlan rejects the resource when the provider is configured for OpenSearch Serverless.
+func (r *SnapshotRepositoryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when destroying.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.providerData.serverlessDiagnostics("opensearch_snapshot_repository")...)
+}
+
+// Create registers the snapshot repository.
+func (r *SnapshotRepositoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SnapshotRepositoryModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := putSnapshotRepository(ctx, client, data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating snapshot repository",
+			fmt.Sprintf("Could not create snapshot repository %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+
+	tflog.Trace(ctx, "created Snapshot Repository resource", map[string]any{
+		"name": data.Name.ValueString(),
+		"type": data.Type.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read compares the snapshot repository in OpenSearch with the state.
+func (r *SnapshotRepositoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SnapshotRepositoryModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	status, body, err := performJSONRequest(ctx, client, "GET", fmt.Sprintf("/_snapshot/%s", name), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading snapshot repository", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if status == http.StatusNotFound {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error reading snapshot repository",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	var getResponse skpropensearch.SnapshotRepositoryGetResponse
+
+	if err := json.Unmarshal(body, &getResponse); err != nil {
+		resp.Diagnostics.AddError(
+			"Error parsing snapshot repository response",
+			fmt.Sprintf("Could not parse snapshot repository response: %s", err.Error()),
+		)
+		return
+	}
+
+	repository, ok := getResponse[name]
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	var actual map[string]any
+
+	if len(repository.Settings) > 0 {
+		if err := json.Unmarshal(repository.Settings, &actual); err != nil {
+			resp.Diagnostics.AddError(
+				"Error parsing snapshot repository response",
+				fmt.Sprintf("Could not parse the settings of snapshot repository %s: %s", name, err.Error()),
+			)
+			return
+		}
+	}
+
+	if actual == nil {
+		actual = map[string]any{}
+	}
+
+	// OpenSearch reports every setting as a string, which readBackJSONSubset treats as equal to numbers and booleans.
+	settings, err := readBackJSONSubset(data.Settings.ValueString(), actual)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading snapshot repository",
+			fmt.Sprintf("Could not compare the settings of snapshot repository %s: %s", name, err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+	data.Type = types.StringValue(repository.Type)
+	data.Settings = NewJSONBodyValue(settings)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update registers the snapshot repository again with the new settings, the type never changes in place.
+func (r *SnapshotRepositoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SnapshotRepositoryModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := putSnapshotRepository(ctx, client, data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating snapshot repository",
+			fmt.Sprintf("Could not update snapshot repository %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "updated Snapshot Repository resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete unregisters the snapshot repository, the snapshots stored in it are kept.
+func (r *SnapshotRepositoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SnapshotRepositoryModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "DELETE", fmt.Sprintf("/_snapshot/%s", data.Name.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting snapshot repository", err.Error())
+		return
+	}
+
+	// Treat 404 as already deleted.
+	if status == http.StatusNotFound {
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error deleting snapshot repository",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Snapshot Repository resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+}
+
+// ImportState imports a snapshot repository by its name, reading its type and all of its settings back from OpenSearch.
+func (r *SnapshotRepositoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
+
+// Registers the snapshot repository, or registers it again when it exists.
+func putSnapshotRepository(ctx context.Context, client *opensearchapi.Client, data SnapshotRepositoryModel) error {
+	requestBody, err := json.Marshal(skpropensearch.SnapshotRepository{
+		Type:     data.Type.ValueString(),
+		Settings: json.RawMessage(data.Settings.ValueString()),
+	})
+	if err != nil {
+		return err
+	}
+
+	status, respBody, err := performJSONRequest(ctx, client, "PUT", fmt.Sprintf("/_snapshot/%s", data.Name.ValueString()), requestBody)
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", status, string(respBody))
+	}
+
+	return nil
+}
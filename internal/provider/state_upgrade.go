@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The schemas of version 0, frozen so state written by it keeps decoding however the current schemas change.
+// Only the attribute types matter for decoding, so descriptions, validators and plan modifiers are left out.
+
+// Version 0 of opensearch_connector.
+var connectorSchemaV0 = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"id":   schema.StringAttribute{Computed: true},
+		"body": schema.StringAttribute{Required: true},
+	},
+}
+
+type connectorModelV0 struct {
+	ID   types.String `tfsdk:"id"`
+	Body types.String `tfsdk:"body"`
+}
+
+// Version 0 of opensearch_model_group.
+var modelGroupSchemaV0 = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"id":          schema.StringAttribute{Computed: true},
+		"name":        schema.StringAttribute{Required: true},
+		"description": schema.StringAttribute{Required: true},
+	},
+}
+
+type modelGroupModelV0 struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+// Version 0 of opensearch_model_register.
+var modelRegisterSchemaV0 = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"model_id": schema.StringAttribute{Computed: true},
+		"body":     schema.StringAttribute{Required: true},
+	},
+}
+
+type modelRegisterModelV0 struct {
+	ModelID types.String `tfsdk:"model_id"`
+	Body    types.String `tfsdk:"body"`
+}
+
+// Version 0 of opensearch_user.
+var internalUserSchemaV0 = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"id":                        schema.StringAttribute{Computed: true},
+		"name":                      schema.StringAttribute{Required: true},
+		"password":                  schema.StringAttribute{Optional: true, Sensitive: true},
+		"description":               schema.StringAttribute{Optional: true},
+		"backend_roles":             schema.ListAttribute{Optional: true, ElementType: types.StringType},
+		"attributes":                schema.MapAttribute{Optional: true, ElementType: types.StringType},
+		"opendistro_security_roles": schema.ListAttribute{Optional: true, ElementType: types.StringType},
+	},
+}
+
+type internalUserModelV0 struct {
+	ID                      types.String            `tfsdk:"id"`
+	Name                    types.String            `tfsdk:"name"`
+	Password                types.String            `tfsdk:"password"`
+	Description             types.String            `tfsdk:"description"`
+	BackendRoles            []types.String          `tfsdk:"backend_roles"`
+	Attributes              map[string]types.String `tfsdk:"attributes"`
+	OpendistroSecurityRoles []types.String          `tfsdk:"opendistro_security_roles"`
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Runs the resource's upgrader for the given version on state with the given prior attribute values.
+func upgradeState(t *testing.T, r resource.ResourceWithUpgradeState, version int64, values map[string]tftypes.Value) tfsdk.State {
+	t.Helper()
+
+	ctx := context.Background()
+
+	upgrader, ok := r.UpgradeState(ctx)[version]
+	if !ok {
+		t.Fatalf("no upgrader for version %d", version)
+	}
+
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attributes := make(map[string]tftypes.Value, len(priorType.AttributeTypes))
+	for name, attributeType := range priorType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+	}
+
+	for name, value := range values {
+		attributes[name] = value
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	req := resource.UpgradeStateRequest{
+		State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: tftypes.NewValue(priorType, attributes)},
+	}
+	resp := &resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+
+	upgrader.StateUpgrader(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	return resp.State
+}
+
+func TestConnectorUpgradeState(t *testing.T) {
+	body := `{"name":"embeddings","protocol":"http","credential":{"key":"secret"}}`
+
+	state := upgradeState(t, &ConnectorResource{}, 0, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "connector-1"),
+		"body": tftypes.NewValue(tftypes.String, body),
+	})
+
+	var data ConnectorModel
+	if diags := state.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("could not read upgraded state: %v", diags)
+	}
+
+	if data.ID.ValueString() != "connector-1" || data.Body.ValueString() != body {
+		t.Errorf("expected the ID and body to be kept, got %s and %s", data.ID, data.Body)
+	}
+
+	// The fingerprint is computed by the next read, which stores the salt it is keyed with.
+	if !data.CredentialFingerprint.IsNull() || !data.Credential.IsNull() || !data.ResponseHeaders.IsNull() {
+		t.Errorf("expected the attributes added since to be null")
+	}
+}
+
+func TestModelGroupUpgradeState(t *testing.T) {
+	state := upgradeState(t, &ModelGroupResource{}, 0, map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, "group-1"),
+		"name":        tftypes.NewValue(tftypes.String, "search"),
+		"description": tftypes.NewValue(tftypes.String, "Search models"),
+	})
+
+	var data ModelGroupModel
+	if diags := state.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("could not read upgraded state: %v", diags)
+	}
+
+	if data.ID.ValueString() != "group-1" || data.Name.ValueString() != "search" || data.Description.ValueString() != "Search models" {
+		t.Errorf("expected the ID, name and description to be kept, got %s, %s and %s", data.ID, data.Name, data.Description)
+	}
+
+	if data.DeleteReferenceCheck.ValueString() != deleteReferenceCheckNone {
+		t.Errorf("expected delete_reference_check to default to %q, got %s", deleteReferenceCheckNone, data.DeleteReferenceCheck)
+	}
+}
+
+func TestModelRegisterUpgradeState(t *testing.T) {
+	state := upgradeState(t, &ModelRegisterResource{}, 0, map[string]tftypes.Value{
+		"model_id": tftypes.NewValue(tftypes.String, "model-1"),
+		"body":     tftypes.NewValue(tftypes.String, `{"name":"embeddings"}`),
+	})
+
+	var data ModelRegisterModel
+	if diags := state.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("could not read upgraded state: %v", diags)
+	}
+
+	if data.ModelID.ValueString() != "model-1" || data.Body.ValueString() != `{"name":"embeddings"}` {
+		t.Errorf("expected the model ID and body to be kept, got %s and %s", data.ModelID, data.Body)
+	}
+
+	if !data.Enabled.ValueBool() || !data.Deploy.ValueBool() {
+		t.Errorf("expected enabled and deploy to default to true, got %s and %s", data.Enabled, data.Deploy)
+	}
+
+	if !data.DeployParameters.IsNull() || !data.WorkerNodes.IsNull() {
+		t.Errorf("expected the attributes added since to be null")
+	}
+}
+
+func TestInternalUserUpgradeState(t *testing.T) {
+	state := upgradeState(t, &InternalUserResource{}, 0, map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.String, "analyst"),
+		"name":     tftypes.NewValue(tftypes.String, "analyst"),
+		"password": tftypes.NewValue(tftypes.String, "secret"),
+		"backend_roles": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "analysts"),
+		}),
+	})
+
+	var data InternalUserModel
+	if diags := state.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("could not read upgraded state: %v", diags)
+	}
+
+	if data.ID.ValueString() != "analyst" || data.Name.ValueString() != "analyst" || len(data.BackendRoles) != 1 {
+		t.Errorf("expected the ID, name and backend roles to be kept, got %s, %s and %v", data.ID, data.Name, data.BackendRoles)
+	}
+
+	if !data.Password.IsNull() || !data.PasswordVersion.IsNull() {
+		t.Errorf("expected the password to be dropped, got %s and version %s", data.Password, data.PasswordVersion)
+	}
+}
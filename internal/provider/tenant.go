@@ -0,0 +1,332 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	skpropensearch "github.com/skpr/terraform-provider-opensearch/internal/opensearch"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &TenantResource{}
+	_ resource.ResourceWithImportState = &TenantResource{}
+)
+
+// NewTenantResource is a helper function to simplify the provider implementation.
+func NewTenantResource() resource.Resource {
+	return &TenantResource{}
+}
+
+// TenantResource is the resource implementation.
+type TenantResource struct {
+	providerData *ProviderData
+}
+
+// TenantModel describes the Tenant resource data model.
+type TenantModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+// Metadata returns the resource type name.
+func (r *TenantResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_tenant", req.ProviderTypeName)
+}
+
+// Schema defines the schema for the Tenant resource.
+func (r *TenantResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a tenant of the security plugin, used for OpenSearch Dashboards multi-tenancy. " +
+			"Grant access to it with `tenant_permissions` of `opensearch_role`. Reserved, static and hidden tenants, e.g. `global_tenant`, can't be managed.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the tenant.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the tenant.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the tenant.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// Configure prepares the OpenSearch client for data sources and resources.
+func (r *TenantResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.providerData = providerData
+}
+
+// Returns the OpenSearch client shared by the provider.
+func (r *TenantResource) client() (*opensearchapi.Client, error) {
+	return r.providerData.client()
+}
+
+// Create creates the tenant, unless a reserved, static or hidden tenant of the same name exists.
+func (r *TenantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TenantModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := putTenant(ctx, client, data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating tenant",
+			fmt.Sprintf("Could not create tenant %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = data.Name
+
+	tflog.Trace(ctx, "created Tenant resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read refreshes the tenant from OpenSearch.
+func (r *TenantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TenantModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	tenant, found, err := getTenant(ctx, client, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading tenant", err.Error())
+		return
+	}
+
+	// If it’s gone, tell Terraform to drop it from state.
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = data.Name
+	data.Description = readBackOptionalString(data.Description, tenant.Description)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update replaces the description of the tenant in place.
+func (r *TenantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data TenantModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	if err := putTenant(ctx, client, data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating tenant",
+			fmt.Sprintf("Could not update tenant %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "updated Tenant resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete removes the tenant, along with the saved objects stored in it.
+func (r *TenantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TenantModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.client()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating OpenSearch client",
+			fmt.Sprintf("Could not create OpenSearch client: %s", err.Error()),
+		)
+		return
+	}
+
+	existing, found, err := getTenant(ctx, client, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting tenant", err.Error())
+		return
+	}
+
+	// Nothing to delete.
+	if !found {
+		return
+	}
+
+	if err := securityObjectReadOnlyError("tenant", existing.SecurityObjectFlags); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting tenant",
+			fmt.Sprintf("Could not delete tenant %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "DELETE", securityAPIPath("tenants", data.Name.ValueString()), nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting tenant", err.Error())
+		return
+	}
+
+	// Treat 404 as already deleted.
+	if status == http.StatusNotFound {
+		return
+	}
+
+	if status < 200 || status >= 300 {
+		resp.Diagnostics.AddError(
+			"Error deleting tenant",
+			fmt.Sprintf("OpenSearch returned %d: %s", status, string(body)),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted Tenant resource", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+}
+
+// ImportState imports a tenant by its name.
+func (r *TenantResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
+
+// Returns the tenant and whether it exists.
+func getTenant(ctx context.Context, client *opensearchapi.Client, name string) (skpropensearch.TenantGetItem, bool, error) {
+	var tenant skpropensearch.TenantGetItem
+
+	status, body, err := performJSONRequest(ctx, client, "GET", securityAPIPath("tenants", name), nil)
+	if err != nil {
+		return tenant, false, err
+	}
+
+	if status == http.StatusNotFound {
+		return tenant, false, nil
+	}
+
+	if status < 200 || status >= 300 {
+		return tenant, false, fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	var getResponse skpropensearch.TenantGetResponse
+
+	if err := json.Unmarshal(body, &getResponse); err != nil {
+		return tenant, false, fmt.Errorf("could not parse tenant response: %w", err)
+	}
+
+	tenant, found := getResponse[name]
+
+	return tenant, found, nil
+}
+
+// Creates or replaces the tenant. A reserved, static or hidden tenant is never changed, see securityObjectReadOnlyError.
+func putTenant(ctx context.Context, client *opensearchapi.Client, data TenantModel) error {
+	name := data.Name.ValueString()
+
+	existing, found, err := getTenant(ctx, client, name)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		if err := securityObjectReadOnlyError("tenant", existing.SecurityObjectFlags); err != nil {
+			return err
+		}
+	}
+
+	requestBody, err := json.Marshal(skpropensearch.Tenant{
+		Description: data.Description.ValueString(),
+	})
+	if err != nil {
+		return err
+	}
+
+	status, body, err := performJSONRequest(ctx, client, "PUT", securityAPIPath("tenants", name), requestBody)
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("OpenSearch returned %d: %s", status, string(body))
+	}
+
+	return nil
+}
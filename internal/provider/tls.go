@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLS renegotiation settings of the tls_renegotiation attribute. Renegotiation only exists up to TLS 1.2.
+var tlsRenegotiationSupport = map[string]tls.RenegotiationSupport{
+	"never":  tls.RenegotiateNever,
+	"once":   tls.RenegotiateOnceAsClient,
+	"freely": tls.RenegotiateFreelyAsClient,
+}
+
+// Returns the TLS configuration for connecting to OpenSearch, or nil to use the system defaults.
+func (m OpenSearchProviderModel) tlsConfig() (*tls.Config, error) {
+	config, err := m.tlsVerifyConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// Go refuses renegotiation by default, which servers asking for a client certificate after the handshake need.
+	if renegotiation := tlsRenegotiationSupport[m.TLSRenegotiation.ValueString()]; renegotiation != tls.RenegotiateNever {
+		if config == nil {
+			config = &tls.Config{}
+		}
+
+		config.Renegotiation = renegotiation
+	}
+
+	return config, nil
+}
+
+// Returns the TLS configuration for verifying OpenSearch, or nil to verify it against the system CAs.
+func (m OpenSearchProviderModel) tlsVerifyConfig() (*tls.Config, error) {
+	if m.Insecure.ValueBool() {
+		return &tls.Config{InsecureSkipVerify: true}, nil // For testing only. Use certificate for validation.
+	}
+
+	pem := []byte(m.CACertPEM.ValueString())
+	source := "cacert_pem"
+
+	if file := m.CACertFile.ValueString(); file != "" {
+		var err error
+
+		pem, err = os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("could not read cacert_file: %w", err)
+		}
+
+		source = file
+	}
+
+	if len(pem) == 0 {
+		return nil, nil
+	}
+
+	// Only the given certificates are trusted, so a private CA can't be bypassed by a publicly trusted one.
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM encoded certificates could be parsed from %s", source)
+	}
+
+	return &tls.Config{RootCAs: pool}, nil
+}
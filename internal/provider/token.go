@@ -0,0 +1,20 @@
+package provider
+
+import (
+	"net/http"
+)
+
+// Authenticates requests with a bearer token, for clusters fronted by a gateway expecting one.
+type bearerTokenTransport struct {
+	base  http.RoundTripper
+	token string
+}
+
+// RoundTrip executes the request with the Authorization header set to the bearer token.
+func (t *bearerTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the request they are given.
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+
+	return t.base.RoundTrip(req)
+}